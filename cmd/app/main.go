@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/config/warouter"
 	"exaroton-wa-bot/internal/handler"
 	"exaroton-wa-bot/internal/handler/wahandler"
 	"exaroton-wa-bot/internal/repository"
@@ -63,14 +64,10 @@ func run() {
 		os.Exit(1)
 	}
 
-	// whatsapp client
-	waClient, err := repository.NewWAClient(waDb)
-	if err != nil {
-		slog.Error("failed to create whatsapp bot client", "error", err)
-		os.Exit(1)
-	}
+	// whatsapp clients, one per device
+	waDevices := repository.NewWADevices(waDb)
 
-	repo, err := repository.New(db, waClient)
+	repo, err := repository.New(db, waDevices)
 	if err != nil {
 		slog.Error("failed to create repo", config.KeyLogErr, err)
 		os.Exit(1)
@@ -78,13 +75,25 @@ func run() {
 
 	service := service.New(cfg, db, repo)
 	handler := handler.NewWeb(cfg, service)
-	waHandler := wahandler.NewWAHandler(
-		cfg,
-		waClient,
-		command.NewRegistry(service.WhatsappService, service.ServerSettingsService),
-		service.AuthService,
-		service.ServerSettingsService,
-	)
+	waHandlers := wahandler.NewWaHandlers(func(deviceID uint, wa warouter.WhatsappService) *wahandler.WaHandler {
+		return wahandler.NewWAHandler(
+			cfg,
+			deviceID,
+			wa,
+			command.NewRegistry(cfg, service.WhatsappService, service.ServerSettingsService, service.JobService, service.MessageTemplateService,
+				service.WhatsappRegistrationService, service.RoleService),
+			service.AuthService,
+			service.ServerSettingsService,
+			service.WhatsappService,
+			service.StartVoteService,
+			service.JobService,
+			service.RoleService,
+		)
+	})
+
+	// every opened device gets its own router
+	waDevices.OnOpen(waHandlers.Start)
+	waDevices.OnClose(waHandlers.Stop)
 
 	port, err := strconv.Atoi(cfg.String(config.KeyPort))
 	if err != nil {
@@ -94,26 +103,33 @@ func run() {
 
 	g, ctx := errgroup.WithContext(ctx)
 
+	// reconnects the devices, records their connection and alerts the admins
+	service.WhatsappConnectionService.Watch(ctx)
+
+	if err := service.WhatsappDeviceService.Load(ctx); err != nil {
+		slog.Error("failed to load whatsapp devices", config.KeyLogErr, err)
+		os.Exit(1)
+	}
+
 	autoWaLogin := cfg.Bool(config.KeyAutoWhatsappLogin)
 	if autoWaLogin {
 		slog.Info("auto login whatsapp during startup is enabled")
-		isWaLoggedIn, err := waClient.LoginWithExistingSession(ctx)
+		loggedIn, err := service.WhatsappDeviceService.LoginWithExistingSessions(ctx)
 		if err != nil {
 			slog.Error(fmt.Sprintf("error while logging in whatsapp during startup: %s", err.Error()))
 			os.Exit(1)
 		}
 
-		slog.Info(fmt.Sprintf("whatsapp login status: %t", isWaLoggedIn))
+		slog.Info(fmt.Sprintf("whatsapp logged in devices: %v", loggedIn))
 	}
 
 	g.Go(func() error {
 		slog.Info("server started")
-		waHandler.Run()
 		return handler.RunHTTP(port)
 	})
 
 	// graceful shutdown
-	shutdown := getGracefulShutdown(handler.Router.Server, db, waDb, repo.WhatsappRepo, waHandler)
+	shutdown := getGracefulShutdown(handler.Router.Server, db, waDb, repo.WhatsappRepo, service.WhatsappConnectionService, waHandlers)
 
 	// run server
 	srvErrs := make(chan error, 1)
@@ -137,14 +153,20 @@ func getGracefulShutdown(
 	gormDB *gorm.DB,
 	waDb *config.WhatsappDB,
 	whatsappRepo repository.IWhatsappRepo,
-	waHandler *wahandler.WaHandler,
+	waConnSvc service.IWhatsappConnectionService,
+	waHandlers *wahandler.WaHandlers,
 ) func(reason interface{}) {
 	return func(reason interface{}) {
 		// put services that needs to be gracefully shutdown here...
 		slog.Info("Server shutting down:", "reason", reason)
 
-		if waHandler != nil {
-			waHandler.Stop()
+		if waHandlers != nil {
+			waHandlers.StopAll()
+		}
+
+		// no reconnects while disconnecting
+		if waConnSvc != nil {
+			waConnSvc.Stop()
 		}
 
 		// whatsapp client
@@ -19,6 +19,7 @@ require (
 	github.com/stretchr/testify v1.11.1
 	go.mau.fi/whatsmeow v0.0.0-20260218135554-9cbe80fb25a4
 	golang.org/x/crypto v0.48.0
+	golang.org/x/image v0.36.0
 	golang.org/x/sync v0.19.0
 	gorm.io/driver/sqlite v1.5.7
 	gorm.io/gorm v1.25.12
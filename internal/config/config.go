@@ -45,6 +45,49 @@ const (
 	keyWASQLiteDBPath   = "wa-db.sqlite_db_path"   // string
 	keyWADBLogLevel     = "wa-db.log_level"        // string
 	keyWAClientLogLevel = "wa-db.client_log_level" // string
+
+	KeyBotCommandPrefixes = "bot.command_prefixes" // []string, e.g. ["/", "!"]
+	KeyBotDefaultRole     = "bot.default_role"     // string, role of unassigned users (guest, player, operator, admin)
+
+	// commands rate limit (token bucket), a burst of 0 disables the limit
+	KeyBotRateLimitSenderBurst = "bot.rate_limit.sender_burst" // int, commands a sender can send at once
+	KeyBotRateLimitSenderEvery = "bot.rate_limit.sender_every" // string (time.Duration), a sender gets a command back every
+	KeyBotRateLimitChatBurst   = "bot.rate_limit.chat_burst"   // int, commands a chat can send at once
+	KeyBotRateLimitChatEvery   = "bot.rate_limit.chat_every"   // string (time.Duration), a chat gets a command back every
+
+	// messages dispatch, messages of a chat are handled one at a time in order
+	KeyBotWorkers         = "bot.workers"          // int, messages handled at once
+	KeyBotQueueSize       = "bot.queue_size"       // int, messages waiting for a worker, newer ones are dropped
+	KeyBotCommandTimeout  = "bot.command_timeout"  // string (time.Duration), deadline of a command
+	KeyBotShutdownTimeout = "bot.shutdown_timeout" // string (time.Duration), wait for running commands on shutdown before cancelling them
+	KeyBotEditWindow      = "bot.edit_window"      // string (time.Duration), a command edited within it runs again
+
+	KeyBotMaxMessageLength = "bot.max_message_length" // int, longer replies are split into several messages
+	KeyBotMaxMessageParts  = "bot.max_message_parts"  // int, replies needing more messages are sent as a text file
+
+	KeyBotDefaultLanguage = "bot.default_language" // string, language of the chats without /lang (en, id)
+
+	// /status fetches the info of the servers concurrently
+	KeyBotStatusWorkers     = "bot.status.workers"      // int, servers fetched at once
+	KeyBotStatusCallTimeout = "bot.status.call_timeout" // string (time.Duration), deadline of each server's info call
+
+	// whatsapp connection supervisor, a dropped connection is reconnected with
+	// a delay doubled after each failed attempt
+	KeyWAReconnectMinDelay = "whatsapp.reconnect_min_delay" // string (time.Duration), delay of the first attempt
+	KeyWAReconnectMaxDelay = "whatsapp.reconnect_max_delay" // string (time.Duration), the delay doesn't grow past it
+	KeyWAHistoryRetention  = "whatsapp.history_retention"   // string (time.Duration), connection history older than it is deleted
+
+	KeyWARemoveLeftGroups = "whatsapp.remove_left_groups" // bool, unwhitelist the groups the bot left or was removed from, else they're flagged
+
+	// alerts sent to the admins when a whatsapp session is lost, each channel
+	// is off if not set
+	KeyAlertWebhookURL   = "alerts.webhook_url"   // string, gets a POST with a JSON body
+	KeyAlertSMTPHost     = "alerts.smtp.host"     // string
+	KeyAlertSMTPPort     = "alerts.smtp.port"     // int
+	KeyAlertSMTPUsername = "alerts.smtp.username" // string, no login if empty
+	KeyAlertSMTPPassword = "alerts.smtp.password" // string
+	KeyAlertSMTPFrom     = "alerts.smtp.from"     // string
+	KeyAlertSMTPTo       = "alerts.smtp.to"       // []string
 )
 
 // log keys
@@ -62,6 +105,9 @@ const (
 
 	// method request (string)
 	KeyLogMethod = "method"
+
+	// id of a whatsapp command, shown to the user on errors (string)
+	KeyLogRequestID = "request_id"
 )
 
 // app environment
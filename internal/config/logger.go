@@ -67,11 +67,48 @@ func newSlogger(cfg *Cfg, keyLogLevel string) *slog.Logger {
 		slog.Warn(fmt.Sprintf("(%s) log level not set, using default: %s", keyLogLevel, opt.Level.Level().String()))
 	}
 
+	var handler slog.Handler = slog.NewTextHandler(os.Stdout, opt)
 	if isJsonLog {
-		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, opt)))
-	} else {
-		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, opt)))
+		handler = slog.NewJSONHandler(os.Stdout, opt)
 	}
+	slog.SetDefault(slog.New(&ctxHandler{handler}))
 
 	return slog.Default()
 }
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying a request ID, every log line
+// logged with the returned context (e.g. slog.ErrorContext) includes it.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID of ctx, "" if it has none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// ctxHandler adds the request ID of the context to the log records.
+type ctxHandler struct {
+	slog.Handler
+}
+
+func (h *ctxHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if id := RequestIDFromContext(ctx); id != "" {
+			r.AddAttrs(slog.String(KeyLogRequestID, id))
+		}
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *ctxHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ctxHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h *ctxHandler) WithGroup(name string) slog.Handler {
+	return &ctxHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,183 @@
+package warouter
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultReplyTimeout is how long Confirm waits for a reply.
+const DefaultReplyTimeout = time.Minute
+
+// ReplyHandlerFunc handles the reply to a question, c.Message is the reply.
+type ReplyHandlerFunc func(c *Context) error
+
+// pendingReply is a question waiting for a reply from a sender in a chat.
+type pendingReply struct {
+	h     ReplyHandlerFunc
+	timer *time.Timer
+
+	// the command that asked, the answer goes on with it
+	command   string
+	messageID string
+	answered  []func(reply *Context, err error) // see OnAnswered
+}
+
+// answers accepted as "yes" by Confirm, anything else is a "no".
+var yesAnswers = map[string]bool{
+	"yes": true,
+	"y":   true,
+	"ya":  true,
+	"iya": true,
+	"ok":  true,
+}
+
+// Ask sends a question to the chat, the next message from the same sender in
+// the same chat is routed to h instead of the command handlers. If no reply
+// comes before the timeout, the question is cancelled. Asking again replaces
+// the previous question.
+//
+// The command is deferred (see Defer) until the answer, which is handled as
+// the same command: its reactions go to the command's message, and the
+// OnAnswered hooks are called once it's handled.
+func (c *Context) Ask(question string, timeout time.Duration, h ReplyHandlerFunc) error {
+	if _, err := c.Reply(question); err != nil {
+		return err
+	}
+
+	c.router.expectReply(c.Chat, c.Sender, timeout, c.T(messages.ReplyTimeout), &pendingReply{
+		h:         h,
+		command:   c.Command,
+		messageID: c.ReactionTarget(),
+		answered:  slices.Clone(c.answered),
+	})
+	c.deferred, c.asked = true, true
+
+	return nil
+}
+
+// Confirm asks a yes/no question, onYes is called with the reply's context if
+// the sender answers yes, then the OnConfirmed hooks if it succeeded.
+func (c *Context) Confirm(question string, onYes HandlerFunc) error {
+	hooks := slices.Clone(c.onYes)
+
+	return c.Ask(question, DefaultReplyTimeout, func(reply *Context) error {
+		if !isYes(reply.Message) {
+			_, err := reply.Reply(reply.T(messages.ConfirmCancelled))
+			return err
+		}
+
+		if err := onYes(reply); err != nil {
+			return err
+		}
+
+		for _, f := range hooks {
+			f()
+		}
+
+		return nil
+	})
+}
+
+func isYes(answer string) bool {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	answer = strings.TrimRight(answer, ".!")
+
+	return yesAnswers[answer]
+}
+
+func conversationKey(chat, sender dto.WhatsappJID) string {
+	return chat.String() + "|" + sender.String()
+}
+
+// expectReply routes the next message of sender in chat to p.h, timeoutText
+// is sent if it doesn't come before the timeout.
+func (r *Router) expectReply(chat, sender dto.WhatsappJID, timeout time.Duration, timeoutText string, p *pendingReply) {
+	key := conversationKey(chat, sender)
+
+	r.pendingMu.Lock()
+	defer r.pendingMu.Unlock()
+
+	if old, ok := r.pending[key]; ok {
+		old.timer.Stop()
+	}
+
+	p.timer = time.AfterFunc(timeout, func() {
+		r.pendingMu.Lock()
+		current, ok := r.pending[key]
+		if ok && current == p {
+			delete(r.pending, key)
+		}
+		r.pendingMu.Unlock()
+
+		// already answered or replaced
+		if !ok || current != p {
+			return
+		}
+
+		_, err := r.waSvc.SendMessage(context.Background(), chat, &dto.WhatsappMessage{
+			Conversation: &timeoutText,
+		})
+		if err != nil {
+			slog.Warn("failed to send reply timeout message", "error", err.Error())
+		}
+	})
+
+	r.pending[key] = p
+}
+
+// takeReply removes and returns the pending question of a sender in a chat, nil if there's none.
+func (r *Router) takeReply(chat, sender dto.WhatsappJID) *pendingReply {
+	key := conversationKey(chat, sender)
+
+	r.pendingMu.Lock()
+	defer r.pendingMu.Unlock()
+
+	p, ok := r.pending[key]
+	if !ok {
+		return nil
+	}
+
+	p.timer.Stop()
+	delete(r.pending, key)
+
+	return p
+}
+
+// handleReply routes a message to the pending question of its sender, returns
+// false if there's none. A new command to the bot cancels the question instead.
+func (r *Router) handleReply(c *Context) (bool, error) {
+	// e.g. stickers or images, keep waiting
+	if strings.TrimSpace(c.Message) == "" {
+		return false, nil
+	}
+
+	p := r.takeReply(c.Chat, c.Sender)
+	if p == nil {
+		return false, nil
+	}
+
+	if _, _, _, isCommand := r.parseCommand(c); isCommand {
+		return false, nil
+	}
+
+	c.Command, c.reactTo, c.answered = p.command, p.messageID, p.answered
+
+	// the command already went thru the global middlewares (e.g. the rate
+	// limit), only a panic of the answer's handler must be caught
+	err := runWithTimeout(c, r.cfgDuration(config.KeyBotCommandTimeout, DefaultCommandTimeout), Recover(HandlerFunc(p.h)))
+
+	// asked again, the hooks wait for the next answer
+	if !c.Asked() {
+		for _, f := range p.answered {
+			f(c, err)
+		}
+	}
+
+	return true, err
+}
@@ -0,0 +1,197 @@
+package warouter
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/dto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsYes(t *testing.T) {
+	for _, answer := range []string{"yes", "Yes", " y ", "YA", "iya!", "ok."} {
+		assert.True(t, isYes(answer), answer)
+	}
+
+	for _, answer := range []string{"no", "n", "", "yes please", "nope"} {
+		assert.False(t, isYes(answer), answer)
+	}
+}
+
+func TestRouter_TakeReply(t *testing.T) {
+	r := NewRouter(nil, nil)
+	chat := dto.WhatsappJID{User: "123", Server: "g.us"}
+	sender := dto.WhatsappJID{User: "628", Server: "s.whatsapp.net"}
+	other := dto.WhatsappJID{User: "629", Server: "s.whatsapp.net"}
+
+	r.expectReply(chat, sender, time.Minute, "", &pendingReply{h: func(c *Context) error { return nil }})
+
+	assert.Nil(t, r.takeReply(chat, other), "other senders must not answer")
+	assert.NotNil(t, r.takeReply(chat, sender))
+	assert.Nil(t, r.takeReply(chat, sender), "a question is answered once")
+}
+
+func TestRouter_HandleReply(t *testing.T) {
+	wa := new(fakeWhatsappService)
+	r := NewRouter(nil, wa)
+	chat := dto.WhatsappJID{User: "123", Server: "g.us"}
+	sender := dto.WhatsappJID{User: "628", Server: "s.whatsapp.net"}
+
+	global := 0
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			global++
+			return next(c)
+		}
+	})
+
+	r.expectReply(chat, sender, time.Minute, "", &pendingReply{h: func(c *Context) error { panic("boom") }})
+
+	replied, err := r.handleReply(&Context{Context: context.Background(), iContext: wa, Message: "yes", Chat: chat, Sender: sender, router: r})
+	assert.True(t, replied)
+	assert.ErrorIs(t, err, errs.ErrCommandPanicked, "the reply handler is recovered")
+	assert.Zero(t, global, "the command already went thru the global middlewares")
+}
+
+func TestRouter_ConfirmKeepsRateLimit(t *testing.T) {
+	wa := new(fakeWhatsappService)
+	r := NewRouter(nil, wa)
+	chat := dto.WhatsappJID{User: "123", Server: "g.us"}
+	sender := dto.WhatsappJID{User: "628", Server: "s.whatsapp.net"}
+
+	// like the RateLimit middleware, with a single token
+	tokens := 1
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			if tokens == 0 {
+				return errors.New("rate limited")
+			}
+			tokens--
+			return next(c)
+		}
+	})
+
+	stopped := false
+	r.Register("/stop", func(c *Context) error {
+		return c.Confirm("Sure?", func(reply *Context) error {
+			stopped = true
+			return nil
+		})
+	})
+
+	newCtx := func(message string) *Context {
+		return &Context{
+			Context:  context.Background(),
+			iContext: wa,
+			Message:  message,
+			Chat:     chat,
+			Sender:   sender,
+			Mentions: []dto.WhatsappJID{{User: "628123", Server: "s.whatsapp.net"}},
+			router:   r,
+		}
+	}
+
+	require.NoError(t, r.handleMsgEvent(newCtx("@628123 /stop")))
+	assert.Zero(t, tokens)
+
+	replied, err := r.handleReply(newCtx("yes"))
+	assert.True(t, replied)
+	assert.NoError(t, err, "the confirmation doesn't take a token")
+	assert.True(t, stopped)
+}
+
+func TestRouter_AskDefersCommand(t *testing.T) {
+	wa := new(fakeWhatsappService)
+	r := NewRouter(nil, wa)
+	chat := dto.WhatsappJID{User: "123", Server: "g.us"}
+	sender := dto.WhatsappJID{User: "628", Server: "s.whatsapp.net"}
+
+	// like the StatusReactions middleware
+	var commands []string
+	reactDone := func(c *Context, err error) {
+		if err == nil && !c.Deferred() {
+			assert.NoError(t, c.React("✅"))
+		}
+	}
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			commands = append(commands, c.Command)
+			c.OnAnswered(reactDone)
+			err := next(c)
+			reactDone(c, err)
+			return err
+		}
+	})
+
+	answered := false
+	r.Register("/stop", func(c *Context) error {
+		return c.Confirm("Sure?", func(reply *Context) error {
+			answered = true
+			return nil
+		})
+	})
+
+	newCtx := func(messageID, message string) *Context {
+		return &Context{
+			Context:   context.Background(),
+			iContext:  wa,
+			MessageID: messageID,
+			Message:   message,
+			Chat:      chat,
+			Sender:    sender,
+			Mentions:  []dto.WhatsappJID{{User: "628123", Server: "s.whatsapp.net"}},
+			router:    r,
+		}
+	}
+
+	c := newCtx("m1", "@628123 /stop")
+	assert.NoError(t, r.handleMsgEvent(c))
+	assert.True(t, c.Deferred(), "waits for the answer")
+	assert.Empty(t, wa.reactions)
+
+	replied, err := r.handleReply(newCtx("m2", "yes"))
+	assert.True(t, replied)
+	assert.NoError(t, err)
+	assert.True(t, answered)
+	assert.Equal(t, []string{"m1 ✅"}, wa.reactions, "the answer reacts to the command's message")
+
+	assert.NoError(t, r.handleMsgEvent(newCtx("m3", "@628123 /xyzzy")))
+	assert.Equal(t, []string{"stop", ""}, commands, "the answer goes on with the command, unknown commands have none")
+}
+
+func TestContext_OnConfirmed(t *testing.T) {
+	wa := new(fakeWhatsappService)
+	r := NewRouter(nil, wa)
+	chat := dto.WhatsappJID{User: "123", Server: "g.us"}
+	sender := dto.WhatsappJID{User: "628", Server: "s.whatsapp.net"}
+
+	newCtx := func(message string) *Context {
+		return &Context{Context: context.Background(), iContext: wa, Message: message, Chat: chat, Sender: sender, router: r}
+	}
+
+	for _, tt := range []struct {
+		answer    string
+		onYesErr  error
+		confirmed bool
+	}{
+		{answer: "yes", confirmed: true},
+		{answer: "no"},
+		{answer: "yes", onYesErr: errors.New("failed")},
+	} {
+		confirmed := false
+		c := newCtx("/stop 1")
+		c.OnConfirmed(func() { confirmed = true })
+
+		assert.NoError(t, c.Confirm("Sure?", func(reply *Context) error { return tt.onYesErr }))
+		assert.True(t, c.Asked())
+		assert.False(t, confirmed, "not before the answer")
+
+		_, err := r.handleReply(newCtx(tt.answer))
+		assert.ErrorIs(t, err, tt.onYesErr)
+		assert.Equal(t, tt.confirmed, confirmed, tt.answer)
+	}
+}
@@ -0,0 +1,156 @@
+package warouter
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// dispatcher runs the events on a bounded pool of workers. The events of a
+// chat are run one at a time in the order they came in, so e.g. a reply to a
+// question is never handled before the question's command.
+type dispatcher struct {
+	mu         sync.Mutex
+	queues     map[string][]func(ctx context.Context) // key: chat, the chat is active while its key exists
+	ready      chan string                            // active chats waiting for a worker
+	pending    int                                    // queued events, running ones included
+	maxPending int
+	closed     bool
+
+	inFlight sync.WaitGroup // queued events, running ones included
+	workers  sync.WaitGroup
+
+	// ctx is passed to every event, cancelled when the drain timed out
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newDispatcher(workers, maxPending int) *dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	d := &dispatcher{
+		queues:     make(map[string][]func(ctx context.Context)),
+		ready:      make(chan string, maxPending), // never more active chats than queued events
+		maxPending: maxPending,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+
+	d.workers.Add(workers)
+	for range workers {
+		go d.work()
+	}
+
+	return d
+}
+
+// dispatch queues an event of a chat, it returns false if the event was
+// dropped because the dispatcher is closed or full.
+func (d *dispatcher) dispatch(chat string, fn func(ctx context.Context)) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed || d.pending >= d.maxPending {
+		return false
+	}
+
+	queue, active := d.queues[chat]
+	d.queues[chat] = append(queue, fn)
+	d.pending++
+	d.inFlight.Add(1)
+
+	// an active chat is already waiting for or running on a worker
+	if !active {
+		d.ready <- chat
+	}
+
+	return true
+}
+
+func (d *dispatcher) work() {
+	defer d.workers.Done()
+
+	for chat := range d.ready {
+		for fn := d.next(chat, false); fn != nil; fn = d.next(chat, true) {
+			d.run(fn)
+		}
+	}
+}
+
+// next pops the next event of a chat, nil if there's none left, in which case
+// the chat isn't active anymore. done marks the previous event as finished.
+func (d *dispatcher) next(chat string, done bool) func(ctx context.Context) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if done {
+		d.pending--
+		d.inFlight.Done()
+	}
+
+	queue := d.queues[chat]
+	if len(queue) == 0 {
+		delete(d.queues, chat)
+		return nil
+	}
+
+	d.queues[chat] = queue[1:]
+	return queue[0]
+}
+
+func (d *dispatcher) run(fn func(ctx context.Context)) {
+	// the drain timed out, drop the queued events
+	if d.ctx.Err() != nil {
+		return
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			slog.Error("panic in whatsapp event handler", "panic", rec)
+		}
+	}()
+
+	fn(d.ctx)
+}
+
+// shutdown stops accepting events and waits for the queued and running ones
+// up to timeout, then cancels their context and waits for them up to timeout
+// again. It returns false if some events were still running, their workers
+// stop once the events return.
+func (d *dispatcher) shutdown(timeout time.Duration) bool {
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+
+	drained := waitTimeout(&d.inFlight, timeout)
+	if !drained {
+		d.cancel()
+		drained = waitTimeout(&d.inFlight, timeout)
+	}
+	d.cancel()
+
+	// nothing is dispatched anymore, the idle workers stop right away
+	close(d.ready)
+	if drained {
+		d.workers.Wait()
+	}
+
+	return drained
+}
+
+// waitTimeout waits for wg, returns false on timeout.
+func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
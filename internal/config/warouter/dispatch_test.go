@@ -0,0 +1,123 @@
+package warouter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatcher_ChatOrder(t *testing.T) {
+	d := newDispatcher(4, 100)
+
+	var mu sync.Mutex
+	got := make(map[string][]int)
+
+	for i := range 20 {
+		for _, chat := range []string{"a", "b", "c"} {
+			assert.True(t, d.dispatch(chat, func(ctx context.Context) {
+				mu.Lock()
+				defer mu.Unlock()
+				got[chat] = append(got[chat], i)
+			}))
+		}
+	}
+
+	assert.True(t, d.shutdown(time.Second))
+
+	want := make([]int, 20)
+	for i := range want {
+		want[i] = i
+	}
+	for _, chat := range []string{"a", "b", "c"} {
+		assert.Equal(t, want, got[chat], chat)
+	}
+}
+
+func TestDispatcher_Concurrent(t *testing.T) {
+	d := newDispatcher(2, 10)
+
+	// a slow chat doesn't block the others
+	block := make(chan struct{})
+	assert.True(t, d.dispatch("slow", func(ctx context.Context) { <-block }))
+
+	done := make(chan struct{})
+	assert.True(t, d.dispatch("fast", func(ctx context.Context) { close(done) }))
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("fast chat blocked by the slow one")
+	}
+
+	close(block)
+	assert.True(t, d.shutdown(time.Second))
+}
+
+func TestDispatcher_Full(t *testing.T) {
+	d := newDispatcher(1, 2)
+
+	block := make(chan struct{})
+	assert.True(t, d.dispatch("a", func(ctx context.Context) { <-block }))
+	assert.True(t, d.dispatch("b", func(ctx context.Context) {}))
+	assert.False(t, d.dispatch("c", func(ctx context.Context) {}))
+
+	close(block)
+	assert.True(t, d.shutdown(time.Second))
+
+	// closed
+	assert.False(t, d.dispatch("a", func(ctx context.Context) {}))
+}
+
+func TestDispatcher_Panic(t *testing.T) {
+	d := newDispatcher(1, 10)
+
+	ran := false
+	assert.True(t, d.dispatch("a", func(ctx context.Context) { panic("boom") }))
+	assert.True(t, d.dispatch("a", func(ctx context.Context) { ran = true }))
+
+	assert.True(t, d.shutdown(time.Second))
+	assert.True(t, ran)
+}
+
+func TestDispatcher_ShutdownCancels(t *testing.T) {
+	d := newDispatcher(1, 10)
+
+	started := make(chan struct{})
+	var cancelled bool
+	assert.True(t, d.dispatch("a", func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		cancelled = true
+	}))
+
+	// queued behind the running one, dropped once cancelled
+	ran := false
+	assert.True(t, d.dispatch("a", func(ctx context.Context) { ran = true }))
+
+	<-started
+	assert.True(t, d.shutdown(50*time.Millisecond))
+	assert.True(t, cancelled)
+	assert.False(t, ran)
+}
+
+func TestDispatcher_ShutdownTimeout(t *testing.T) {
+	d := newDispatcher(2, 10)
+
+	// ignores its context
+	block := make(chan struct{})
+	assert.True(t, d.dispatch("a", func(ctx context.Context) { <-block }))
+
+	// queued behind it, dropped once it returns
+	ran := false
+	assert.True(t, d.dispatch("a", func(ctx context.Context) { ran = true }))
+
+	assert.False(t, d.shutdown(10*time.Millisecond))
+
+	// the workers don't leak
+	close(block)
+	assert.True(t, waitTimeout(&d.workers, time.Second))
+	assert.False(t, ran)
+}
@@ -0,0 +1,113 @@
+package warouter
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/dto"
+	"time"
+
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// DefaultEditWindow is how long a command can be edited to be handled again.
+const DefaultEditWindow = 2 * time.Minute
+
+// handledCommand is a recently handled command message, see handleEditEvent.
+type handledCommand struct {
+	at     time.Time
+	cancel func() bool // see Context.Defer, nil if not set
+}
+
+func commandKey(chat dto.WhatsappJID, messageID string) string {
+	return chat.String() + "|" + messageID
+}
+
+// rememberCommand remembers a handled command so an edit of its message can
+// be handled, the commands older than the edit window are forgotten.
+func (r *Router) rememberCommand(c *Context) {
+	if c.MessageID == "" || c.Reaction != nil {
+		return
+	}
+
+	now := time.Now()
+	window := r.cfgDuration(config.KeyBotEditWindow, DefaultEditWindow)
+
+	r.commandsMu.Lock()
+	defer r.commandsMu.Unlock()
+
+	for key, cmd := range r.commands {
+		if now.Sub(cmd.at) > window {
+			delete(r.commands, key)
+		}
+	}
+
+	r.commands[commandKey(c.Chat, c.MessageID)] = &handledCommand{at: now, cancel: c.cancel}
+}
+
+// takeCommand removes and returns a command handled within the edit window, nil if there's none.
+func (r *Router) takeCommand(chat dto.WhatsappJID, messageID string) *handledCommand {
+	key := commandKey(chat, messageID)
+
+	r.commandsMu.Lock()
+	defer r.commandsMu.Unlock()
+
+	cmd, ok := r.commands[key]
+	if !ok {
+		return nil
+	}
+	delete(r.commands, key)
+
+	if time.Since(cmd.at) > r.cfgDuration(config.KeyBotEditWindow, DefaultEditWindow) {
+		return nil
+	}
+
+	return cmd
+}
+
+// handleEditEvent handles an edit of a command message within the edit window,
+// e.g. "/strat 1" corrected to "/start 1". If the command is still going on
+// (e.g. its job), the edit only cancels it. Otherwise, the new text is handled
+// as a command, its replies quote the edited message.
+func (r *Router) handleEditEvent(ctx context.Context, v *events.Message) {
+	edit := v.Message.GetProtocolMessage()
+	messageID := edit.GetKey().GetID()
+
+	cmd := r.takeCommand(dto.NewWhatsappJID(v.Info.Chat), messageID)
+	if cmd == nil {
+		return
+	}
+
+	if cmd.cancel != nil && cmd.cancel() {
+		return
+	}
+
+	c := r.newContext(ctx, v, edit.GetEditedMessage())
+	c.MessageID = messageID // e.g. for the reactions, the edit has its own id
+	c.iContext = &quotingSender{
+		iContext: r.waSvc,
+		chat:     c.Chat,
+		quote:    &dto.WhatsappQuote{MessageID: messageID, Sender: c.Sender, Text: c.Message},
+	}
+
+	if err := r.handleMsgEvent(c); err != nil && r.ErrorHandlerFunc != nil {
+		r.ErrorHandlerFunc(c, err)
+	}
+}
+
+// quotingSender quotes a message in the messages sent to its chat, linking the
+// replies of an edited command to it.
+type quotingSender struct {
+	iContext
+	chat  dto.WhatsappJID
+	quote *dto.WhatsappQuote
+}
+
+func (q *quotingSender) SendMessage(ctx context.Context, to dto.WhatsappJID, message *dto.WhatsappMessage) (*dto.WhatsappSendResponse, error) {
+	if to == q.chat && message.Quote == nil {
+		quoted := *message
+		quoted.Quote = q.quote
+		message = &quoted
+	}
+
+	return q.iContext.SendMessage(ctx, to, message)
+}
@@ -0,0 +1,80 @@
+package warouter
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/dto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestRouter_HandleEditEvent(t *testing.T) {
+	wa := new(fakeWhatsappService)
+	r := NewRouter(nil, wa)
+
+	dm := types.JID{User: "6285", Server: types.DefaultUserServer}
+	info := func(id string) types.MessageInfo {
+		return types.MessageInfo{MessageSource: types.MessageSource{Chat: dm, Sender: dm}, ID: id}
+	}
+	message := func(id, text string) *events.Message {
+		return &events.Message{Info: info(id), Message: &waE2E.Message{Conversation: &text}}
+	}
+	edit := func(id, originalID, text string) *events.Message {
+		return &events.Message{Info: info(id), Message: &waE2E.Message{
+			ProtocolMessage: &waE2E.ProtocolMessage{
+				Type:          waE2E.ProtocolMessage_MESSAGE_EDIT.Enum(),
+				Key:           &waCommon.MessageKey{ID: &originalID},
+				EditedMessage: &waE2E.Message{Conversation: &text},
+			},
+		}}
+	}
+
+	var started []string
+	cancelled := 0
+	r.Register("/start", func(c *Context) error {
+		started = append(started, c.Args[0])
+		_, err := c.SendMessage(c, c.Chat, &dto.WhatsappMessage{Conversation: &c.Args[0]})
+		return err
+	})
+	r.Register("/job", func(c *Context) error {
+		c.Defer(func() bool {
+			cancelled++
+			return true
+		})
+		return nil
+	})
+
+	ctx := context.Background()
+
+	// a typo corrected by an edit, the reply quotes the edited message
+	r.handleMessage(ctx, message("m1", "/strat 1"))
+	r.handleMessage(ctx, edit("e1", "m1", "/start 1"))
+	assert.Equal(t, []string{"1"}, started)
+	assert.Equal(t, []string{"m1", "m1"}, wa.quotes) // the unknown command reply, then the corrected one
+	assert.Equal(t, "/start 1", wa.messages[1].Quote.Text)
+
+	// edited again
+	r.handleMessage(ctx, edit("e2", "m1", "/start 2"))
+	assert.Equal(t, []string{"1", "2"}, started)
+
+	// an edit of a running job only cancels it
+	r.handleMessage(ctx, message("m2", "/job"))
+	r.handleMessage(ctx, edit("e3", "m2", "/start 3"))
+	assert.Equal(t, 1, cancelled)
+	assert.Equal(t, []string{"1", "2"}, started)
+
+	// not a command, or too late
+	r.handleMessage(ctx, message("m3", "hello"))
+	r.handleMessage(ctx, edit("e4", "m3", "/start 4"))
+
+	r.handleMessage(ctx, message("m4", "/strat 5"))
+	r.commands[commandKey(dto.NewWhatsappJID(dm), "m4")].at = time.Now().Add(-DefaultEditWindow - time.Second)
+	r.handleMessage(ctx, edit("e5", "m4", "/start 5"))
+
+	assert.Equal(t, []string{"1", "2"}, started)
+}
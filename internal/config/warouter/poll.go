@@ -0,0 +1,184 @@
+package warouter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+const (
+	// DefaultPollTimeout is how long a poll waits for a vote.
+	DefaultPollTimeout = 2 * time.Minute
+
+	// MaxPollOptions is the most options WhatsApp allows in a poll.
+	MaxPollOptions = 12
+)
+
+// PollHandlerFunc handles the vote on a poll, choice is the chosen option.
+type PollHandlerFunc func(c *Context, choice string) error
+
+// pendingPoll is a poll waiting for the vote of the sender who caused it.
+type pendingPoll struct {
+	voters  []dto.WhatsappJID // the sender's jids (e.g. PN and LID)
+	options []string
+	h       PollHandlerFunc
+	timer   *time.Timer
+
+	timeoutText string // sent when no vote comes in time, in the chat's language
+}
+
+// Poll sends a single-choice poll to the chat, the sender's vote is routed to
+// h, the votes of other members are ignored. If no vote comes before the
+// timeout, the poll is closed.
+func (c *Context) Poll(question string, options []string, timeout time.Duration, h PollHandlerFunc) error {
+	sent, err := c.SendPoll(c, c.Chat, question, options)
+	if err != nil {
+		return err
+	}
+
+	voters := []dto.WhatsappJID{c.Sender}
+	if c.SenderAlt.User != "" {
+		voters = append(voters, c.SenderAlt)
+	}
+
+	c.router.expectVote(c.Chat, sent.ID, &pendingPoll{voters: voters, options: options, h: h, timeoutText: c.T(messages.PollTimeout)}, timeout)
+
+	return nil
+}
+
+// RunCommand runs a registered command with args as if the sender had sent
+// it, thru its middlewares, e.g. with the choice of a poll.
+func (c *Context) RunCommand(cmd string, args ...string) error {
+	name := normalizeCommand(cmd)
+
+	h, ok := c.router.lookup(name)
+	if !ok {
+		return fmt.Errorf("%w: /%s", errs.ErrCommandNotFound, name)
+	}
+
+	c.Command = c.router.resolve(name)
+	c.Args = args
+	return runWithTimeout(c, c.router.timeoutOf(name), h)
+}
+
+func (r *Router) expectVote(chat dto.WhatsappJID, pollID string, p *pendingPoll, timeout time.Duration) {
+	r.pollsMu.Lock()
+	defer r.pollsMu.Unlock()
+
+	p.timer = time.AfterFunc(timeout, func() {
+		// already voted
+		if r.takePoll(chat, pollID) == nil {
+			return
+		}
+
+		_, err := r.waSvc.SendMessage(context.Background(), chat, &dto.WhatsappMessage{
+			Conversation: &p.timeoutText,
+		})
+		if err != nil {
+			slog.Warn("failed to send poll timeout message", "error", err.Error())
+		}
+	})
+
+	r.polls[commandKey(chat, pollID)] = p
+}
+
+// peekPoll returns the pending poll of a message, nil if there's none.
+func (r *Router) peekPoll(chat dto.WhatsappJID, pollID string) *pendingPoll {
+	r.pollsMu.Lock()
+	defer r.pollsMu.Unlock()
+
+	return r.polls[commandKey(chat, pollID)]
+}
+
+// takePoll removes and returns the pending poll of a message, nil if there's none.
+func (r *Router) takePoll(chat dto.WhatsappJID, pollID string) *pendingPoll {
+	key := commandKey(chat, pollID)
+
+	r.pollsMu.Lock()
+	defer r.pollsMu.Unlock()
+
+	p, ok := r.polls[key]
+	if !ok {
+		return nil
+	}
+
+	p.timer.Stop()
+	delete(r.polls, key)
+
+	return p
+}
+
+// handlePollVote routes a vote on a pending poll to its handler, the poll is
+// closed by the first vote for an option.
+func (r *Router) handlePollVote(ctx context.Context, v *events.Message) {
+	chat := dto.NewWhatsappJID(v.Info.Chat)
+	pollID := v.Message.GetPollUpdateMessage().GetPollCreationMessageKey().GetID()
+
+	p := r.peekPoll(chat, pollID)
+	if p == nil || !p.isVoter(dto.NewWhatsappJID(v.Info.Sender), dto.NewWhatsappJID(v.Info.SenderAlt)) {
+		return
+	}
+
+	selected, err := r.waSvc.DecryptPollVote(ctx, v)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to decrypt poll vote", "error", err.Error())
+		return
+	}
+
+	// e.g. the vote was removed
+	choice, ok := p.choice(selected)
+	if !ok {
+		return
+	}
+
+	// voted twice at once
+	if r.takePoll(chat, pollID) == nil {
+		return
+	}
+
+	c := r.newContext(ctx, v, nil)
+	c.MessageID = "" // votes aren't shown in the chat, nothing to react to or quote
+	c.Message = choice
+
+	err = runWithTimeout(c, r.cfgDuration(config.KeyBotCommandTimeout, DefaultCommandTimeout), func(c *Context) error {
+		return p.h(c, choice)
+	})
+	if err != nil && r.ErrorHandlerFunc != nil {
+		r.ErrorHandlerFunc(c, err)
+	}
+}
+
+func (p *pendingPoll) isVoter(jids ...dto.WhatsappJID) bool {
+	for _, jid := range jids {
+		for _, voter := range p.voters {
+			if jid.User != "" && jid.User == voter.User && jid.Server == voter.Server {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// choice returns the option of the selected hashes, false if none is selected.
+func (p *pendingPoll) choice(selected [][]byte) (string, bool) {
+	for _, option := range p.options {
+		hash := sha256.Sum256([]byte(option))
+		for _, s := range selected {
+			if bytes.Equal(s, hash[:]) {
+				return option, true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,124 @@
+package warouter
+
+import (
+	"context"
+	"crypto/sha256"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestContext_Poll(t *testing.T) {
+	wa := new(fakeWhatsappService)
+	r := NewRouter(nil, wa)
+
+	group := types.JID{User: "1203", Server: types.GroupServer}
+	alice := types.JID{User: "6285", Server: types.DefaultUserServer}
+	bob := types.JID{User: "6286", Server: types.DefaultUserServer}
+
+	// mentioning the bot
+	message := func(sender types.JID, text string) *events.Message {
+		text = "@628123 " + text
+		return &events.Message{
+			Info: types.MessageInfo{MessageSource: types.MessageSource{Chat: group, Sender: sender, IsGroup: true}, ID: "m1"},
+			Message: &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+				Text:        &text,
+				ContextInfo: &waE2E.ContextInfo{MentionedJID: []string{"628123@s.whatsapp.net"}},
+			}},
+		}
+	}
+	vote := func(voter types.JID, pollID string, options ...string) *events.Message {
+		var selected [][]byte
+		for _, option := range options {
+			hash := sha256.Sum256([]byte(option))
+			selected = append(selected, hash[:])
+		}
+		wa.votes = append(wa.votes, selected)
+
+		return &events.Message{
+			Info: types.MessageInfo{MessageSource: types.MessageSource{Chat: group, Sender: voter, IsGroup: true}, ID: "v1"},
+			Message: &waE2E.Message{PollUpdateMessage: &waE2E.PollUpdateMessage{
+				PollCreationMessageKey: &waCommon.MessageKey{ID: &pollID},
+			}},
+		}
+	}
+
+	var started []string
+	r.Register("/start", func(c *Context) error {
+		started = append(started, c.Args[0])
+		return nil
+	})
+	r.Register("/pick", func(c *Context) error {
+		return c.Poll("Which one?", []string{"#0 a", "#1 b"}, time.Minute, func(c *Context, choice string) error {
+			return c.RunCommand("/start", choice[1:2])
+		})
+	})
+
+	ctx := context.Background()
+	r.handleMessage(ctx, message(alice, "/pick"))
+	require.Equal(t, []string{"Which one? #0 a,#1 b"}, wa.polls)
+
+	// other members' votes are ignored, without decrypting them
+	r.handleMessage(ctx, vote(bob, "poll1", "#0 a"))
+	assert.Empty(t, started)
+	assert.Len(t, wa.votes, 1)
+	wa.votes = nil
+
+	// a removed vote doesn't choose
+	r.handleMessage(ctx, vote(alice, "poll1"))
+	assert.Empty(t, started)
+
+	r.handleMessage(ctx, vote(alice, "poll1", "#1 b"))
+	assert.Equal(t, []string{"1"}, started)
+
+	// the poll is closed by the first vote
+	r.handleMessage(ctx, vote(alice, "poll1", "#0 a"))
+	assert.Equal(t, []string{"1"}, started)
+	assert.Nil(t, r.peekPoll(dto.NewWhatsappJID(group), "poll1"))
+}
+
+func TestContext_Poll_Timeout(t *testing.T) {
+	wa := new(fakeWhatsappService)
+	r := NewRouter(nil, wa)
+
+	c := &Context{Context: context.Background(), iContext: wa, router: r, Chat: dto.NewWhatsappJID(types.JID{User: "6285", Server: types.DefaultUserServer})}
+	err := c.Poll("Which one?", []string{"a", "b"}, time.Millisecond, func(c *Context, choice string) error {
+		t.Error("no vote expected")
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		wa.mu.Lock()
+		defer wa.mu.Unlock()
+
+		return len(wa.sent) == 1 && wa.sent[0] == i18n.T(i18n.Default, messages.PollTimeout)
+	}, time.Second, time.Millisecond)
+	assert.Nil(t, r.peekPoll(c.Chat, "poll1"))
+}
+
+func TestContext_RunCommand(t *testing.T) {
+	r := NewRouter(nil, new(fakeWhatsappService))
+
+	var args []string
+	r.Register("/start", func(c *Context) error {
+		args = c.Args
+		return nil
+	})
+
+	c := &Context{Context: context.Background(), router: r, Args: []string{"ignored"}}
+	require.NoError(t, c.RunCommand("/start", "2"))
+	assert.Equal(t, []string{"2"}, args)
+
+	assert.ErrorIs(t, c.RunCommand("/stop"), errs.ErrCommandNotFound)
+}
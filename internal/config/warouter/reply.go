@@ -0,0 +1,97 @@
+package warouter
+
+import (
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+)
+
+// Reply sends a text to the chat, quoting the message that triggered the
+// context. The mentioned users are notified, the text should contain their "@user".
+//
+// A long text is split into several messages (see config.KeyBotMaxMessageLength),
+// only the first one quotes. If it needs too many, it's sent as a text file instead.
+func (c *Context) Reply(text string, mentions ...dto.WhatsappJID) (*dto.WhatsappSendResponse, error) {
+	parts := splitMessage(text, c.router.cfgInt(config.KeyBotMaxMessageLength, DefaultMaxMessageLength))
+	if len(parts) > c.router.cfgInt(config.KeyBotMaxMessageParts, DefaultMaxMessageParts) {
+		return c.ReplyDocument([]byte(text), "reply.txt", "text/plain", c.T(messages.ReplyAsDocument))
+	}
+
+	var first *dto.WhatsappSendResponse
+	for i, part := range parts {
+		message := &dto.WhatsappMessage{
+			Conversation: &part,
+			Mentions:     mentions,
+		}
+		if i == 0 {
+			message.Quote = c.quote()
+		}
+
+		res, err := c.SendMessage(c, c.Chat, message)
+		if err != nil {
+			return first, err
+		}
+
+		if first == nil {
+			first = res
+		}
+	}
+
+	return first, nil
+}
+
+// ReplyDocument sends a file to the chat, quoting the message that triggered
+// the context. The caption is optional.
+func (c *Context) ReplyDocument(data []byte, fileName, mimeType, caption string) (*dto.WhatsappSendResponse, error) {
+	return c.SendMessage(c, c.Chat, &dto.WhatsappMessage{
+		Conversation: optionalText(caption),
+		Quote:        c.quote(),
+		Document: &dto.WhatsappDocument{
+			WhatsappMedia: dto.WhatsappMedia{Data: data, MimeType: mimeType},
+			FileName:      fileName,
+		},
+	})
+}
+
+// ReplyImage sends an image (e.g. "image/png") to the chat, quoting the
+// message that triggered the context. The caption is optional.
+func (c *Context) ReplyImage(data []byte, mimeType, caption string) (*dto.WhatsappSendResponse, error) {
+	return c.SendMessage(c, c.Chat, &dto.WhatsappMessage{
+		Conversation: optionalText(caption),
+		Quote:        c.quote(),
+		Image: &dto.WhatsappImage{
+			WhatsappMedia: dto.WhatsappMedia{Data: data, MimeType: mimeType},
+		},
+	})
+}
+
+// ReplyLocation sends a location to the chat, quoting the message that
+// triggered the context.
+func (c *Context) ReplyLocation(location dto.WhatsappLocation) (*dto.WhatsappSendResponse, error) {
+	return c.SendMessage(c, c.Chat, &dto.WhatsappMessage{
+		Quote:    c.quote(),
+		Location: &location,
+	})
+}
+
+// quote returns the quote of the message that triggered the context, nil for
+// reactions (the reacted message is the bot's).
+func (c *Context) quote() *dto.WhatsappQuote {
+	if c.Reaction != nil || c.MessageID == "" {
+		return nil
+	}
+
+	return &dto.WhatsappQuote{
+		MessageID: c.MessageID,
+		Sender:    c.Sender,
+		Text:      c.Message,
+	}
+}
+
+func optionalText(text string) *string {
+	if text == "" {
+		return nil
+	}
+
+	return &text
+}
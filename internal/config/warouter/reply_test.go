@@ -0,0 +1,48 @@
+package warouter
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/dto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_Reply(t *testing.T) {
+	wa := new(fakeWhatsappService)
+
+	c := &Context{Context: context.Background(), iContext: wa, MessageID: "abc", Message: "/info 1"}
+	_, err := c.Reply("hi")
+	assert.NoError(t, err)
+
+	// reactions aren't quoted
+	reaction := &Context{Context: context.Background(), iContext: wa, MessageID: "def", Reaction: &Reaction{Emoji: "▶"}}
+	_, err = reaction.Reply("hi")
+	assert.NoError(t, err)
+
+	_, err = c.ReplyDocument([]byte("a,b"), "players.csv", "text/csv", "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"abc", "", "abc"}, wa.quotes)
+	assert.Equal(t, &dto.WhatsappQuote{MessageID: "abc", Text: "/info 1"}, wa.messages[0].Quote)
+
+	doc := wa.messages[2]
+	assert.Nil(t, doc.Conversation, "no caption")
+	assert.Equal(t, "players.csv", doc.Document.FileName)
+	assert.Equal(t, []byte("a,b"), doc.Document.Data)
+}
+
+func TestContext_ReplyImage(t *testing.T) {
+	wa := new(fakeWhatsappService)
+	c := &Context{Context: context.Background(), iContext: wa, MessageID: "abc"}
+
+	_, err := c.ReplyImage([]byte{1}, "image/png", "status")
+	assert.NoError(t, err)
+	_, err = c.ReplyLocation(dto.WhatsappLocation{Latitude: 1, Longitude: 2})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "status", *wa.messages[0].Conversation)
+	assert.Equal(t, "image/png", wa.messages[0].Image.MimeType)
+	assert.Equal(t, 2.0, wa.messages[1].Location.Longitude)
+	assert.Equal(t, []string{"abc", "abc"}, wa.quotes)
+}
@@ -0,0 +1,101 @@
+package warouter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultMaxMessageLength is the length above which a reply is split,
+	// well below the WhatsApp limit to keep every part readable.
+	DefaultMaxMessageLength = 4096
+
+	// DefaultMaxMessageParts is the most messages a reply is split into,
+	// longer replies are sent as a text file.
+	DefaultMaxMessageParts = 3
+
+	codeFence = "```"
+)
+
+// splitMessage splits text into parts of at most limit runes, at line breaks
+// when possible. A code block cut in two is closed and reopened, so both
+// parts keep their monospace formatting.
+func splitMessage(text string, limit int) []string {
+	if utf8.RuneCountInString(text) <= limit {
+		return []string{text}
+	}
+
+	// a part in a code block starts with a reopened fence and ends with a closing one
+	reopen, closing := codeFence+"\n", "\n"+codeFence
+	chunkSize := max(limit-len(reopen)-len(closing), 1)
+
+	var (
+		parts   []string
+		cur     strings.Builder
+		curLen  int
+		inFence bool
+	)
+
+	flush := func() {
+		part := strings.TrimRight(cur.String(), "\n")
+		if inFence {
+			part += closing
+		}
+		if strings.TrimSpace(part) != "" {
+			parts = append(parts, part)
+		}
+
+		cur.Reset()
+		curLen = 0
+		if inFence {
+			cur.WriteString(reopen)
+			curLen = len(reopen)
+		}
+	}
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		for _, chunk := range chunkRunes(line, chunkSize) {
+			n := utf8.RuneCountInString(chunk)
+			toggles := strings.Count(chunk, codeFence)%2 == 1
+
+			// keep room to close the code block the chunk leaves open
+			reserve := 0
+			if inFence != toggles {
+				reserve = len(closing)
+			}
+			// the line break ending a part is trimmed
+			if curLen > 0 && curLen+utf8.RuneCountInString(strings.TrimSuffix(chunk, "\n"))+reserve > limit {
+				flush()
+			}
+
+			cur.WriteString(chunk)
+			curLen += n
+
+			if toggles {
+				inFence = !inFence
+			}
+		}
+	}
+
+	if part := strings.TrimRight(cur.String(), "\n"); strings.TrimSpace(part) != "" {
+		parts = append(parts, part)
+	}
+
+	return parts
+}
+
+// chunkRunes cuts s into chunks of at most size runes.
+func chunkRunes(s string, size int) []string {
+	if utf8.RuneCountInString(s) <= size {
+		return []string{s}
+	}
+
+	var chunks []string
+	runes := []rune(s)
+	for len(runes) > size {
+		chunks = append(chunks, string(runes[:size]))
+		runes = runes[size:]
+	}
+
+	return append(chunks, string(runes))
+}
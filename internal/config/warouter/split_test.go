@@ -0,0 +1,79 @@
+package warouter
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/i18n"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitMessage(t *testing.T) {
+	t.Run("short text", func(t *testing.T) {
+		assert.Equal(t, []string{"hi\nthere"}, splitMessage("hi\nthere", 20))
+	})
+
+	t.Run("splits at line breaks", func(t *testing.T) {
+		text := "aaaaaaaaaa\nbbbbbbbbbb\ncccccccccc"
+		assert.Equal(t, []string{"aaaaaaaaaa\nbbbbbbbbbb", "cccccccccc"}, splitMessage(text, 30))
+	})
+
+	t.Run("hard splits long lines by runes", func(t *testing.T) {
+		text := strings.Repeat("é", 50)
+		parts := splitMessage(text, 20)
+
+		assert.Equal(t, text, strings.Join(parts, ""))
+		for _, part := range parts {
+			assert.LessOrEqual(t, utf8.RuneCountInString(part), 20)
+		}
+	})
+
+	t.Run("code blocks are closed and reopened", func(t *testing.T) {
+		text := "players:\n```\nSteve\nAlex\nHerobrine\nNotch\n```\ndone"
+		parts := splitMessage(text, 24)
+
+		require.Greater(t, len(parts), 1)
+		for _, part := range parts {
+			assert.LessOrEqual(t, utf8.RuneCountInString(part), 24)
+			assert.Zero(t, strings.Count(part, "```")%2, "unbalanced fence in %q", part)
+		}
+	})
+}
+
+func TestContext_Reply_Split(t *testing.T) {
+	k := koanf.New(".")
+	require.NoError(t, k.Set(config.KeyBotMaxMessageLength, 21))
+	require.NoError(t, k.Set(config.KeyBotMaxMessageParts, 2))
+
+	wa := new(fakeWhatsappService)
+	r := NewRouter(&config.Cfg{Koanf: k}, wa)
+	c := &Context{Context: context.Background(), iContext: wa, MessageID: "abc", router: r}
+
+	t.Run("parts", func(t *testing.T) {
+		_, err := c.Reply("aaaaaaaaaa\nbbbbbbbbbb\ncccccccccc")
+		require.NoError(t, err)
+
+		require.Len(t, wa.messages, 2)
+		assert.Equal(t, "aaaaaaaaaa\nbbbbbbbbbb", *wa.messages[0].Conversation)
+		assert.Equal(t, "cccccccccc", *wa.messages[1].Conversation)
+		assert.Equal(t, []string{"abc", ""}, wa.quotes, "only the first part quotes")
+	})
+
+	t.Run("too many parts are sent as a document", func(t *testing.T) {
+		wa.messages, wa.quotes = nil, nil
+
+		text := strings.Repeat("line\n", 20)
+		_, err := c.Reply(text)
+		require.NoError(t, err)
+
+		require.Len(t, wa.messages, 1)
+		assert.Equal(t, []byte(text), wa.messages[0].Document.Data)
+		assert.Equal(t, i18n.T(i18n.Default, messages.ReplyAsDocument), *wa.messages[0].Conversation)
+	})
+}
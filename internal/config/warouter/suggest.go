@@ -0,0 +1,55 @@
+package warouter
+
+import "sort"
+
+// maxSuggestDistance is the max edit distance of a "did you mean" suggestion.
+const maxSuggestDistance = 2
+
+// suggest returns the registered command or alias closest to name,
+// "" if none is close enough.
+func (r *Router) suggest(name string) string {
+	candidates := make([]string, 0, len(r.handlers)+len(r.aliases))
+	for cmd := range r.handlers {
+		candidates = append(candidates, cmd)
+	}
+	for alias := range r.aliases {
+		candidates = append(candidates, alias)
+	}
+	sort.Strings(candidates) // ties go to the first alphabetically
+
+	best, bestDist := "", maxSuggestDistance+1
+	for _, cmd := range candidates {
+		// e.g. everything is 1 edit away from a 1 letter alias
+		d := levenshtein(name, cmd)
+		if d < bestDist && d < len([]rune(cmd)) {
+			best, bestDist = cmd, d
+		}
+	}
+
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
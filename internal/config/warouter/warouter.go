@@ -2,27 +2,168 @@ package warouter
 
 import (
 	"context"
-	"errors"
+	"crypto/rand"
+	"encoding/hex"
 	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/constants/messages"
 	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"slices"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
 
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 )
 
 type Context struct {
 	context.Context
 	iContext
+	RequestID string // short random id, in every log line of the request and in error replies
+	MessageID string // the message that triggered the context
+
 	Message string
-	Args    []string // Message[1:]
+	Command string   // the name of the command, resolved from its alias, without the slash, empty if unknown
+	Args    []string // the words after the command, without the bot mention
 
 	PhoneNumber string // self
 	Sender      dto.WhatsappJID
+	SenderAlt   dto.WhatsappJID // the sender's other identity (PN or LID), empty if unknown
 	Chat        dto.WhatsappJID
+	Mentions    []dto.WhatsappJID // mentioned users, as listed in the message's context info
+
+	Quoted   *Quote    // nil if the message isn't a reply
+	Reaction *Reaction // nil if the context isn't from a reaction
+
+	lang     i18n.Lang                         // see Lang, empty until resolved
+	reactTo  string                            // see ReactionTarget, the command's message of an answer
+	fromMe   bool                              // sent by the bot's own account, e.g. from the phone
+	deferred bool                              // see Defer
+	asked    bool                              // see Asked
+	onYes    []func()                          // see OnConfirmed
+	answered []func(reply *Context, err error) // see OnAnswered
+	cancel   func() bool                       // see Defer, nil if not set
+
+	router *Router
+}
+
+// Quote is the message a reply quotes.
+type Quote struct {
+	MessageID string
+	Sender    dto.WhatsappJID
+}
+
+// IsDirect reports whether the context is from a direct (one-on-one) chat.
+func (c *Context) IsDirect() bool {
+	return c.Chat.Server == types.DefaultUserServer || c.Chat.Server == types.HiddenUserServer
+}
+
+// Lang returns the language of the chat's replies, see Router.LangFunc.
+func (c *Context) Lang() i18n.Lang {
+	if c.lang == "" {
+		c.lang = i18n.Default
+		if c.router != nil && c.router.LangFunc != nil {
+			c.lang = c.router.LangFunc(c, c.Chat)
+		}
+	}
+
+	return c.lang
+}
+
+// T translates a message in the language of the chat, see i18n.T.
+func (c *Context) T(id string, args ...any) string {
+	return i18n.T(c.Lang(), id, args...)
+}
+
+// N translates a message with plural forms in the language of the chat, see i18n.N.
+func (c *Context) N(id string, n int, args ...any) string {
+	return i18n.N(c.Lang(), id, n, args...)
+}
+
+// React reacts to the message that triggered the context, e.g. "✅". It does
+// nothing for reactions, the bot doesn't react to reactions. It still reacts
+// once the command's deadline passed, to show the failure.
+func (c *Context) React(emoji string) error {
+	target := c.ReactionTarget()
+	if target == "" {
+		return nil
+	}
+
+	return c.SendReaction(context.WithoutCancel(c), c.Chat, c.Sender, target, emoji)
+}
+
+// ReactionTarget returns the message React reacts to: the command's message,
+// also for the answer to one of its questions (see Ask). It's empty if there's
+// none, e.g. for a reaction.
+func (c *Context) ReactionTarget() string {
+	switch {
+	case c.Reaction != nil:
+		return ""
+	case c.reactTo != "":
+		return c.reactTo
+	}
+
+	return c.MessageID
+}
+
+// Defer tells the middlewares that the command goes on after its handler
+// returned, e.g. in a background job, which reports its own outcome. cancel
+// stops it, e.g. when the command's message is edited, and returns false if
+// it was already done. cancel may be nil.
+func (c *Context) Defer(cancel func() bool) {
+	c.deferred = true
+	c.cancel = cancel
+}
+
+// Deferred reports whether the handler called Defer.
+func (c *Context) Deferred() bool {
+	return c.deferred
+}
+
+// Asked reports whether the handler asked a question (see Ask), the command
+// goes on with the answer.
+func (c *Context) Asked() bool {
+	return c.asked
+}
+
+// OnConfirmed registers f to be called once the sender answers yes to the
+// confirmation of the command (see Confirm) and it ran without error. It must
+// be registered before the handler asks.
+func (c *Context) OnConfirmed(f func()) {
+	c.onYes = append(c.onYes, f)
+}
+
+// OnAnswered registers f to be called once the answer to the question of the
+// command (see Ask) was handled, with the answer's context and the error of
+// its handler. The answers don't run thru the global middlewares, e.g. the
+// status reactions of the command go on here. It must be registered before
+// the handler asks, and carries over to the questions the answer asks.
+func (c *Context) OnAnswered(f func(reply *Context, err error)) {
+	c.answered = append(c.answered, f)
+}
+
+// Reaction is a reaction to one of the bot's messages.
+type Reaction struct {
+	Emoji     string // normalized, without skin tones or variation selectors
+	MessageID string // the message reacted to
 }
 
 type iContext interface {
 	SendMessage(ctx context.Context, to dto.WhatsappJID, message *dto.WhatsappMessage) (*dto.WhatsappSendResponse, error)
+	EditMessage(ctx context.Context, to dto.WhatsappJID, messageID string, message *dto.WhatsappMessage) (*dto.WhatsappSendResponse, error)
+
+	// SendReaction reacts to a message of sender in a chat, an empty emoji removes the reaction.
+	SendReaction(ctx context.Context, chat, sender dto.WhatsappJID, messageID, emoji string) error
+
+	// SendPoll sends a single-choice poll.
+	SendPoll(ctx context.Context, to dto.WhatsappJID, question string, options []string) (*dto.WhatsappSendResponse, error)
 }
 
 type HandlerFunc func(c *Context) error
@@ -34,27 +175,68 @@ type WhatsappService interface {
 	RegisterEventHandler(f func(any)) uint32
 	UnregisterEventHandler(handlerID uint32) bool
 	GetPhoneNumber() string // self phone number
-	GetSelfLID() *dto.WhatsappJID
+
+	// IsSelf checks if a jid is the bot, by its phone number or LID.
+	IsSelf(ctx context.Context, jid dto.WhatsappJID) bool
 	IsSyncComplete(ctx context.Context) bool
+
+	// DecryptPollVote returns the SHA-256 hashes of the options selected by a vote on one of the bot's polls.
+	DecryptPollVote(ctx context.Context, vote *events.Message) ([][]byte, error)
 }
 
+// defaults of the dispatch config, see config.KeyBotWorkers and the like
+const (
+	DefaultWorkers         = 8
+	DefaultQueueSize       = 256
+	DefaultCommandTimeout  = 30 * time.Second
+	DefaultShutdownTimeout = 10 * time.Second
+)
+
 type Router struct {
 	waSvc       WhatsappService
 	cfg         *config.Cfg
-	handlers    map[string]HandlerFunc
-	middlewares []MiddlewareFunc // global middlewares
+	handlers    map[string]HandlerFunc   // key: command name, without prefix
+	aliases     map[string]string        // alias -> command name
+	timeouts    map[string]time.Duration // key: command name, commands without one use the default
+	reactions   map[string]HandlerFunc   // key: emoji
+	middlewares []MiddlewareFunc         // global middlewares
+
+	pendingMu sync.Mutex
+	pending   map[string]*pendingReply // key: chat + sender, questions waiting for a reply
+
+	commandsMu sync.Mutex
+	commands   map[string]*handledCommand // key: chat + message id, commands that can still be edited
+
+	pollsMu sync.Mutex
+	polls   map[string]*pendingPoll // key: chat + poll message id, polls waiting for a vote
+
+	dispatcher *dispatcher // nil while not running
 
 	ErrorHandlerFunc func(c *Context, err error) // nil if not set
 
+	// LangFunc returns the language of a chat's replies, it's only called
+	// once a context replies. Replies are in i18n.Default if not set.
+	LangFunc func(ctx context.Context, chat dto.WhatsappJID) i18n.Lang
+
+	// ContextFunc derives the context of every handled message, e.g. to scope
+	// it to the bot account. The dispatcher's context is used if not set.
+	ContextFunc func(ctx context.Context) context.Context
+
 	// event handler codes
 	HandlerCodeCommandWA uint32
 }
 
 func NewRouter(cfg *config.Cfg, waService WhatsappService) *Router {
 	return &Router{
-		waSvc:    waService,
-		cfg:      cfg,
-		handlers: make(map[string]HandlerFunc),
+		waSvc:     waService,
+		cfg:       cfg,
+		handlers:  make(map[string]HandlerFunc),
+		aliases:   make(map[string]string),
+		timeouts:  make(map[string]time.Duration),
+		reactions: make(map[string]HandlerFunc),
+		pending:   make(map[string]*pendingReply),
+		commands:  make(map[string]*handledCommand),
+		polls:     make(map[string]*pendingPoll),
 	}
 }
 
@@ -68,28 +250,113 @@ func (r *Router) unregisterEventHandler(handlerID uint32) bool {
 	return r.waSvc.UnregisterEventHandler(handlerID)
 }
 
-// Register a new handler with optional middlewares
+// Register a new handler with optional middlewares, cmd is matched
+// case-insensitively with any of the configured prefixes (e.g. "/start").
 func (r *Router) Register(cmd string, h HandlerFunc, mws ...MiddlewareFunc) {
+	r.handlers[normalizeCommand(cmd)] = r.wrap(h, mws...)
+}
+
+// Alias routes another name to a registered command, e.g. "/s" to "/start".
+func (r *Router) Alias(alias string, cmd string) {
+	alias = normalizeCommand(alias)
+	if _, ok := r.lookup(alias); ok {
+		panic("command alias must be unique")
+	}
+
+	r.aliases[alias] = normalizeCommand(cmd)
+}
+
+func normalizeCommand(cmd string) string {
+	return strings.ToLower(strings.TrimPrefix(cmd, "/"))
+}
+
+// RegisterReaction registers a handler for a reaction emoji on one of the
+// bot's messages, with optional middlewares.
+func (r *Router) RegisterReaction(emoji string, h HandlerFunc, mws ...MiddlewareFunc) {
+	r.reactions[normalizeEmoji(emoji)] = r.wrap(h, mws...)
+}
+
+// ReactionFilterFunc returns true if a reaction is one its handler takes care of.
+// It runs before any middleware, so it should be cheap.
+type ReactionFilterFunc func(c *Context) (bool, error)
+
+// RegisterReactionFiltered registers a handler like RegisterReaction, but the
+// reactions the filter rejects are ignored before the middlewares run.
+func (r *Router) RegisterReactionFiltered(emoji string, filter ReactionFilterFunc, h HandlerFunc, mws ...MiddlewareFunc) {
+	h = r.wrap(h, mws...)
+
+	r.reactions[normalizeEmoji(emoji)] = func(c *Context) error {
+		ok, err := filter(c)
+		if err != nil || !ok {
+			return err
+		}
+
+		return h(c)
+	}
+}
+
+// Recover is a middleware recovering from panics in the handlers, the panic
+// is logged with its stack and returned as errs.ErrCommandPanicked.
+func Recover(next HandlerFunc) HandlerFunc {
+	return func(c *Context) (err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				err = fmt.Errorf("%w: %v", errs.ErrCommandPanicked, rec)
+				config.ErrLog(c, err, debug.Stack())
+			}
+		}()
+
+		return next(c)
+	}
+}
+
+// wrap wraps a handler with the global middlewares, then the given ones.
+func (r *Router) wrap(h HandlerFunc, mws ...MiddlewareFunc) HandlerFunc {
 	all := append(r.middlewares, mws...)
 
 	for i := len(all) - 1; i >= 0; i-- {
 		h = all[i](h)
 	}
 
-	r.handlers[cmd] = h
+	return h
 }
 
 // Run registers the entry point function as an event handler and starts the event loop
 func (r *Router) Run() {
 	if r.HandlerCodeCommandWA == 0 {
+		r.dispatcher = newDispatcher(r.cfgInt(config.KeyBotWorkers, DefaultWorkers), r.cfgInt(config.KeyBotQueueSize, DefaultQueueSize))
 		r.HandlerCodeCommandWA = r.registerEventHandler(r.entryPoint)
 	}
 }
 
+// cfgInt reads a positive int from the config, def if unset. The router can be
+// nil, e.g. a context built outside of it.
+func (r *Router) cfgInt(key string, def int) int {
+	if r != nil && r.cfg != nil && r.cfg.Koanf != nil {
+		if v := r.cfg.Int(key); v > 0 {
+			return v
+		}
+	}
+
+	return def
+}
+
+// cfgDuration reads a positive duration from the config, def if unset. The
+// router can be nil like for cfgInt.
+func (r *Router) cfgDuration(key string, def time.Duration) time.Duration {
+	if r != nil && r.cfg != nil && r.cfg.Koanf != nil {
+		if d := r.cfg.Duration(key); d > 0 {
+			return d
+		}
+	}
+
+	return def
+}
+
 // entryPoint is an entry point for any event from WhatsApp.
-// Currently, it only handles *events.Message. It will create a new Context
-// and call the handle function. If the handle function returns an error, it
-// will call the ErrorHandlerFunc if it is not nil.
+// Currently, it only handles *events.Message (including reactions). It
+// dispatches the message to the worker pool (see dispatcher), whatsmeow
+// waits for its event handlers so they must not block.
 func (r *Router) entryPoint(evt any) {
 	// skip all event if sync is not complete
 	if !r.waSvc.IsSyncComplete(context.TODO()) {
@@ -98,105 +365,352 @@ func (r *Router) entryPoint(evt any) {
 
 	switch v := evt.(type) {
 	case *events.Message:
-		// is it really needed? skip messages from self
-		// if v.Info.IsFromMe { return }
+		if !r.dispatcher.dispatch(v.Info.Chat.String(), func(ctx context.Context) { r.handleMessage(ctx, v) }) {
+			slog.Warn("whatsapp message dropped, the router is busy or stopped", "chat", v.Info.Chat.String(), "id", v.Info.ID)
+		}
+	}
+}
+
+// handleMessage creates a new Context from a message and calls the handle
+// function. If the handle function returns an error, it will call the
+// ErrorHandlerFunc if it is not nil.
+func (r *Router) handleMessage(ctx context.Context, v *events.Message) {
+	if r.ContextFunc != nil {
+		ctx = r.ContextFunc(ctx)
+	}
+
+	switch {
+	case v.Message.GetReactionMessage() != nil:
+		r.handleReactionEvent(ctx, v)
+		return
+	case v.Message.GetProtocolMessage().GetType() == waE2E.ProtocolMessage_MESSAGE_EDIT:
+		r.handleEditEvent(ctx, v)
+		return
+	case v.Message.GetPollUpdateMessage() != nil:
+		r.handlePollVote(ctx, v)
+		return
+	}
+
+	c := r.newContext(ctx, v, v.Message)
+
+	replied, err := r.handleReply(c)
+	if !replied {
+		err = r.handleMsgEvent(c)
+	}
+
+	if err != nil && r.ErrorHandlerFunc != nil {
+		r.ErrorHandlerFunc(c, err)
+	}
+}
+
+// newContext creates the Context of a message event, m is the message's
+// content (e.g. the new content of an edit).
+func (r *Router) newContext(ctx context.Context, v *events.Message, m *waE2E.Message) *Context {
+	ctxInfo := m.GetExtendedTextMessage().GetContextInfo()
+	requestID := newRequestID()
+
+	return &Context{
+		Context:     config.WithRequestID(ctx, requestID),
+		iContext:    r.waSvc,
+		RequestID:   requestID,
+		MessageID:   v.Info.ID,
+		Message:     messageText(m),
+		PhoneNumber: r.waSvc.GetPhoneNumber(),
+		Sender:      dto.NewWhatsappJID(v.Info.Sender),
+		SenderAlt:   dto.NewWhatsappJID(v.Info.SenderAlt),
+		Chat:        dto.NewWhatsappJID(v.Info.Chat),
+		Mentions:    parseMentions(ctxInfo.GetMentionedJID()),
+		Quoted:      parseQuote(ctxInfo),
+		fromMe:      v.Info.IsFromMe,
+		router:      r,
+	}
+}
 
-		var msg string
-		var args []string
+// messageText returns the text of a text message, "" for other messages (e.g. stickers).
+func messageText(m *waE2E.Message) string {
+	if text := m.GetConversation(); text != "" {
+		return text
+	}
 
-		switch v.Message != nil {
-		case v.Message.Conversation != nil &&
-			*v.Message.Conversation != "":
-			msg = *v.Message.Conversation
+	return m.GetExtendedTextMessage().GetText()
+}
+
+// newRequestID returns a short random id, e.g. "3fa9c1".
+func newRequestID() string {
+	b := make([]byte, 3)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// handleMsgEvent handles incoming message events from WhatsApp.
+// It will check if the message is addressed to the bot and has a command
+// (see parseCommand). If the checks pass, it will call the handle
+// function associated with the command or its alias. If the checks
+// fail, it will return nil without calling the ErrorHandlerFunc. If
+// the handle function is not found, it will reply with "Unknown command"
+// and the closest command, if any.
+func (r *Router) handleMsgEvent(c *Context) error {
+	prefix, name, args, ok := r.parseCommand(c)
+	if !ok {
+		return nil
+	}
+
+	c.Args = args
+	defer r.rememberCommand(c)
+
+	h, ok := r.lookup(name)
+	if !ok {
+		// still thru the global middlewares, e.g. no replies to non-whitelisted groups
+		return r.wrap(r.unknownCommand(prefix, name))(c)
+	}
+	c.Command = r.resolve(name)
 
-		case v.Message.ExtendedTextMessage != nil &&
-			v.Message.ExtendedTextMessage.Text != nil &&
-			*v.Message.ExtendedTextMessage.Text != "":
-			msg = *v.Message.ExtendedTextMessage.Text
+	return runWithTimeout(c, r.timeoutOf(name), h)
+}
+
+// Timeout sets the deadline of a command's handler, instead of the default one
+// (see config.KeyBotCommandTimeout).
+func (r *Router) Timeout(cmd string, d time.Duration) {
+	r.timeouts[normalizeCommand(cmd)] = d
+}
+
+// timeoutOf returns the deadline of a command name or alias.
+func (r *Router) timeoutOf(name string) time.Duration {
+	if target, ok := r.aliases[name]; ok {
+		name = target
+	}
+
+	if d, ok := r.timeouts[name]; ok {
+		return d
+	}
+
+	return r.cfgDuration(config.KeyBotCommandTimeout, DefaultCommandTimeout)
+}
+
+// runWithTimeout runs h with a deadline on c. The deadline is removed
+// afterwards, so the error handler can still reply.
+func runWithTimeout(c *Context, timeout time.Duration, h HandlerFunc) error {
+	parent := c.Context
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer func() {
+		cancel()
+		c.Context = parent
+	}()
+
+	c.Context = ctx
+	return h(c)
+}
+
+// parseCommand finds the command in a message and returns its lowercased
+// name (without prefix) and args. ok is false if the message isn't a command
+// to the bot. A message is addressed to the bot if:
+//   - it mentions the bot, the mention can be anywhere in the message and the
+//     command is the first word starting with one of the configured prefixes.
+//   - it replies to one of the bot's messages, or it's a direct message. The
+//     command must be the first word, so a chat about "/start" isn't a command.
+//
+// The args are the words after the command.
+func (r *Router) parseCommand(c *Context) (prefix, name string, args []string, ok bool) {
+	// the mention is rendered as "@user" in the text
+	selfTags := make(map[string]bool)
+	for _, m := range c.Mentions {
+		if r.waSvc.IsSelf(c, m) {
+			selfTags["@"+m.User] = true
 		}
+	}
+
+	parts := slices.DeleteFunc(strings.Fields(c.Message), func(p string) bool {
+		return selfTags[strings.TrimRight(p, ",.:;!?")]
+	})
 
-		parts := strings.Fields(msg)
-		if len(parts) > 2 {
-			args = parts[2:]
+	if len(selfTags) == 0 {
+		// own messages are never replies nor DMs to the bot, also the bot's
+		// replies in a DM would be commands otherwise
+		if c.fromMe || !(c.IsDirect() || r.isReplyToSelf(c)) || len(parts) == 0 {
+			return "", "", nil, false
 		}
 
-		ctx := &Context{
-			Context:     context.Background(),
-			iContext:    r.waSvc,
-			Message:     msg,
-			Args:        args,
-			PhoneNumber: r.waSvc.GetPhoneNumber(),
-			Sender:      dto.NewWhatsappJID(v.Info.Sender),
-			Chat:        dto.NewWhatsappJID(v.Info.Chat),
+		if prefix, name, ok := r.cutPrefix(parts[0]); ok {
+			return prefix, name, parts[1:], true
 		}
 
-		err := r.handleMsgEvent(ctx, msg)
-		if err != nil && r.ErrorHandlerFunc != nil {
-			r.ErrorHandlerFunc(ctx, err)
+		return "", "", nil, false
+	}
+
+	for i, word := range parts {
+		if prefix, name, ok := r.cutPrefix(word); ok {
+			return prefix, name, parts[i+1:], true
 		}
 	}
+
+	return "", "", nil, false
 }
 
-// handleMsgEvent handles incoming message events from WhatsApp.
-// It will check if the message contains the self phone number and
-// if it starts with a "/". If the checks pass, it will call
-// the handle function associated with the command. If the handle
-// function returns an error, it will call the ErrorHandlerFunc if it
-// is not nil. If the checks fail, it will return nil without
-// calling the ErrorHandlerFunc. If the handle function is not found,
-// it will reply with "Unknown command".
-func (r *Router) handleMsgEvent(c *Context, message string) error {
-	parts := strings.Fields(message)
-
-	if len(parts) < 2 {
-		return nil
+// cutPrefix returns the prefix and the lowercased command name of a word
+// starting with one of the configured prefixes.
+func (r *Router) cutPrefix(word string) (prefix, name string, ok bool) {
+	for _, p := range r.commandPrefixes() {
+		// the name must start with a letter, e.g. "..." isn't a command
+		if cmd, found := strings.CutPrefix(word, p); found && cmd != "" && unicode.IsLetter([]rune(cmd)[0]) {
+			return p, strings.ToLower(cmd), true
+		}
 	}
 
-	selfTagged, err := r.isSelfTagged(parts[0])
-	if err != nil {
-		return err
-	}
+	return "", "", false
+}
+
+// isReplyToSelf checks if the message quotes one of the bot's messages.
+func (r *Router) isReplyToSelf(c *Context) bool {
+	return c.Quoted != nil && r.waSvc.IsSelf(c, c.Quoted.Sender)
+}
 
-	if !selfTagged {
+// parseQuote parses the quoted message of a reply, nil if the message isn't a reply.
+func parseQuote(info *waE2E.ContextInfo) *Quote {
+	if info.GetStanzaID() == "" {
 		return nil
 	}
 
-	// command must start with a "/"
-	cmd := parts[1]
-	if !strings.HasPrefix(cmd, "/") {
+	sender, err := types.ParseJID(info.GetParticipant())
+	if err != nil || sender.User == "" {
 		return nil
 	}
 
-	if h, ok := r.handlers[cmd]; ok {
-		err := h(c)
-		if err != nil {
-			return err
+	return &Quote{
+		MessageID: info.GetStanzaID(),
+		Sender:    dto.NewWhatsappJID(sender),
+	}
+}
+
+// parseMentions parses the mentioned jids of a message, skipping malformed ones.
+func parseMentions(jids []string) []dto.WhatsappJID {
+	mentions := make([]dto.WhatsappJID, 0, len(jids))
+	for _, jid := range jids {
+		parsed, err := types.ParseJID(jid)
+		if err != nil || parsed.User == "" || parsed.Server == "" {
+			continue
 		}
 
-		return nil
+		mentions = append(mentions, dto.NewWhatsappJID(parsed))
+	}
+
+	return mentions
+}
+
+// commandPrefixes returns the configured command prefixes, "/" by default.
+func (r *Router) commandPrefixes() []string {
+	if r.cfg != nil {
+		if prefixes := r.cfg.Strings(config.KeyBotCommandPrefixes); len(prefixes) > 0 {
+			return prefixes
+		}
 	}
 
-	return nil
+	return []string{"/"}
+}
+
+// lookup gets the handler of a command name or alias.
+func (r *Router) lookup(name string) (HandlerFunc, bool) {
+	h, ok := r.handlers[r.resolve(name)]
+	return h, ok
+}
+
+// resolve returns the command an alias routes to, or name itself.
+func (r *Router) resolve(name string) string {
+	if target, ok := r.aliases[name]; ok {
+		return target
+	}
+
+	return name
+}
+
+// unknownCommand replies that the command doesn't exist, suggesting the
+// closest one, with the prefix the sender used.
+func (r *Router) unknownCommand(prefix, name string) HandlerFunc {
+	return func(c *Context) error {
+		text := c.T(messages.CmdUnknown, prefix, name)
+		if suggestion := r.suggest(name); suggestion != "" {
+			text = c.T(messages.CmdDidYouMean, prefix, name, suggestion)
+		}
+
+		_, err := c.Reply(text)
+		return err
+	}
 }
 
-func (r *Router) isSelfTagged(tag string) (bool, error) {
-	lid := r.waSvc.GetSelfLID()
-	if lid != nil && lid.User != "" && strings.Contains(tag, lid.User) {
-		return true, nil
+// handleReactionEvent calls the handler registered for the reaction's emoji,
+// only reactions to the bot's own messages are handled.
+func (r *Router) handleReactionEvent(ctx context.Context, v *events.Message) {
+	reaction := v.Message.GetReactionMessage()
+
+	// empty text means the reaction was removed
+	emoji := normalizeEmoji(reaction.GetText())
+	if emoji == "" {
+		return
+	}
+
+	h, ok := r.reactions[emoji]
+	if !ok {
+		return
+	}
+
+	key := reaction.GetKey()
+	if key.GetFromMe() {
+		return
+	}
+
+	// only reactions to the bot's own messages
+	participant, err := types.ParseJID(key.GetParticipant())
+	if err != nil || participant.User == "" || !r.waSvc.IsSelf(ctx, dto.NewWhatsappJID(participant)) {
+		return
 	}
 
-	phoneNumber := r.waSvc.GetPhoneNumber()
-	if phoneNumber == "" {
-		return false, errors.New("failed to get whatsapp self id")
+	requestID := newRequestID()
+	c := &Context{
+		Context:     config.WithRequestID(ctx, requestID),
+		iContext:    r.waSvc,
+		RequestID:   requestID,
+		MessageID:   v.Info.ID,
+		PhoneNumber: r.waSvc.GetPhoneNumber(),
+		Sender:      dto.NewWhatsappJID(v.Info.Sender),
+		SenderAlt:   dto.NewWhatsappJID(v.Info.SenderAlt),
+		Chat:        dto.NewWhatsappJID(v.Info.Chat),
+		Reaction: &Reaction{
+			Emoji:     emoji,
+			MessageID: key.GetID(),
+		},
+		router: r,
 	}
 
-	return strings.Contains(tag, phoneNumber), nil
+	if err := runWithTimeout(c, r.cfgDuration(config.KeyBotCommandTimeout, DefaultCommandTimeout), h); err != nil && r.ErrorHandlerFunc != nil {
+		r.ErrorHandlerFunc(c, err)
+	}
 }
 
-// Stop unregisters the entry point function as an event handler, stopping the event loop
+// normalizeEmoji strips skin tone modifiers and variation selectors,
+// so e.g. "👍🏽" matches "👍".
+func normalizeEmoji(emoji string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\uFE0F' || (r >= 0x1F3FB && r <= 0x1F3FF) {
+			return -1
+		}
+		return r
+	}, emoji)
+}
+
+// Stop unregisters the entry point function as an event handler, stopping the
+// event loop. It waits for the running handlers up to the shutdown timeout,
+// then cancels their context.
 func (r *Router) Stop() {
 	if r.HandlerCodeCommandWA == 0 {
-		_ = r.unregisterEventHandler(r.HandlerCodeCommandWA)
+		return
+	}
+
+	_ = r.unregisterEventHandler(r.HandlerCodeCommandWA)
+	r.HandlerCodeCommandWA = 0
+
+	timeout := r.cfgDuration(config.KeyBotShutdownTimeout, DefaultShutdownTimeout)
+	if !r.dispatcher.shutdown(timeout) {
+		slog.Warn("whatsapp handlers still running after shutdown timeout", "timeout", timeout)
 	}
 }
 
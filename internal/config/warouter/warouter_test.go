@@ -0,0 +1,386 @@
+package warouter
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/helper"
+	"exaroton-wa-bot/internal/i18n"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// fakeWhatsappService is a WhatsappService logged in as 628123 (LID 999).
+type fakeWhatsappService struct {
+	mu           sync.Mutex // guards sent, for messages sent by timers
+	sent         []string
+	messages     []*dto.WhatsappMessage
+	quotes       []string // quoted message id of each sent message, "" if none
+	reactions    []string // message id + emoji
+	unregistered []uint32
+	polls        []string   // question + options
+	votes        [][][]byte // selected options returned by each DecryptPollVote
+}
+
+func (f *fakeWhatsappService) SendMessage(ctx context.Context, to dto.WhatsappJID, message *dto.WhatsappMessage) (*dto.WhatsappSendResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.sent = append(f.sent, helper.Deref(message.Conversation))
+	f.messages = append(f.messages, message)
+
+	quote := ""
+	if message.Quote != nil {
+		quote = message.Quote.MessageID
+	}
+	f.quotes = append(f.quotes, quote)
+	return &dto.WhatsappSendResponse{}, nil
+}
+
+func (f *fakeWhatsappService) EditMessage(ctx context.Context, to dto.WhatsappJID, messageID string, message *dto.WhatsappMessage) (*dto.WhatsappSendResponse, error) {
+	return &dto.WhatsappSendResponse{}, nil
+}
+
+func (f *fakeWhatsappService) SendReaction(ctx context.Context, chat, sender dto.WhatsappJID, messageID, emoji string) error {
+	f.reactions = append(f.reactions, messageID+" "+emoji)
+	return nil
+}
+
+func (f *fakeWhatsappService) SendPoll(ctx context.Context, to dto.WhatsappJID, question string, options []string) (*dto.WhatsappSendResponse, error) {
+	f.polls = append(f.polls, question+" "+strings.Join(options, ","))
+	return &dto.WhatsappSendResponse{ID: fmt.Sprintf("poll%d", len(f.polls))}, nil
+}
+
+func (f *fakeWhatsappService) DecryptPollVote(ctx context.Context, vote *events.Message) ([][]byte, error) {
+	selected := f.votes[0]
+	f.votes = f.votes[1:]
+	return selected, nil
+}
+
+func (f *fakeWhatsappService) RegisterEventHandler(func(any)) uint32 { return 1 }
+func (f *fakeWhatsappService) GetPhoneNumber() string                { return "628123" }
+func (f *fakeWhatsappService) IsSyncComplete(context.Context) bool   { return true }
+
+func (f *fakeWhatsappService) UnregisterEventHandler(id uint32) bool {
+	f.unregistered = append(f.unregistered, id)
+	return true
+}
+
+func (f *fakeWhatsappService) IsSelf(ctx context.Context, jid dto.WhatsappJID) bool {
+	return (jid.User == "628123" && jid.Server == "s.whatsapp.net") || (jid.User == "999" && jid.Server == "lid")
+}
+
+func TestRouter_ParseCommand(t *testing.T) {
+	r := NewRouter(nil, new(fakeWhatsappService))
+
+	pn := []dto.WhatsappJID{{User: "628123", Server: "s.whatsapp.net"}}
+	lid := []dto.WhatsappJID{{User: "999", Server: "lid"}}
+	other := []dto.WhatsappJID{{User: "6281234", Server: "s.whatsapp.net"}}
+
+	group := dto.WhatsappJID{User: "1203630", Server: "g.us"}
+	dm := dto.WhatsappJID{User: "6285", Server: "s.whatsapp.net"}
+	replyToBot := &Quote{MessageID: "abc", Sender: dto.WhatsappJID{User: "999", Server: "lid"}}
+	replyToOther := &Quote{MessageID: "abc", Sender: dto.WhatsappJID{User: "6285", Server: "s.whatsapp.net"}}
+
+	tests := []struct {
+		message  string
+		mentions []dto.WhatsappJID
+		chat     dto.WhatsappJID
+		quoted   *Quote
+		fromMe   bool
+		name     string
+		args     []string
+		ok       bool
+	}{
+		{message: "@628123 /start 1", mentions: pn, name: "start", args: []string{"1"}, ok: true},
+		{message: "  @999\n/START   1  ", mentions: lid, name: "start", args: []string{"1"}, ok: true},
+		{message: "/start 1 @628123", mentions: pn, name: "start", args: []string{"1"}, ok: true},
+		{message: "hey @628123, /players 2 pls", mentions: pn, name: "players", args: []string{"2", "pls"}, ok: true},
+		{message: "@628123 /status", mentions: pn, name: "status", args: []string{}, ok: true},
+		{message: "@628123 /start 1", mentions: nil, ok: false},    // no mention, only text
+		{message: "@6281234 /start 1", mentions: other, ok: false}, // contains our number
+		{message: "@628123 hello", mentions: pn, ok: false},
+		{message: "@628123 !start 1", mentions: pn, ok: false}, // "!" isn't a default prefix
+		{message: "@628123 /", mentions: pn, ok: false},
+
+		// replies to the bot
+		{message: "/start 1", chat: group, quoted: replyToBot, name: "start", args: []string{"1"}, ok: true},
+		{message: "please /start 1", chat: group, quoted: replyToBot, ok: false}, // command must be the first word
+		{message: "/start 1", chat: group, quoted: replyToOther, ok: false},
+		{message: "/start 1", chat: group, ok: false},
+
+		// direct messages
+		{message: "/Players 2", chat: dm, name: "players", args: []string{"2"}, ok: true},
+		{message: "/players", chat: dto.WhatsappJID{User: "77", Server: "lid"}, name: "players", args: []string{}, ok: true},
+		{message: "what does /start do?", chat: dm, ok: false},
+		{message: "", chat: dm, ok: false},
+		{message: "/start 1", chat: dm, fromMe: true, ok: false}, // the bot's own message
+	}
+
+	for _, tt := range tests {
+		c := &Context{
+			Context:  context.Background(),
+			Message:  tt.message,
+			Chat:     tt.chat,
+			Mentions: tt.mentions,
+			Quoted:   tt.quoted,
+			fromMe:   tt.fromMe,
+		}
+
+		prefix, name, args, ok := r.parseCommand(c)
+		assert.Equal(t, tt.ok, ok, tt.message)
+		if tt.ok {
+			assert.NotEmpty(t, prefix, tt.message)
+			assert.Equal(t, tt.name, name, tt.message)
+			assert.Equal(t, tt.args, args, tt.message)
+		}
+	}
+}
+
+func TestParseQuote(t *testing.T) {
+	id, participant := "abc", "999@lid"
+
+	assert.Nil(t, parseQuote(nil))
+	assert.Nil(t, parseQuote(&waE2E.ContextInfo{Participant: &participant}))
+
+	assert.Equal(t, &Quote{
+		MessageID: "abc",
+		Sender:    dto.WhatsappJID{User: "999", Server: "lid"},
+	}, parseQuote(&waE2E.ContextInfo{
+		StanzaID:    &id,
+		Participant: &participant,
+	}))
+}
+
+func TestParseMentions(t *testing.T) {
+	mentions := parseMentions([]string{"628123@s.whatsapp.net", "999@lid", "not a jid@"})
+
+	assert.Equal(t, []dto.WhatsappJID{
+		{User: "628123", Server: "s.whatsapp.net"},
+		{User: "999", Server: "lid"},
+	}, mentions)
+}
+
+func TestRouter_HandleMsgEvent(t *testing.T) {
+	wa := new(fakeWhatsappService)
+	r := NewRouter(nil, wa)
+
+	var called []string
+	r.Register("/start", func(c *Context) error {
+		called = append(called, "start "+c.Args[0])
+		return nil
+	})
+	r.Register("/players", func(c *Context) error { return nil })
+	r.Alias("s", "start")
+
+	newCtx := func(message string) *Context {
+		return &Context{
+			Context:  context.Background(),
+			iContext: wa,
+			Message:  message,
+			Mentions: []dto.WhatsappJID{{User: "628123", Server: "s.whatsapp.net"}},
+			router:   r,
+		}
+	}
+
+	assert.NoError(t, r.handleMsgEvent(newCtx("@628123 /S 2")))
+	assert.Equal(t, []string{"start 2"}, called)
+
+	assert.NoError(t, r.handleMsgEvent(newCtx("@628123 /strat 2")))
+	assert.Equal(t, []string{"Unknown command /strat. Did you mean /start?"}, wa.sent)
+
+	assert.NoError(t, r.handleMsgEvent(newCtx("@628123 /xyzzy")))
+	assert.Equal(t, "Unknown command /xyzzy, see /help", wa.sent[1])
+
+	r.LangFunc = func(context.Context, dto.WhatsappJID) i18n.Lang { return i18n.ID }
+	assert.NoError(t, r.handleMsgEvent(newCtx("@628123 /xyzzy")))
+	assert.Equal(t, i18n.T(i18n.ID, messages.CmdUnknown, "/", "xyzzy"), wa.sent[2], "in the chat's language")
+}
+
+func TestRouter_UnknownCommandPrefix(t *testing.T) {
+	k := koanf.New(".")
+	_ = k.Set(config.KeyBotCommandPrefixes, []string{"/", "!"})
+
+	wa := new(fakeWhatsappService)
+	r := NewRouter(&config.Cfg{Koanf: k}, wa)
+	r.Register("/start", func(c *Context) error { return nil })
+
+	for _, message := range []string{"@628123 !strat", "@628123 !xyzzy"} {
+		assert.NoError(t, r.handleMsgEvent(&Context{
+			Context:  context.Background(),
+			iContext: wa,
+			Message:  message,
+			Mentions: []dto.WhatsappJID{{User: "628123", Server: "s.whatsapp.net"}},
+			router:   r,
+		}))
+	}
+
+	assert.Equal(t, []string{
+		"Unknown command !strat. Did you mean !start?",
+		"Unknown command !xyzzy, see !help",
+	}, wa.sent)
+}
+
+func TestRouter_ContextFunc(t *testing.T) {
+	r := NewRouter(nil, new(fakeWhatsappService))
+
+	type key struct{}
+	r.ContextFunc = func(ctx context.Context) context.Context {
+		return context.WithValue(ctx, key{}, "device")
+	}
+
+	var got any
+	r.Register("/start", func(c *Context) error {
+		got = c.Value(key{})
+		return nil
+	})
+
+	dm := types.JID{User: "6285", Server: types.DefaultUserServer}
+	text := "/start"
+	r.handleMessage(context.Background(), &events.Message{
+		Info:    types.MessageInfo{MessageSource: types.MessageSource{Chat: dm, Sender: dm}, ID: "m1"},
+		Message: &waE2E.Message{Conversation: &text},
+	})
+
+	assert.Equal(t, "device", got)
+}
+
+func TestRouter_Timeout(t *testing.T) {
+	r := NewRouter(nil, new(fakeWhatsappService))
+
+	var deadlines []time.Duration
+	h := func(c *Context) error {
+		deadline, ok := c.Deadline()
+		assert.True(t, ok)
+		deadlines = append(deadlines, time.Until(deadline).Round(time.Second))
+		return nil
+	}
+	r.Register("/start", h)
+	r.Register("/status", h)
+	r.Alias("s", "start")
+	r.Timeout("/start", 5*time.Minute)
+
+	for _, message := range []string{"@628123 /s 1", "@628123 /status"} {
+		c := &Context{
+			Context:  context.Background(),
+			Message:  message,
+			Mentions: []dto.WhatsappJID{{User: "628123", Server: "s.whatsapp.net"}},
+			router:   r,
+		}
+		assert.NoError(t, r.handleMsgEvent(c))
+
+		// the deadline is removed once the handler returns
+		_, ok := c.Deadline()
+		assert.False(t, ok)
+	}
+
+	assert.Equal(t, []time.Duration{5 * time.Minute, DefaultCommandTimeout}, deadlines)
+}
+
+func TestRouter_Stop(t *testing.T) {
+	wa := new(fakeWhatsappService)
+	r := NewRouter(nil, wa)
+
+	r.Run()
+	assert.Equal(t, uint32(1), r.HandlerCodeCommandWA)
+
+	r.Stop()
+	assert.Equal(t, []uint32{1}, wa.unregistered)
+	assert.Zero(t, r.HandlerCodeCommandWA)
+
+	// already stopped
+	r.Stop()
+	assert.Len(t, wa.unregistered, 1)
+}
+
+func TestContext_React(t *testing.T) {
+	wa := new(fakeWhatsappService)
+
+	c := &Context{Context: context.Background(), iContext: wa, MessageID: "abc"}
+	assert.NoError(t, c.React("✅"))
+
+	// not on reactions, nor without a message
+	reaction := &Context{Context: context.Background(), iContext: wa, MessageID: "def", Reaction: &Reaction{Emoji: "👍"}}
+	assert.NoError(t, reaction.React("✅"))
+	assert.NoError(t, (&Context{Context: context.Background(), iContext: wa}).React("✅"))
+
+	assert.Equal(t, []string{"abc ✅"}, wa.reactions)
+}
+
+func TestRouter_RegisterReactionFiltered(t *testing.T) {
+	r := NewRouter(nil, new(fakeWhatsappService))
+
+	global := 0
+	r.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			global++
+			return next(c)
+		}
+	})
+
+	var handled []string
+	r.RegisterReactionFiltered("👍", func(c *Context) (bool, error) {
+		return c.Reaction.MessageID == "vote", nil
+	}, func(c *Context) error {
+		handled = append(handled, c.Reaction.MessageID)
+		return nil
+	})
+
+	group := types.JID{User: "123", Server: types.GroupServer}
+	sender := types.JID{User: "6285", Server: types.DefaultUserServer}
+	react := func(messageID string) {
+		r.handleReactionEvent(context.Background(), &events.Message{
+			Info: types.MessageInfo{MessageSource: types.MessageSource{Chat: group, Sender: sender}, ID: "r-" + messageID},
+			Message: &waE2E.Message{ReactionMessage: &waE2E.ReactionMessage{
+				Key: &waCommon.MessageKey{
+					ID:          helper.Ptr(messageID),
+					FromMe:      helper.Ptr(false),
+					Participant: helper.Ptr("628123@s.whatsapp.net"),
+				},
+				Text: helper.Ptr("👍🏽"),
+			}},
+		})
+	}
+
+	react("other")
+	assert.Zero(t, global, "the filter runs before the middlewares")
+	assert.Empty(t, handled)
+
+	react("vote")
+	assert.Equal(t, 1, global)
+	assert.Equal(t, []string{"vote"}, handled)
+}
+
+func TestRouter_CfgNil(t *testing.T) {
+	// a context built outside of a router
+	var r *Router
+	assert.Equal(t, 3, r.cfgInt(config.KeyBotWorkers, 3))
+	assert.Equal(t, time.Second, r.cfgDuration(config.KeyBotCommandTimeout, time.Second))
+
+	r = NewRouter(&config.Cfg{}, nil)
+	assert.Equal(t, time.Second, r.cfgDuration(config.KeyBotCommandTimeout, time.Second))
+}
+
+func TestNewRequestID(t *testing.T) {
+	id := newRequestID()
+	assert.Regexp(t, "^[0-9a-f]{6}$", id)
+	assert.NotEqual(t, id, newRequestID())
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("start", "start"))
+	assert.Equal(t, 1, levenshtein("stat", "start"))
+	assert.Equal(t, 2, levenshtein("strat", "start"))
+	assert.Equal(t, 3, levenshtein("", "abc"))
+}
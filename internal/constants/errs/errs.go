@@ -18,8 +18,10 @@ var (
 	ErrUserNotLoggedIn     = errors.New("User is not logged in")
 	ErrLoginFailed         = errors.New("Wrong credentials")
 
-	ErrWAGroupNotWhitelisted = errors.New("Whatsapp group is not whitelisted")
-	ErrInvalidCommandPrefix  = errors.New("Invalid command prefix")
+	ErrWAGroupNotWhitelisted     = errors.New("Whatsapp group is not whitelisted")
+	ErrWAGroupAlreadyWhitelisted = errors.New("Whatsapp group is already whitelisted")
+	ErrWAUserNotWhitelisted      = errors.New("Whatsapp user is not whitelisted")
+	ErrInvalidCommandPrefix      = errors.New("Invalid command prefix")
 )
 
 // whatsapp errors
@@ -32,6 +34,13 @@ var (
 	ErrWAQRIsPairing         = errors.New("Whatsapp is already pairing, please wait for it to finish.")
 	ErrWAQRClientOutdated    = errors.New("Whatsapp client is outdated, please update this app.")
 	ErrWAQREnableMultidevice = errors.New("Whatsapp is not enabled for multidevice, please enable it.")
+
+	ErrWADeviceNotFound     = errors.New("Whatsapp device not found")
+	ErrWADeviceNotRemovable = errors.New("The default whatsapp device can't be removed")
+
+	ErrWAInviteLinkInvalid = errors.New("The group invite link is invalid or was revoked")
+
+	ErrWAMediaNotUploaded = errors.New("Whatsapp media is not uploaded")
 )
 
 // Game server specific errors
@@ -41,6 +50,7 @@ var (
 	ErrGSEmptyAPIKey           = errors.New("API key is empty")
 	ErrServerNotFound          = errors.New("Server not found")
 	ErrServerIsAlreadyStopping = errors.New("Server is already stopped/stopping")
+	ErrServerNotInScope        = errors.New("Server can't be used in this chat")
 )
 
 // command error
@@ -48,4 +58,28 @@ var (
 	ErrCommandNotFound   = errors.New("Command not found")
 	ErrCommandMissingArg = errors.New("Missing argument")
 	ErrCommandInvalidArg = errors.New("Invalid argument")
+	ErrRateLimited       = errors.New("You are sending commands too fast")
+	ErrCommandCooldown   = errors.New("This command was used recently")
+	ErrCommandPanicked   = errors.New("Command panicked")
+)
+
+// job error
+var (
+	ErrJobNotFound = errors.New("Job not found, it might have finished already")
+)
+
+// message template error
+var (
+	ErrTemplateNotFound = errors.New("Template not found")
+)
+
+// registration error
+var (
+	ErrRegistrationCodeInvalid = errors.New("Invalid, expired or already used registration code")
+	ErrRegisterGroupOnly       = errors.New("Only groups can be registered")
+)
+
+// i18n error
+var (
+	ErrUnknownLanguage = errors.New("Unknown language")
 )
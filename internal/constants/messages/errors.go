@@ -0,0 +1,35 @@
+package messages
+
+import (
+	"errors"
+	"exaroton-wa-bot/internal/constants/errs"
+)
+
+// errMessages are the messages of the errors the sender can act on, the
+// command replies and the job reports show them instead of the error.
+var errMessages = []struct {
+	err error
+	id  string
+}{
+	{errs.ErrServerNotFound, ErrServerNotFound},
+	{errs.ErrCommandNotFound, ErrCommandNotFound},
+	{errs.ErrServerIsAlreadyStopping, ErrServerIsAlreadyStopping},
+	{errs.ErrServerNotInScope, ErrServerNotInScope},
+	{errs.ErrJobNotFound, ErrJobNotFound},
+	{errs.ErrRegistrationCodeInvalid, ErrRegistrationCodeInvalid},
+	{errs.ErrWAGroupAlreadyWhitelisted, ErrGroupAlreadyWhitelisted},
+	{errs.ErrRegisterGroupOnly, ErrRegisterGroupOnly},
+	{errs.ErrForbidden, ErrForbidden},
+}
+
+// ErrorID returns the message id of a known error, false if the error isn't
+// one the sender can act on.
+func ErrorID(err error) (string, bool) {
+	for _, m := range errMessages {
+		if errors.Is(err, m.err) {
+			return m.id, true
+		}
+	}
+
+	return "", false
+}
@@ -2,15 +2,115 @@ package messages
 
 const (
 	MsgWALoginSuccess   = "Login Success, redirecting..."
+	MsgWALoginFailed    = "Couldn't link WhatsApp, please try again (ref: %s)"
 	MsgErrInvalidAPIKey = "Invalid API key"
 
 	ResourceCreated         = "Resource created"
 	ValidKey                = "Valid key"
 	GroupWhitelistSuccess   = "Group whitelisted successfully"
 	GroupUnwhitelistSuccess = "Group unwhitelisted successfully"
+	UserWhitelistSuccess    = "User whitelisted successfully"
+	UserUnwhitelistSuccess  = "User unwhitelisted successfully"
+	GroupSettingsSaved      = "Group settings saved"
+	GroupsCleanedUp         = "Groups the bot isn't in were unwhitelisted"
+	GroupJoined             = "Joined the group"
+	GroupJoinedWhitelisted  = "Joined and whitelisted the group"
+	RegistrationCodeCreated = "Registration code created"
+	RegistrationCodeDeleted = "Registration code deleted"
+	RoleAssigned            = "Role assigned"
+	RoleUnassigned          = "Role removed"
+	TemplateSaved           = "Template saved"
+	TemplateReset           = "Template reset to its default"
 	ServerIsStarting        = "Server is starting..."
-	ServerStartFinish       = "The server start (ID: %d) process has finished. Final status: %s."
-	ServerIsStopping        = "Server is stopping :)"
+	DeviceCreated           = "Device added, select it to pair it"
+	DeviceRemoved           = "Device removed"
+	DeviceSelected          = "Device selected"
+	DeviceLoggedOut         = "Device logged out"
+)
+
+// IDs of the bot replies in the i18n catalog (internal/i18n/locales), which
+// has their text in each language.
+const (
+	CmdUnknown    = "cmd.unknown"
+	CmdDidYouMean = "cmd.did_you_mean"
+	CmdTimeout    = "cmd.timeout"
+	CmdFailed     = "cmd.failed"
+	CmdUsage      = "cmd.usage"
+
+	ConfirmStopServer    = "confirm.stop_server" // plural, by the number of online players
+	ConfirmRestartServer = "confirm.restart_server"
+	ConfirmCancelled     = "confirm.cancelled"
+	ReplyTimeout         = "reply.timeout"
+	ReplyAsDocument      = "reply.as_document"
+
+	PollTimeout             = "poll.timeout"
+	PollChooseServerStart   = "poll.choose_server.start"
+	PollChooseServerStop    = "poll.choose_server.stop"
+	PollChooseServerRestart = "poll.choose_server.restart"
+	PollChooseServerInfo    = "poll.choose_server.info"
+	PollChooseServerPlayers = "poll.choose_server.players"
+	PollChooseServerOther   = "poll.choose_server.other" // any other command, by its name
+
+	StartVoteOpened       = "start_vote.opened"
+	StartVoteJoined       = "start_vote.joined"
+	StartVoteAlreadyVoted = "start_vote.already_voted"
+	StartVotePassed       = "start_vote.passed"
+	StartVoteExpired      = "start_vote.expired"
+
+	UptimeUnknown = "uptime.unknown" // the server was already online when the bot first saw it
+
+	JobCancelled = "job.cancelled"
+	JobFailed    = "job.failed"
+
+	ErrForbidden               = "err.forbidden"
+	ErrForbiddenRole           = "err.forbidden_role"
+	ErrRateLimited             = "err.rate_limited"
+	ErrCommandCooldown         = "err.cooldown"
+	ErrCommandNotFound         = "err.command_not_found"
+	ErrServerNotFound          = "err.server_not_found"
+	ErrServerIsAlreadyStopping = "err.server_already_stopping"
+	ErrServerNotInScope        = "err.server_not_in_scope"
+	ErrJobNotFound             = "err.job_not_found"
+	ErrRegistrationCodeInvalid = "err.registration_code_invalid"
+	ErrGroupAlreadyWhitelisted = "err.group_already_whitelisted"
+	ErrRegisterGroupOnly       = "err.register_group_only"
+
+	ArgUnknownFlag      = "arg.unknown_flag"
+	ArgFlagTakesNoValue = "arg.flag_takes_no_value"
+	ArgFlagNeedsValue   = "arg.flag_needs_value"
+	ArgInvalidFlag      = "arg.invalid_flag"
+	ArgMissing          = "arg.missing"
+	ArgInvalid          = "arg.invalid"
+	ArgTooMany          = "arg.too_many"
+	ArgMustBeNumber     = "arg.must_be_number"
+	ArgMustBeOneOf      = "arg.must_be_one_of"
+	UsageArguments      = "usage.arguments"
+	UsageFlags          = "usage.flags"
+	UsageOptional       = "usage.optional"
+	UsageOneOf          = "usage.one_of"
+)
+
+// IDs of the command help texts, see command.Command.Help and command.Arg.Help.
+const (
+	HelpCancel   = "help.cancel"
+	HelpHelp     = "help.help"
+	HelpInfo     = "help.info"
+	HelpJobs     = "help.jobs"
+	HelpLang     = "help.lang"
+	HelpPlayers  = "help.players"
+	HelpRegister = "help.register"
+	HelpRestart  = "help.restart"
+	HelpServers  = "help.servers"
+	HelpStart    = "help.start"
+	HelpStatus   = "help.status"
+	HelpStop     = "help.stop"
+	HelpWhoAmI   = "help.whoami"
 
-	CmdShowingPage = "(/%s) showing page %d out of %d"
+	ArgHelpServerID         = "arg_help.server_id"
+	ArgHelpJobID            = "arg_help.job_id"
+	ArgHelpLanguage         = "arg_help.language"
+	ArgHelpOwnCredit        = "arg_help.own_credit"
+	ArgHelpPage             = "arg_help.page"
+	ArgHelpTopic            = "arg_help.topic"
+	ArgHelpRegistrationCode = "arg_help.registration_code"
 )
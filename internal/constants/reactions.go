@@ -0,0 +1,8 @@
+package constants
+
+// Reactions of the bot on the message of a command, showing its status.
+const (
+	ReactionRunning = "⏳"
+	ReactionDone    = "✅"
+	ReactionFailed  = "❌"
+)
@@ -0,0 +1,11 @@
+package constants
+
+// WhatsappGroupSettings keys for specific per group settings (db).
+const (
+	GroupStartVoteThreshold = "start_vote_threshold" // int, 0 or 1 disables the vote
+	GroupStartVoteDeadline  = "start_vote_deadline"  // string (time.Duration)
+	GroupAdminsAreOperators = "admins_are_operators" // bool, group admins get the operator role
+	GroupCardMode           = "card_mode"            // bool, /info and /status reply with an image card
+	GroupLanguage           = "language"             // string (i18n.Lang), the language of the replies
+	GroupServers            = "servers"              // string, comma separated exaroton IDs of the servers the group can use, empty for every server
+)
@@ -0,0 +1,8 @@
+package entity
+
+// MessageTemplate is a message template overridden from the web UI, the
+// templates without a row use their default.
+type MessageTemplate struct {
+	Name string `gorm:"column:name"`
+	Body string `gorm:"column:body"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// WhatsappConnectionLog is a connection state a device went thru, see
+// dto.WhatsappConnState.
+type WhatsappConnectionLog struct {
+	ID        uint
+	DeviceID  uint `gorm:"column:device_id"`
+	State     string
+	Reason    string
+	CreatedAt time.Time
+}
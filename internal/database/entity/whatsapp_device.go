@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// WhatsappDevice is a bot account, JID is the paired whatsmeow store device
+// and is empty until the device is paired.
+type WhatsappDevice struct {
+	ID        uint
+	Name      string
+	JID       string `gorm:"column:jid"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,9 @@
+package entity
+
+type WhatsappGroupSettings struct {
+	DeviceID  uint   `gorm:"column:device_id"`
+	JID       string `gorm:"column:jid"`
+	ServerJID string `gorm:"column:server_jid"`
+	Key       string
+	Value     string
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// WhatsappRegistrationCode is a one-time code a group redeems with /register
+// to be whitelisted, with the settings it presets.
+type WhatsappRegistrationCode struct {
+	ID                 uint
+	DeviceID           uint `gorm:"column:device_id"`
+	Code               string
+	Servers            string // comma separated exaroton server IDs, empty for every server
+	AdminsAreOperators bool
+	ExpiresAt          time.Time
+	RedeemedAt         *time.Time // nil until redeemed
+	CreatedAt          time.Time
+}
+
+// WhatsappGroupRegistration is a redeemed registration code, the audit trail
+// of the groups whitelisted with /register.
+type WhatsappGroupRegistration struct {
+	ID             uint
+	DeviceID       uint `gorm:"column:device_id"`
+	Code           string
+	GroupJID       string `gorm:"column:group_jid"`
+	GroupServerJID string `gorm:"column:group_server_jid"`
+	GroupName      string
+	RedeemedBy     string // the sender's jid
+	CreatedAt      time.Time
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// start vote statuses
+const (
+	StartVoteStatusOpen    = "open"
+	StartVoteStatusPassed  = "passed"
+	StartVoteStatusExpired = "expired"
+)
+
+type WhatsappStartVote struct {
+	ID         uint
+	DeviceID   uint   `gorm:"column:device_id"`
+	JID        string `gorm:"column:jid"`        // chat
+	ServerJID  string `gorm:"column:server_jid"` // chat
+	ServerID   string `gorm:"column:server_id"`  // exaroton id
+	ServerName string
+	Threshold  int
+	MessageID  string // the bot's vote message, reacting to it counts as a vote
+	Status     string
+	Deadline   time.Time
+	CreatedAt  time.Time
+
+	Voters []WhatsappStartVoteVoter `gorm:"foreignKey:VoteID"`
+}
+
+type WhatsappStartVoteVoter struct {
+	VoteID uint
+	Voter  string
+}
@@ -0,0 +1,12 @@
+package entity
+
+// WhatsappUserRole is a role assigned to a user (by phone number or LID),
+// the group is empty if the role applies to every chat.
+type WhatsappUserRole struct {
+	DeviceID       uint   `gorm:"column:device_id"`
+	GroupJID       string `gorm:"column:group_jid"`
+	GroupServerJID string `gorm:"column:group_server_jid"`
+	JID            string `gorm:"column:jid"`
+	ServerJID      string `gorm:"column:server_jid"`
+	Role           string `gorm:"column:role"`
+}
@@ -1,6 +1,16 @@
 package entity
 
+import "time"
+
 type WhatsappWhitelistedGroup struct {
+	DeviceID  uint   `gorm:"column:device_id"`
 	JID       string `gorm:"column:jid"`
 	ServerJID string `gorm:"column:server_jid"`
+
+	// the group's info, kept current from the group events, so it's still
+	// shown after the bot left the group
+	Name       string     `gorm:"column:name"`
+	Topic      string     `gorm:"column:topic"`
+	LastSeenAt *time.Time `gorm:"column:last_seen_at"` // the bot was last seen in the group
+	LeftAt     *time.Time `gorm:"column:left_at"`      // nil while the bot is in the group
 }
@@ -0,0 +1,8 @@
+package entity
+
+// WhatsappWhitelistedUser is a user allowed to use the bot in direct messages.
+type WhatsappWhitelistedUser struct {
+	DeviceID uint   `gorm:"column:device_id"`
+	JID      string `gorm:"column:jid"` // phone number
+	Name     string `gorm:"column:name"`
+}
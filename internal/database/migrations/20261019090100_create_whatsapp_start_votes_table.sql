@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE whatsapp_start_votes
+(
+  id         INTEGER PRIMARY KEY AUTOINCREMENT,
+  jid        TEXT     NOT NULL,
+  server_jid TEXT     NOT NULL,
+  server_idx INTEGER  NOT NULL,
+  threshold  INTEGER  NOT NULL,
+  message_id TEXT     NOT NULL DEFAULT '',
+  status     TEXT     NOT NULL,
+  deadline   DATETIME NOT NULL,
+  created_at DATETIME NOT NULL
+);
+
+CREATE INDEX idx_whatsapp_start_votes_chat_status ON whatsapp_start_votes (jid, server_jid, status);
+
+CREATE TABLE whatsapp_start_vote_voters
+(
+  vote_id INTEGER NOT NULL REFERENCES whatsapp_start_votes (id) ON DELETE CASCADE,
+  voter   TEXT    NOT NULL,
+  PRIMARY KEY (vote_id, voter)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS whatsapp_start_vote_voters;
+DROP TABLE IF EXISTS whatsapp_start_votes;
+-- +goose StatementEnd
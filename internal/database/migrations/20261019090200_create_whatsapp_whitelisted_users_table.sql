@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE whatsapp_whitelisted_users
+(
+  jid  TEXT NOT NULL PRIMARY KEY, -- phone number
+  name TEXT NOT NULL DEFAULT ''
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS whatsapp_whitelisted_users;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE whatsapp_user_roles
+(
+  group_jid        TEXT NOT NULL DEFAULT '', -- empty for every chat
+  group_server_jid TEXT NOT NULL DEFAULT '',
+  jid              TEXT NOT NULL,
+  server_jid       TEXT NOT NULL,
+  role             TEXT NOT NULL,
+  PRIMARY KEY (group_jid, group_server_jid, jid, server_jid)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS whatsapp_user_roles;
+-- +goose StatementEnd
@@ -0,0 +1,127 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE whatsapp_devices
+(
+  id         INTEGER PRIMARY KEY AUTOINCREMENT,
+  name       TEXT     NOT NULL,
+  jid        TEXT     NOT NULL DEFAULT '', -- the paired whatsmeow store device, empty if not paired
+  created_at DATETIME NOT NULL
+);
+
+-- the device of the existing data, it adopts the paired store device on startup
+INSERT INTO whatsapp_devices (id, name, created_at) VALUES (1, 'Default', CURRENT_TIMESTAMP);
+
+-- every whitelist and setting belongs to a device
+CREATE TABLE whatsapp_whitelisted_groups_new
+(
+  device_id  INTEGER NOT NULL DEFAULT 1,
+  jid        TEXT    NOT NULL,
+  server_jid TEXT    NOT NULL,
+  PRIMARY KEY (device_id, jid, server_jid)
+);
+INSERT INTO whatsapp_whitelisted_groups_new (jid, server_jid) SELECT jid, server_jid FROM whatsapp_whitelisted_groups;
+DROP TABLE whatsapp_whitelisted_groups;
+ALTER TABLE whatsapp_whitelisted_groups_new RENAME TO whatsapp_whitelisted_groups;
+
+CREATE TABLE whatsapp_whitelisted_users_new
+(
+  device_id INTEGER NOT NULL DEFAULT 1,
+  jid       TEXT    NOT NULL, -- phone number
+  name      TEXT    NOT NULL DEFAULT '',
+  PRIMARY KEY (device_id, jid)
+);
+INSERT INTO whatsapp_whitelisted_users_new (jid, name) SELECT jid, name FROM whatsapp_whitelisted_users;
+DROP TABLE whatsapp_whitelisted_users;
+ALTER TABLE whatsapp_whitelisted_users_new RENAME TO whatsapp_whitelisted_users;
+
+CREATE TABLE whatsapp_group_settings_new
+(
+  device_id  INTEGER NOT NULL DEFAULT 1,
+  jid        TEXT    NOT NULL,
+  server_jid TEXT    NOT NULL,
+  key        TEXT    NOT NULL,
+  value      TEXT    NOT NULL,
+  PRIMARY KEY (device_id, jid, server_jid, key)
+);
+INSERT INTO whatsapp_group_settings_new (jid, server_jid, key, value) SELECT jid, server_jid, key, value FROM whatsapp_group_settings;
+DROP TABLE whatsapp_group_settings;
+ALTER TABLE whatsapp_group_settings_new RENAME TO whatsapp_group_settings;
+
+CREATE TABLE whatsapp_user_roles_new
+(
+  device_id        INTEGER NOT NULL DEFAULT 1,
+  group_jid        TEXT    NOT NULL DEFAULT '', -- empty for every chat
+  group_server_jid TEXT    NOT NULL DEFAULT '',
+  jid              TEXT    NOT NULL,
+  server_jid       TEXT    NOT NULL,
+  role             TEXT    NOT NULL,
+  PRIMARY KEY (device_id, group_jid, group_server_jid, jid, server_jid)
+);
+INSERT INTO whatsapp_user_roles_new (group_jid, group_server_jid, jid, server_jid, role)
+SELECT group_jid, group_server_jid, jid, server_jid, role FROM whatsapp_user_roles;
+DROP TABLE whatsapp_user_roles;
+ALTER TABLE whatsapp_user_roles_new RENAME TO whatsapp_user_roles;
+
+-- votes keep their ids, the voters reference them
+ALTER TABLE whatsapp_start_votes ADD COLUMN device_id INTEGER NOT NULL DEFAULT 1;
+DROP INDEX idx_whatsapp_start_votes_chat_status;
+CREATE INDEX idx_whatsapp_start_votes_chat_status ON whatsapp_start_votes (device_id, jid, server_jid, status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- only the data of the default device is kept
+DROP INDEX idx_whatsapp_start_votes_chat_status;
+DELETE FROM whatsapp_start_vote_voters WHERE vote_id IN (SELECT id FROM whatsapp_start_votes WHERE device_id != 1);
+DELETE FROM whatsapp_start_votes WHERE device_id != 1;
+ALTER TABLE whatsapp_start_votes DROP COLUMN device_id;
+CREATE INDEX idx_whatsapp_start_votes_chat_status ON whatsapp_start_votes (jid, server_jid, status);
+
+CREATE TABLE whatsapp_user_roles_old
+(
+  group_jid        TEXT NOT NULL DEFAULT '',
+  group_server_jid TEXT NOT NULL DEFAULT '',
+  jid              TEXT NOT NULL,
+  server_jid       TEXT NOT NULL,
+  role             TEXT NOT NULL,
+  PRIMARY KEY (group_jid, group_server_jid, jid, server_jid)
+);
+INSERT INTO whatsapp_user_roles_old (group_jid, group_server_jid, jid, server_jid, role)
+SELECT group_jid, group_server_jid, jid, server_jid, role FROM whatsapp_user_roles WHERE device_id = 1;
+DROP TABLE whatsapp_user_roles;
+ALTER TABLE whatsapp_user_roles_old RENAME TO whatsapp_user_roles;
+
+CREATE TABLE whatsapp_group_settings_old
+(
+  jid        TEXT NOT NULL,
+  server_jid TEXT NOT NULL,
+  key        TEXT NOT NULL,
+  value      TEXT NOT NULL,
+  PRIMARY KEY (jid, server_jid, key)
+);
+INSERT INTO whatsapp_group_settings_old (jid, server_jid, key, value)
+SELECT jid, server_jid, key, value FROM whatsapp_group_settings WHERE device_id = 1;
+DROP TABLE whatsapp_group_settings;
+ALTER TABLE whatsapp_group_settings_old RENAME TO whatsapp_group_settings;
+
+CREATE TABLE whatsapp_whitelisted_users_old
+(
+  jid  TEXT NOT NULL PRIMARY KEY,
+  name TEXT NOT NULL DEFAULT ''
+);
+INSERT INTO whatsapp_whitelisted_users_old (jid, name) SELECT jid, name FROM whatsapp_whitelisted_users WHERE device_id = 1;
+DROP TABLE whatsapp_whitelisted_users;
+ALTER TABLE whatsapp_whitelisted_users_old RENAME TO whatsapp_whitelisted_users;
+
+CREATE TABLE whatsapp_whitelisted_groups_old
+(
+  jid        TEXT NOT NULL,
+  server_jid TEXT NOT NULL,
+  PRIMARY KEY (jid, server_jid)
+);
+INSERT INTO whatsapp_whitelisted_groups_old (jid, server_jid) SELECT jid, server_jid FROM whatsapp_whitelisted_groups WHERE device_id = 1;
+DROP TABLE whatsapp_whitelisted_groups;
+ALTER TABLE whatsapp_whitelisted_groups_old RENAME TO whatsapp_whitelisted_groups;
+
+DROP TABLE IF EXISTS whatsapp_devices;
+-- +goose StatementEnd
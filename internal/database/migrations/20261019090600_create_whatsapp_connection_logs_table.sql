@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE whatsapp_connection_logs
+(
+  id         INTEGER PRIMARY KEY AUTOINCREMENT,
+  device_id  INTEGER  NOT NULL,
+  state      TEXT     NOT NULL,
+  reason     TEXT     NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL
+);
+
+CREATE INDEX idx_whatsapp_connection_logs_device ON whatsapp_connection_logs (device_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE whatsapp_connection_logs;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE whatsapp_whitelisted_groups ADD COLUMN name TEXT NOT NULL DEFAULT '';
+ALTER TABLE whatsapp_whitelisted_groups ADD COLUMN topic TEXT NOT NULL DEFAULT '';
+ALTER TABLE whatsapp_whitelisted_groups ADD COLUMN last_seen_at DATETIME NULL;
+ALTER TABLE whatsapp_whitelisted_groups ADD COLUMN left_at DATETIME NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE whatsapp_whitelisted_groups DROP COLUMN left_at;
+ALTER TABLE whatsapp_whitelisted_groups DROP COLUMN last_seen_at;
+ALTER TABLE whatsapp_whitelisted_groups DROP COLUMN topic;
+ALTER TABLE whatsapp_whitelisted_groups DROP COLUMN name;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE whatsapp_registration_codes
+(
+  id                   INTEGER PRIMARY KEY AUTOINCREMENT,
+  device_id            INTEGER  NOT NULL,
+  code                 TEXT     NOT NULL,
+  servers              TEXT     NOT NULL DEFAULT '', -- the servers setting given to the group, empty for every server
+  admins_are_operators BOOLEAN  NOT NULL DEFAULT FALSE,
+  expires_at           DATETIME NOT NULL,
+  redeemed_at          DATETIME NULL,
+  created_at           DATETIME NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_whatsapp_registration_codes_code ON whatsapp_registration_codes (code);
+
+-- the audit trail of the redeemed codes, it's kept when a code is deleted
+CREATE TABLE whatsapp_group_registrations
+(
+  id               INTEGER PRIMARY KEY AUTOINCREMENT,
+  device_id        INTEGER  NOT NULL,
+  code             TEXT     NOT NULL,
+  group_jid        TEXT     NOT NULL,
+  group_server_jid TEXT     NOT NULL,
+  group_name       TEXT     NOT NULL DEFAULT '',
+  redeemed_by      TEXT     NOT NULL, -- the sender's jid
+  created_at       DATETIME NOT NULL
+);
+
+CREATE INDEX idx_whatsapp_group_registrations_device ON whatsapp_group_registrations (device_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE whatsapp_group_registrations;
+DROP TABLE whatsapp_registration_codes;
+-- +goose StatementEnd
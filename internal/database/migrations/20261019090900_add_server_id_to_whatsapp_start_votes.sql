@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- votes keep the server's exaroton id, its index changes when the server list does.
+-- The open votes by index can't be mapped to an id, they're closed without an announcement.
+UPDATE whatsapp_start_votes SET status = 'expired' WHERE status = 'open';
+ALTER TABLE whatsapp_start_votes ADD COLUMN server_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE whatsapp_start_votes ADD COLUMN server_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE whatsapp_start_votes DROP COLUMN server_idx;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE whatsapp_start_votes SET status = 'expired' WHERE status = 'open';
+ALTER TABLE whatsapp_start_votes ADD COLUMN server_idx INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE whatsapp_start_votes DROP COLUMN server_name;
+ALTER TABLE whatsapp_start_votes DROP COLUMN server_id;
+-- +goose StatementEnd
@@ -1,6 +1,10 @@
 package dto
 
-import "pkg.icikowski.pl/exaroton/model"
+import (
+	"time"
+
+	"pkg.icikowski.pl/exaroton/model"
+)
 
 type ExarotonAccountInfo struct {
 	// Name represents the account's name.
@@ -186,3 +190,19 @@ type StartExarotonServerRes struct {
 	Status <-chan ServerStatus
 	Err    error
 }
+
+// ExarotonServerStatus is a single entry of the servers overview.
+type ExarotonServerStatus struct {
+	// Idx is the server index, as used by the other commands.
+	Idx uint
+
+	// Server is the fetched server info. Falls back to the server list entry
+	// when fetching the details failed (see Err).
+	Server *ExarotonServerInfo
+
+	// OnlineSince is the time the server was first seen online, nil if unknown.
+	OnlineSince *time.Time
+
+	// Err is the error from fetching the server details, if any.
+	Err error
+}
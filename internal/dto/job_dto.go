@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// Job is a background job started by a command, e.g. starting a server.
+type Job struct {
+	ID        uint
+	Name      string
+	Chat      WhatsappJID // the chat the job was started from
+	Device    uint        // the bot account of the chat
+	StartedAt time.Time
+}
@@ -0,0 +1,146 @@
+package dto
+
+import (
+	"exaroton-wa-bot/internal/database/entity"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// The data of each message template (see render.TemplateDefs), the fields are
+// what the templates can use.
+type (
+	HelpPageTmplData struct {
+		Command   string
+		Page      int
+		TotalPage int
+		Commands  []HelpEntryTmplData
+	}
+
+	HelpEntryTmplData struct {
+		Name string
+		Help string
+	}
+
+	HelpCommandTmplData struct {
+		Name     string
+		Help     string
+		Usage    string
+		Aliases  []string
+		Role     string // the needed role, empty if anyone can use the command
+		Cooldown string // e.g. "2 min per id", empty if none
+		Detail   string // the args and flags, empty if none
+	}
+
+	ServersTmplData struct {
+		Command   string
+		Page      int
+		TotalPage int
+		Servers   []ServerTmplData
+	}
+
+	ServerTmplData struct {
+		Idx    uint
+		Server *ExarotonServerInfo
+	}
+
+	PlayersTmplData struct {
+		Idx     uint
+		Players []string
+	}
+
+	StatusTmplData struct {
+		Command string
+		Servers []ServerStatusTmplData
+	}
+
+	ServerStatusTmplData struct {
+		Idx      uint
+		Server   *ExarotonServerInfo
+		Outdated bool   // fetching the details failed, the server is from the list
+		Uptime   string // e.g. "1h 5m", empty if not online
+	}
+
+	JobsTmplData struct {
+		Command string
+		Jobs    []JobTmplData
+	}
+
+	JobTmplData struct {
+		ID   uint
+		Name string
+		Age  string // e.g. "1m30s"
+	}
+
+	// JobIDTmplData is the data of templates about a job, e.g. cancelling it.
+	JobIDTmplData struct {
+		JobID uint
+	}
+
+	// ServerIdxTmplData is the data of templates about a server action, e.g. stopping it.
+	ServerIdxTmplData struct {
+		Idx int
+	}
+
+	StartTmplData struct {
+		Idx    int
+		Status ServerStatus
+		JobID  uint
+	}
+
+	WhoAmITmplData struct {
+		IDs  []string // the user's jids
+		Role string
+	}
+
+	// LangTmplData is the language of the chat, or the new one if Changed.
+	LangTmplData struct {
+		Code    string
+		Name    string
+		Changed bool
+		Langs   []LangOptionTmplData // the available languages
+	}
+
+	LangOptionTmplData struct {
+		Code string
+		Name string
+	}
+
+	// RegisterTmplData is a group whitelisted with /register.
+	RegisterTmplData struct {
+		Servers []string // the servers the group can use, e.g. "#0 Survival", empty for every server
+	}
+)
+
+// MessageTemplate is a message template, as edited in the web UI.
+type MessageTemplate struct {
+	Name     string `json:"name"`
+	Help     string `json:"help"`
+	Default  string `json:"default"`
+	Override string `json:"override"` // empty if the default is used
+}
+
+type UpdateMessageTemplateReq struct {
+	Name string `json:"name"`
+	Body string `json:"body"`
+}
+
+func (r *UpdateMessageTemplateReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required),
+		validation.Field(&r.Body, validation.Required, validation.Length(1, 4096)),
+	)
+}
+
+func (r *UpdateMessageTemplateReq) ToEntity() *entity.MessageTemplate {
+	return &entity.MessageTemplate{Name: r.Name, Body: r.Body}
+}
+
+type ResetMessageTemplateReq struct {
+	Name string `json:"name"`
+}
+
+func (r *ResetMessageTemplateReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required),
+	)
+}
@@ -29,6 +29,11 @@ type WebSession interface {
 	GetOldInput(c echo.Context) (WebOldInput, error)
 	// Set old input
 	SetOldInput(c echo.Context, oldInput Mappable) error
+
+	// Get the whatsapp device managed in the web ui, 0 if none is selected
+	GetWhatsappDevice(c echo.Context) (uint, error)
+	// Set the whatsapp device managed in the web ui
+	SetWhatsappDevice(c echo.Context, deviceID uint) error
 }
 
 type webSession struct{}
@@ -109,6 +114,7 @@ var (
 	sessionFlashName    = "_flash"
 	sessionValErrName   = "_val_err"
 	sessionOldInputName = "_old_input"
+	sessionWADeviceName = "whatsapp_device"
 )
 
 func (s *webSession) GetFlash(c echo.Context) (WebFlashMessage, error) {
@@ -245,4 +251,26 @@ func (s *webSession) SetOldInput(c echo.Context, oldInput Mappable) error {
 	return sess.Save(c.Request(), c.Response())
 }
 
+func (s *webSession) GetWhatsappDevice(c echo.Context) (uint, error) {
+	sess, err := session.Get(sessionBaseName, c)
+	if err != nil {
+		return 0, err
+	}
+
+	deviceID, _ := sess.Values[sessionWADeviceName].(uint)
+
+	return deviceID, nil
+}
+
+func (s *webSession) SetWhatsappDevice(c echo.Context, deviceID uint) error {
+	sess, err := session.Get(sessionBaseName, c)
+	if err != nil {
+		return err
+	}
+
+	sess.Values[sessionWADeviceName] = deviceID
+
+	return sess.Save(c.Request(), c.Response())
+}
+
 // ==========================================
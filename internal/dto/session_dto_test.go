@@ -349,3 +349,18 @@ func TestWeSession_GetSetOldInput(t *testing.T) {
 		})
 	}
 }
+
+func TestWebSession_GetSetWhatsappDevice(t *testing.T) {
+	c, _ := setupTestContext()
+	ws := NewWebSession()
+
+	deviceID, err := ws.GetWhatsappDevice(c)
+	require.NoError(t, err)
+	assert.Zero(t, deviceID, "none is selected")
+
+	require.NoError(t, ws.SetWhatsappDevice(c, 2))
+
+	deviceID, err = ws.GetWhatsappDevice(c)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), deviceID)
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"exaroton-wa-bot/internal/database/entity"
+	"time"
+)
+
+type WhatsappStartVote struct {
+	ID         uint
+	Chat       WhatsappJID
+	ServerID   string
+	ServerName string
+	Threshold  int
+	Votes      int
+	MessageID  string
+	Status     string
+	Deadline   time.Time
+}
+
+func NewWhatsappStartVote(e *entity.WhatsappStartVote) *WhatsappStartVote {
+	return &WhatsappStartVote{
+		ID:         e.ID,
+		Chat:       WhatsappJID{User: e.JID, Server: e.ServerJID},
+		ServerID:   e.ServerID,
+		ServerName: e.ServerName,
+		Threshold:  e.Threshold,
+		Votes:      len(e.Voters),
+		MessageID:  e.MessageID,
+		Status:     e.Status,
+		Deadline:   e.Deadline,
+	}
+}
+
+type StartVoteReq struct {
+	Chat     WhatsappJID
+	ServerID string
+	Voter    string
+
+	// only used when a new vote is opened
+	ServerName string
+	Threshold  int
+	Deadline   time.Duration
+}
+
+type StartVoteRes struct {
+	Vote *WhatsappStartVote
+
+	// Opened is true if the vote was opened by this request.
+	Opened bool
+
+	// Joined is false if the voter had already voted.
+	Joined bool
+
+	// Passed is true if this request made the vote reach its threshold.
+	Passed bool
+}
@@ -0,0 +1,165 @@
+package dto
+
+import (
+	"exaroton-wa-bot/internal/database/entity"
+	"time"
+)
+
+// WhatsappConnEventType is a connection event of a device's client, see
+// WhatsappConnEvent.
+type WhatsappConnEventType string
+
+const (
+	WAConnEventConnected         WhatsappConnEventType = "connected"
+	WAConnEventDisconnected      WhatsappConnEventType = "disconnected" // by the server or the network
+	WAConnEventLoggedOut         WhatsappConnEventType = "logged_out"   // unlinked from the phone
+	WAConnEventLogout            WhatsappConnEventType = "logout"       // logged out from the web ui
+	WAConnEventStreamReplaced    WhatsappConnEventType = "stream_replaced"
+	WAConnEventTemporaryBan      WhatsappConnEventType = "temporary_ban"
+	WAConnEventClientOutdated    WhatsappConnEventType = "client_outdated"
+	WAConnEventConnectFailure    WhatsappConnEventType = "connect_failure"
+	WAConnEventKeepAliveTimeout  WhatsappConnEventType = "keepalive_timeout"
+	WAConnEventKeepAliveRestored WhatsappConnEventType = "keepalive_restored"
+)
+
+// WhatsappConnEvent is a whatsmeow connection event of a device.
+type WhatsappConnEvent struct {
+	Type   WhatsappConnEventType
+	Reason string        // e.g. the logout or the ban reason, may be empty
+	Expire time.Duration // of a temporary ban, 0 if unknown
+}
+
+// WhatsappConnState is the state of a device's connection, kept by the
+// connection supervisor.
+type WhatsappConnState string
+
+const (
+	WAConnStateDisconnected WhatsappConnState = "disconnected" // not connected yet, or logged out from the web ui
+	WAConnStateConnected    WhatsappConnState = "connected"
+	WAConnStateUnstable     WhatsappConnState = "unstable" // connected, but the keepalive pings fail
+	WAConnStateReconnecting WhatsappConnState = "reconnecting"
+	WAConnStateLoggedOut    WhatsappConnState = "logged_out"
+	WAConnStateReplaced     WhatsappConnState = "replaced" // another client uses the session
+	WAConnStateBanned       WhatsappConnState = "banned"
+	WAConnStateOutdated     WhatsappConnState = "outdated"
+	WAConnStateFailed       WhatsappConnState = "failed" // an unknown connect failure
+)
+
+// IsSessionLost reports whether reconnecting right away can't bring the device
+// back, e.g. it must be paired again. The admins are alerted when a device
+// gets there.
+func (s WhatsappConnState) IsSessionLost() bool {
+	switch s {
+	case WAConnStateLoggedOut, WAConnStateReplaced, WAConnStateBanned, WAConnStateOutdated, WAConnStateFailed:
+		return true
+	}
+
+	return false
+}
+
+func (s WhatsappConnState) String() string {
+	switch s {
+	case WAConnStateConnected:
+		return "Connected"
+	case WAConnStateUnstable:
+		return "Unstable"
+	case WAConnStateReconnecting:
+		return "Reconnecting"
+	case WAConnStateLoggedOut:
+		return "Logged out"
+	case WAConnStateReplaced:
+		return "Replaced by another client"
+	case WAConnStateBanned:
+		return "Temporarily banned"
+	case WAConnStateOutdated:
+		return "Client outdated"
+	case WAConnStateFailed:
+		return "Connection failed"
+	}
+
+	return "Disconnected"
+}
+
+// WhatsappConnStatus is the current connection state of a device.
+type WhatsappConnStatus struct {
+	State     WhatsappConnState `json:"state"`
+	Label     string            `json:"label"`
+	Reason    string            `json:"reason,omitempty"`
+	Since     time.Time         `json:"since"`
+	Attempts  int               `json:"attempts"`             // failed reconnects since the last connection
+	NextRetry *time.Time        `json:"next_retry,omitempty"` // nil if no reconnect is scheduled
+}
+
+// WhatsappConnLog is an entry of a device's connection history.
+type WhatsappConnLog struct {
+	State  WhatsappConnState `json:"state"`
+	Label  string            `json:"label"`
+	Reason string            `json:"reason,omitempty"`
+	At     time.Time         `json:"at"`
+}
+
+func NewWhatsappConnLog(e *entity.WhatsappConnectionLog) *WhatsappConnLog {
+	state := WhatsappConnState(e.State)
+
+	return &WhatsappConnLog{
+		State:  state,
+		Label:  state.String(),
+		Reason: e.Reason,
+		At:     e.CreatedAt,
+	}
+}
+
+// WhatsappConnAlert is sent to the admins when a device's session is lost,
+// it is also the body of the alert webhook.
+type WhatsappConnAlert struct {
+	DeviceID   uint              `json:"device_id"`
+	DeviceName string            `json:"device_name"`
+	State      WhatsappConnState `json:"state"`
+	Reason     string            `json:"reason,omitempty"`
+	At         time.Time         `json:"at"`
+}
+
+func (a *WhatsappConnAlert) Subject() string {
+	return "WhatsApp bot: " + a.DeviceName + " is " + a.State.String()
+}
+
+func (a *WhatsappConnAlert) Body() string {
+	body := "The WhatsApp device \"" + a.DeviceName + "\" went offline at " + a.At.Format(time.RFC1123) +
+		": " + a.State.String() + "."
+	if a.Reason != "" {
+		body += "\nReason: " + a.Reason
+	}
+
+	return body + "\n\n" + a.hint()
+}
+
+func (a *WhatsappConnAlert) hint() string {
+	switch a.State {
+	case WAConnStateLoggedOut:
+		return "Pair the device again from the web UI."
+	case WAConnStateReplaced:
+		return "Another client connected with the same session, stop it and restart the bot."
+	case WAConnStateBanned:
+		return "The bot reconnects once the ban expires."
+	case WAConnStateOutdated:
+		return "Update the bot."
+	}
+
+	return "Check the bot's logs."
+}
+
+// SMTPConfig is the server the alert emails are sent thru.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// WhatsappConnectionRes is the connection dashboard of a device.
+type WhatsappConnectionRes struct {
+	Status  WhatsappConnStatus `json:"status"`
+	History []*WhatsappConnLog `json:"history"`
+}
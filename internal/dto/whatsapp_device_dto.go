@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/database/entity"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// DefaultWhatsappDevice is the device created by the migration, it owns the
+// data from before there were several devices and can't be removed.
+const DefaultWhatsappDevice uint = 1
+
+type whatsappDeviceKey struct{}
+
+// WithWhatsappDevice returns a copy of ctx scoped to a device, the whatsapp
+// repositories use it to pick the client and the rows of that device.
+func WithWhatsappDevice(ctx context.Context, deviceID uint) context.Context {
+	return context.WithValue(ctx, whatsappDeviceKey{}, deviceID)
+}
+
+// WhatsappDeviceFromContext returns the device ctx is scoped to, the default
+// device if none.
+func WhatsappDeviceFromContext(ctx context.Context) uint {
+	if deviceID, ok := ctx.Value(whatsappDeviceKey{}).(uint); ok && deviceID != 0 {
+		return deviceID
+	}
+
+	return DefaultWhatsappDevice
+}
+
+// WhatsappDeviceStatus is the connection state of a device's client.
+type WhatsappDeviceStatus struct {
+	LoggedIn  bool   `json:"logged_in"`
+	Connected bool   `json:"connected"`
+	Synced    bool   `json:"synced"`
+	Phone     string `json:"phone"` // empty if not paired
+}
+
+// WhatsappDevice is a bot account with its connection status.
+type WhatsappDevice struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Default bool   `json:"default"` // can't be removed
+	Current bool   `json:"current"` // managed in the web ui
+	WhatsappDeviceStatus
+
+	// set by the handler, see IWhatsappConnectionService
+	Connection *WhatsappConnStatus `json:"connection,omitempty"`
+}
+
+func NewWhatsappDevice(e *entity.WhatsappDevice, status WhatsappDeviceStatus) *WhatsappDevice {
+	return &WhatsappDevice{
+		ID:                   e.ID,
+		Name:                 e.Name,
+		Default:              e.ID == DefaultWhatsappDevice,
+		WhatsappDeviceStatus: status,
+	}
+}
+
+type CreateWhatsappDeviceReq struct {
+	Name string `json:"name"`
+}
+
+func (r *CreateWhatsappDeviceReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Name, validation.Required, validation.Length(1, 64)),
+	)
+}
+
+// WhatsappDeviceReq targets a device, e.g. to select or remove it.
+type WhatsappDeviceReq struct {
+	ID uint `json:"id"`
+}
+
+func (r *WhatsappDeviceReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ID, validation.Required),
+	)
+}
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhatsappDeviceFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, DefaultWhatsappDevice, WhatsappDeviceFromContext(ctx), "the default device if unset")
+	assert.Equal(t, DefaultWhatsappDevice, WhatsappDeviceFromContext(WithWhatsappDevice(ctx, 0)))
+	assert.Equal(t, uint(3), WhatsappDeviceFromContext(WithWhatsappDevice(ctx, 3)))
+}
+
+func TestCreateWhatsappDeviceReq_Validate(t *testing.T) {
+	assert.NoError(t, (&CreateWhatsappDeviceReq{Name: "Community"}).Validate())
+	assert.Error(t, (&CreateWhatsappDeviceReq{}).Validate())
+	assert.Error(t, (&CreateWhatsappDeviceReq{Name: strings.Repeat("a", 65)}).Validate())
+}
@@ -1,7 +1,10 @@
 package dto
 
 import (
+	"exaroton-wa-bot/internal/constants/errs"
 	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/helper"
+	"regexp"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -32,6 +35,14 @@ const (
 	WhatsappQREventIsPairing = "error-is-pairing"
 )
 
+var phoneNumberRegex = regexp.MustCompile(`^[0-9]{5,20}$`)
+
+// pairPhoneRegex is an international phone number (E.164) without the +.
+var pairPhoneRegex = regexp.MustCompile(`^[1-9][0-9]{6,14}$`)
+
+// inviteLinkRegex is a group invite link, or only its code.
+var inviteLinkRegex = regexp.MustCompile(`^(https://chat\.whatsapp\.com/)?[0-9A-Za-z]{16,32}$`)
+
 type WhatsappJID struct {
 	User       string
 	RawAgent   uint8
@@ -50,6 +61,11 @@ func NewWhatsappJID(jid types.JID) WhatsappJID {
 	}
 }
 
+// String returns the JID as "user@server", e.g to identify a sender.
+func (w *WhatsappJID) String() string {
+	return w.User + "@" + w.Server
+}
+
 func (w *WhatsappJID) To() types.JID {
 	return types.JID{
 		User:       w.User,
@@ -60,14 +76,189 @@ func (w *WhatsappJID) To() types.JID {
 	}
 }
 
+// WhatsappMessage is an outgoing message: a text, or a document, image or
+// location if one of them is set.
 type WhatsappMessage struct {
-	Conversation *string
+	Conversation *string        // the text, or the caption of a document or image
+	Quote        *WhatsappQuote // nil if the message doesn't quote one
+	Mentions     []WhatsappJID  // mentioned users, the text should contain their "@user"
+
+	Document *WhatsappDocument
+	Image    *WhatsappImage
+	Location *WhatsappLocation
+}
+
+// WhatsappQuote is a message quoted by a reply.
+type WhatsappQuote struct {
+	MessageID string
+	Sender    WhatsappJID
+	Text      string // shown in the quote
+}
+
+// WhatsappMedia is the file of a document or an image. The repository uploads
+// it before sending the message.
+type WhatsappMedia struct {
+	Data     []byte
+	MimeType string
+
+	Uploaded *WhatsappUpload // nil until uploaded
+}
+
+// WhatsappUpload is where an uploaded media is stored on the WhatsApp servers
+// and the keys to decrypt it.
+type WhatsappUpload struct {
+	URL        string
+	DirectPath string
+
+	MediaKey      []byte
+	FileEncSHA256 []byte
+	FileSHA256    []byte
+	FileLength    uint64
+}
+
+type WhatsappDocument struct {
+	WhatsappMedia
+	FileName string
+}
+
+type WhatsappImage struct {
+	WhatsappMedia
+}
+
+type WhatsappLocation struct {
+	Latitude  float64
+	Longitude float64
+	Name      string // optional
+	Address   string // optional
+}
+
+// Media returns the media to upload before sending the message and its type,
+// nil if the message has none.
+func (w *WhatsappMessage) Media() (*WhatsappMedia, whatsmeow.MediaType) {
+	switch {
+	case w.Document != nil:
+		return &w.Document.WhatsappMedia, whatsmeow.MediaDocument
+	case w.Image != nil:
+		return &w.Image.WhatsappMedia, whatsmeow.MediaImage
+	}
+
+	return nil, ""
+}
+
+// WithUpload returns a copy of the message with its media uploaded to up, the
+// message itself is left as is.
+func (w *WhatsappMessage) WithUpload(up *WhatsappUpload) *WhatsappMessage {
+	msg := *w
+
+	switch {
+	case w.Document != nil:
+		doc := *w.Document
+		doc.Uploaded = up
+		msg.Document = &doc
+	case w.Image != nil:
+		img := *w.Image
+		img.Uploaded = up
+		msg.Image = &img
+	}
+
+	return &msg
 }
 
-func (w *WhatsappMessage) To() *waE2E.Message {
+// To converts the message to whatsmeow's, its media must be uploaded first:
+// it returns errs.ErrWAMediaNotUploaded otherwise.
+func (w *WhatsappMessage) To() (*waE2E.Message, error) {
+	ctxInfo := w.contextInfo()
+
+	switch {
+	case w.Document != nil:
+		up := w.Document.Uploaded
+		if up == nil {
+			return nil, errs.ErrWAMediaNotUploaded
+		}
+
+		return &waE2E.Message{
+			DocumentMessage: &waE2E.DocumentMessage{
+				URL:           &up.URL,
+				DirectPath:    &up.DirectPath,
+				MediaKey:      up.MediaKey,
+				FileEncSHA256: up.FileEncSHA256,
+				FileSHA256:    up.FileSHA256,
+				FileLength:    &up.FileLength,
+				Mimetype:      helper.Ptr(w.Document.MimeType),
+				FileName:      helper.Ptr(w.Document.FileName),
+				Title:         helper.Ptr(w.Document.FileName),
+				Caption:       w.Conversation,
+				ContextInfo:   ctxInfo,
+			},
+		}, nil
+
+	case w.Image != nil:
+		up := w.Image.Uploaded
+		if up == nil {
+			return nil, errs.ErrWAMediaNotUploaded
+		}
+
+		return &waE2E.Message{
+			ImageMessage: &waE2E.ImageMessage{
+				URL:           &up.URL,
+				DirectPath:    &up.DirectPath,
+				MediaKey:      up.MediaKey,
+				FileEncSHA256: up.FileEncSHA256,
+				FileSHA256:    up.FileSHA256,
+				FileLength:    &up.FileLength,
+				Mimetype:      helper.Ptr(w.Image.MimeType),
+				Caption:       w.Conversation,
+				ContextInfo:   ctxInfo,
+			},
+		}, nil
+
+	case w.Location != nil:
+		return &waE2E.Message{
+			LocationMessage: &waE2E.LocationMessage{
+				DegreesLatitude:  helper.Ptr(w.Location.Latitude),
+				DegreesLongitude: helper.Ptr(w.Location.Longitude),
+				Name:             helper.If(w.Location.Name == "", nil, helper.Ptr(w.Location.Name)),
+				Address:          helper.If(w.Location.Address == "", nil, helper.Ptr(w.Location.Address)),
+				ContextInfo:      ctxInfo,
+			},
+		}, nil
+
+	// quotes and mentions need an extended text message
+	case ctxInfo != nil:
+		return &waE2E.Message{
+			ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+				Text:        w.Conversation,
+				ContextInfo: ctxInfo,
+			},
+		}, nil
+	}
+
 	return &waE2E.Message{
 		Conversation: w.Conversation,
+	}, nil
+}
+
+// contextInfo returns the quote and mentions of the message, nil if it has none.
+func (w *WhatsappMessage) contextInfo() *waE2E.ContextInfo {
+	if w.Quote == nil && len(w.Mentions) == 0 {
+		return nil
 	}
+
+	info := &waE2E.ContextInfo{}
+
+	if w.Quote != nil {
+		sender := w.Quote.Sender.To()
+		info.StanzaID = helper.Ptr(w.Quote.MessageID)
+		info.Participant = helper.Ptr(sender.ToNonAD().String())
+		info.QuotedMessage = &waE2E.Message{Conversation: helper.Ptr(w.Quote.Text)}
+	}
+
+	for _, m := range w.Mentions {
+		jid := m.To()
+		info.MentionedJID = append(info.MentionedJID, jid.ToNonAD().String())
+	}
+
+	return info
 }
 
 type WhatsappSendResponse struct {
@@ -94,9 +285,9 @@ func NewWhatsappSendResponse(resp whatsmeow.SendResponse) WhatsappSendResponse {
 	}
 }
 
-// whatsapp qr websocket response
+// whatsapp qr websocket response, also used by the login with a pairing code
 type WhatsappQRWSRes struct {
-	Code  string `json:"code"`
+	Code  string `json:"code"` // the qr code, or the pairing code
 	Event string `json:"event"`
 	Error string `json:"error"` // public error message
 
@@ -116,6 +307,13 @@ type WhatsappGroupInfo struct {
 	// Group Parent
 	IsParent                      bool   `json:"is_parent"`
 	DefaultMembershipApprovalMode string `json:"default_membership_approval_mode"` // request_required
+
+	Topic string `json:"topic"`
+
+	// of a whitelisted group the bot isn't in anymore, it's shown from the
+	// stored info until it's cleaned up
+	Left       bool       `json:"left"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
 }
 
 func NewWhatsappGroupInfo(g *types.GroupInfo) *WhatsappGroupInfo {
@@ -131,9 +329,51 @@ func NewWhatsappGroupInfo(g *types.GroupInfo) *WhatsappGroupInfo {
 
 		IsParent:                      g.IsParent,
 		DefaultMembershipApprovalMode: g.DefaultMembershipApprovalMode,
+
+		Topic: g.Topic,
+	}
+}
+
+// NewWhatsappLeftGroupInfo is a whitelisted group the bot isn't in anymore.
+func NewWhatsappLeftGroupInfo(e *entity.WhatsappWhitelistedGroup) *WhatsappGroupInfo {
+	jid := types.NewJID(e.JID, e.ServerJID)
+
+	return &WhatsappGroupInfo{
+		JID:        jid,
+		JIDUser:    jid.User,
+		JIDServer:  jid.Server,
+		Name:       e.Name,
+		Topic:      e.Topic,
+		Left:       true,
+		LastSeenAt: e.LastSeenAt,
 	}
 }
 
+// WhatsappGroupInfoUpdate is a change of a group's info, a nil field is
+// unchanged.
+type WhatsappGroupInfoUpdate struct {
+	Group WhatsappJID
+	Name  *string
+	Topic *string
+}
+
+// WhatsappGroupsCleanupRes is the result of unwhitelisting the groups the bot
+// isn't in anymore.
+type WhatsappGroupsCleanupRes struct {
+	Removed int64 `json:"removed"`
+}
+
+// WhatsappLoginNumberReq is the phone number to link with a pairing code.
+type WhatsappLoginNumberReq struct {
+	Phone string `query:"phone"` // digits only, with the country code
+}
+
+func (r *WhatsappLoginNumberReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Phone, validation.Required, validation.Match(pairPhoneRegex).Error("must be digits only, with the country code, e.g. 628123456789")),
+	)
+}
+
 type SettingsWhatsappPageData struct {
 	PhoneNumber string
 }
@@ -154,6 +394,31 @@ func (r *WhitelistWhatsappGroupReq) Validate() error {
 	)
 }
 
+// WhatsappGroupInviteReq previews the group of an invite link.
+type WhatsappGroupInviteReq struct {
+	Link string `query:"link"` // https://chat.whatsapp.com/<code>, or only the code
+}
+
+func (r *WhatsappGroupInviteReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Link, validation.Required, validation.Match(inviteLinkRegex).Error("must be a https://chat.whatsapp.com/ invite link")),
+	)
+}
+
+// JoinWhatsappGroupReq joins the group of an invite link, and whitelists it
+// if asked.
+type JoinWhatsappGroupReq struct {
+	Link      string   `json:"link"` // https://chat.whatsapp.com/<code>, or only the code
+	Whitelist bool     `json:"whitelist"`
+	Servers   []string `json:"servers"` // the exaroton IDs of the servers the whitelisted group can use, empty for every server
+}
+
+func (r *JoinWhatsappGroupReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Link, validation.Required, validation.Match(inviteLinkRegex).Error("must be a https://chat.whatsapp.com/ invite link")),
+	)
+}
+
 // UnwhitelistWhatsappGroupReq
 type UnwhitelistWhatsappGroupReq struct {
 	User   string `json:"user"`
@@ -180,3 +445,38 @@ func NewWhatsappWhitelistedGroup(e *entity.WhatsappWhitelistedGroup) *WhatsappWh
 		ServerJID: e.ServerJID,
 	}
 }
+
+// WhatsappWhitelistedUser is a user allowed to use the bot in direct messages.
+type WhatsappWhitelistedUser struct {
+	Phone string `json:"phone"`
+	Name  string `json:"name"`
+}
+
+func NewWhatsappWhitelistedUser(e *entity.WhatsappWhitelistedUser) *WhatsappWhitelistedUser {
+	return &WhatsappWhitelistedUser{
+		Phone: e.JID,
+		Name:  e.Name,
+	}
+}
+
+type WhitelistWhatsappUserReq struct {
+	Phone string `json:"phone"` // digits only, with the country code
+	Name  string `json:"name"`
+}
+
+func (r *WhitelistWhatsappUserReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Phone, validation.Required, validation.Match(phoneNumberRegex).Error("must be digits only, with the country code")),
+		validation.Field(&r.Name, validation.Length(0, 64)),
+	)
+}
+
+type UnwhitelistWhatsappUserReq struct {
+	Phone string `json:"phone"`
+}
+
+func (r *UnwhitelistWhatsappUserReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Phone, validation.Required),
+	)
+}
@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"exaroton-wa-bot/internal/constants/errs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mau.fi/whatsmeow"
+)
+
+func TestWhatsappMessage_To(t *testing.T) {
+	text := "hello @6285"
+	quote := &WhatsappQuote{MessageID: "abc", Sender: WhatsappJID{User: "77", Device: 2, Server: "lid"}, Text: "/info 1"}
+	mention := WhatsappJID{User: "6285", Server: "s.whatsapp.net"}
+
+	// plain text
+	msg, err := (&WhatsappMessage{Conversation: &text}).To()
+	require.NoError(t, err)
+	assert.Equal(t, text, msg.GetConversation())
+	assert.Nil(t, msg.GetExtendedTextMessage())
+
+	// quotes and mentions
+	msg, err = (&WhatsappMessage{Conversation: &text, Quote: quote, Mentions: []WhatsappJID{mention}}).To()
+	require.NoError(t, err)
+	assert.Empty(t, msg.GetConversation())
+	assert.Equal(t, text, msg.GetExtendedTextMessage().GetText())
+
+	info := msg.GetExtendedTextMessage().GetContextInfo()
+	assert.Equal(t, "abc", info.GetStanzaID())
+	assert.Equal(t, "77@lid", info.GetParticipant())
+	assert.Equal(t, "/info 1", info.GetQuotedMessage().GetConversation())
+	assert.Equal(t, []string{"6285@s.whatsapp.net"}, info.GetMentionedJID())
+
+	// document
+	doc := &WhatsappMessage{Conversation: &text, Quote: quote, Document: &WhatsappDocument{
+		WhatsappMedia: WhatsappMedia{Data: []byte("a,b"), MimeType: "text/csv"},
+		FileName:      "players.csv",
+	}}
+	media, mediaType := doc.Media()
+	assert.Equal(t, whatsmeow.MediaDocument, mediaType)
+	assert.Equal(t, "text/csv", media.MimeType)
+
+	_, err = doc.To()
+	assert.ErrorIs(t, err, errs.ErrWAMediaNotUploaded)
+
+	uploaded := doc.WithUpload(&WhatsappUpload{URL: "https://mmg.whatsapp.net/x", DirectPath: "/x", FileLength: 3})
+	assert.Nil(t, doc.Document.Uploaded)
+
+	msg, err = uploaded.To()
+	require.NoError(t, err)
+	assert.Equal(t, "https://mmg.whatsapp.net/x", msg.GetDocumentMessage().GetURL())
+	assert.Equal(t, "players.csv", msg.GetDocumentMessage().GetFileName())
+	assert.Equal(t, "text/csv", msg.GetDocumentMessage().GetMimetype())
+	assert.Equal(t, uint64(3), msg.GetDocumentMessage().GetFileLength())
+	assert.Equal(t, text, msg.GetDocumentMessage().GetCaption())
+	assert.Equal(t, "abc", msg.GetDocumentMessage().GetContextInfo().GetStanzaID())
+
+	// image
+	img := &WhatsappMessage{Image: &WhatsappImage{WhatsappMedia: WhatsappMedia{Data: []byte{1}, MimeType: "image/png"}}}
+	_, mediaType = img.Media()
+	assert.Equal(t, whatsmeow.MediaImage, mediaType)
+
+	_, err = img.To()
+	assert.ErrorIs(t, err, errs.ErrWAMediaNotUploaded)
+
+	msg, err = img.WithUpload(&WhatsappUpload{URL: "https://mmg.whatsapp.net/y"}).To()
+	require.NoError(t, err)
+	assert.Equal(t, "https://mmg.whatsapp.net/y", msg.GetImageMessage().GetURL())
+	assert.Equal(t, "image/png", msg.GetImageMessage().GetMimetype())
+	assert.Nil(t, msg.GetImageMessage().Caption)
+
+	// location, no media
+	loc := &WhatsappMessage{Location: &WhatsappLocation{Latitude: -6.2, Longitude: 106.8, Name: "Spawn"}}
+	media, _ = loc.Media()
+	assert.Nil(t, media)
+
+	msg, err = loc.To()
+	require.NoError(t, err)
+	assert.Equal(t, -6.2, msg.GetLocationMessage().GetDegreesLatitude())
+	assert.Equal(t, "Spawn", msg.GetLocationMessage().GetName())
+	assert.Nil(t, msg.GetLocationMessage().Address)
+}
+
+func TestWhatsappLoginNumberReq_Validate(t *testing.T) {
+	for _, phone := range []string{"628123456789", "14155552671"} {
+		assert.NoError(t, (&WhatsappLoginNumberReq{Phone: phone}).Validate(), phone)
+	}
+
+	for _, phone := range []string{"", "+628123456789", "08123456789", "12345", "6281234567890123"} {
+		assert.Error(t, (&WhatsappLoginNumberReq{Phone: phone}).Validate(), phone)
+	}
+}
+
+func TestJoinWhatsappGroupReq_Validate(t *testing.T) {
+	for _, link := range []string{"https://chat.whatsapp.com/AbCdEfGhIjKlMnOpQrStUv", "AbCdEfGhIjKlMnOpQrStUv"} {
+		assert.NoError(t, (&JoinWhatsappGroupReq{Link: link}).Validate(), link)
+	}
+
+	for _, link := range []string{"", "http://chat.whatsapp.com/AbCdEfGhIjKlMnOpQrStUv", "https://example.com/AbCdEfGhIjKlMnOpQrStUv", "https://chat.whatsapp.com/short"} {
+		assert.Error(t, (&JoinWhatsappGroupReq{Link: link}).Validate(), link)
+	}
+}
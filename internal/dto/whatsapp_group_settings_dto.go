@@ -0,0 +1,171 @@
+package dto
+
+import (
+	"exaroton-wa-bot/internal/constants"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/i18n"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	defaultStartVoteDeadline = 10 * time.Minute
+)
+
+// WhatsappGroupSettings is the typed form of a group's key/value settings.
+type WhatsappGroupSettings struct {
+	// StartVoteThreshold is the number of distinct members that must vote
+	// before /start starts a server, 0 or 1 disables the vote.
+	StartVoteThreshold int `json:"start_vote_threshold"`
+
+	// StartVoteDeadline is how long a start vote stays open.
+	StartVoteDeadline time.Duration `json:"start_vote_deadline"`
+
+	// AdminsAreOperators gives the group admins at least the operator role.
+	AdminsAreOperators bool `json:"admins_are_operators"`
+
+	// CardMode replies /info and /status with an image card instead of text.
+	CardMode bool `json:"card_mode"`
+
+	// Language is the language of the replies, set with /lang. Empty if not
+	// set, the chat uses the configured default language.
+	Language i18n.Lang `json:"language"`
+
+	// Servers are the exaroton IDs of the servers the group can use, every
+	// server if empty. They're IDs rather than indexes in /servers, which
+	// shift as servers are added or removed.
+	Servers []string `json:"servers"`
+}
+
+// NewWhatsappGroupSettings builds the settings from db rows, unknown or
+// malformed keys fall back to their default value.
+func NewWhatsappGroupSettings(rows []*entity.WhatsappGroupSettings) *WhatsappGroupSettings {
+	settings := &WhatsappGroupSettings{
+		StartVoteDeadline: defaultStartVoteDeadline,
+	}
+
+	for _, row := range rows {
+		switch row.Key {
+		case constants.GroupStartVoteThreshold:
+			if v, err := strconv.Atoi(row.Value); err == nil {
+				settings.StartVoteThreshold = v
+			}
+		case constants.GroupStartVoteDeadline:
+			if v, err := time.ParseDuration(row.Value); err == nil && v > 0 {
+				settings.StartVoteDeadline = v
+			}
+		case constants.GroupAdminsAreOperators:
+			settings.AdminsAreOperators, _ = strconv.ParseBool(row.Value)
+		case constants.GroupCardMode:
+			settings.CardMode, _ = strconv.ParseBool(row.Value)
+		case constants.GroupLanguage:
+			if lang, ok := i18n.ParseLang(row.Value); ok {
+				settings.Language = lang
+			}
+		case constants.GroupServers:
+			settings.Servers = parseServerIDs(row.Value)
+		}
+	}
+
+	return settings
+}
+
+// AllowsServer returns true if the group can use the server of the exaroton ID.
+func (s *WhatsappGroupSettings) AllowsServer(serverID string) bool {
+	return len(s.Servers) == 0 || slices.Contains(s.Servers, serverID)
+}
+
+// NewWhatsappGroupServersSetting is the db row of the servers a group can use.
+func NewWhatsappGroupServersSetting(group WhatsappJID, servers []string) *entity.WhatsappGroupSettings {
+	return &entity.WhatsappGroupSettings{
+		JID:       group.User,
+		ServerJID: group.Server,
+		Key:       constants.GroupServers,
+		Value:     formatServerIDs(servers),
+	}
+}
+
+// formatServerIDs joins the exaroton server IDs with commas, see parseServerIDs.
+func formatServerIDs(servers []string) string {
+	return strings.Join(servers, ",")
+}
+
+// parseServerIDs parses the comma separated exaroton server IDs, blank ones
+// are skipped.
+func parseServerIDs(value string) []string {
+	servers := make([]string, 0)
+	for _, v := range strings.Split(value, ",") {
+		if id := strings.TrimSpace(v); id != "" {
+			servers = append(servers, id)
+		}
+	}
+
+	return servers
+}
+
+// IsStartVoteEnabled returns true if /start requires a vote in the group.
+func (s *WhatsappGroupSettings) IsStartVoteEnabled() bool {
+	return s.StartVoteThreshold > 1
+}
+
+type GetWhatsappGroupSettingsReq struct {
+	User   string `query:"user"`
+	Server string `query:"server"`
+}
+
+func (r *GetWhatsappGroupSettingsReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.User, validation.Required),
+		validation.Field(&r.Server, validation.Required),
+	)
+}
+
+type UpdateWhatsappGroupSettingsReq struct {
+	User   string `json:"user"`
+	Server string `json:"server"`
+
+	StartVoteThreshold       int `json:"start_vote_threshold"`
+	StartVoteDeadlineMinutes int `json:"start_vote_deadline_minutes"`
+
+	AdminsAreOperators bool `json:"admins_are_operators"`
+
+	CardMode bool `json:"card_mode"`
+
+	Language string `json:"language"` // empty for the default language
+
+	Servers []string `json:"servers"` // exaroton server IDs, empty for every server
+}
+
+func (r *UpdateWhatsappGroupSettingsReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.User, validation.Required),
+		validation.Field(&r.Server, validation.Required),
+		validation.Field(&r.StartVoteThreshold, validation.Min(0), validation.Max(100)),
+		validation.Field(&r.StartVoteDeadlineMinutes, validation.Required, validation.Min(1), validation.Max(24*60)),
+		validation.Field(&r.Language, validation.By(func(any) error {
+			if _, ok := i18n.ParseLang(r.Language); r.Language != "" && !ok {
+				return errs.ErrUnknownLanguage
+			}
+			return nil
+		})),
+	)
+}
+
+// ToEntities turns the request into db rows.
+func (r *UpdateWhatsappGroupSettingsReq) ToEntities() []*entity.WhatsappGroupSettings {
+	deadline := time.Duration(r.StartVoteDeadlineMinutes) * time.Minute
+
+	return []*entity.WhatsappGroupSettings{
+		{JID: r.User, ServerJID: r.Server, Key: constants.GroupStartVoteThreshold, Value: strconv.Itoa(r.StartVoteThreshold)},
+		{JID: r.User, ServerJID: r.Server, Key: constants.GroupStartVoteDeadline, Value: deadline.String()},
+		{JID: r.User, ServerJID: r.Server, Key: constants.GroupAdminsAreOperators, Value: strconv.FormatBool(r.AdminsAreOperators)},
+		{JID: r.User, ServerJID: r.Server, Key: constants.GroupCardMode, Value: strconv.FormatBool(r.CardMode)},
+		{JID: r.User, ServerJID: r.Server, Key: constants.GroupLanguage, Value: r.Language},
+		NewWhatsappGroupServersSetting(WhatsappJID{User: r.User, Server: r.Server}, r.Servers),
+	}
+}
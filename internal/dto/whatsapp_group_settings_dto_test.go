@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"exaroton-wa-bot/internal/constants"
+	"exaroton-wa-bot/internal/database/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhatsappGroupSettings_Servers(t *testing.T) {
+	group := WhatsappJID{User: "1203630", Server: "g.us"}
+
+	row := NewWhatsappGroupServersSetting(group, []string{"tgkm731xO7GiHt76", "Xm3zQp9a"})
+	assert.Equal(t, &entity.WhatsappGroupSettings{JID: "1203630", ServerJID: "g.us", Key: constants.GroupServers, Value: "tgkm731xO7GiHt76,Xm3zQp9a"}, row)
+
+	settings := NewWhatsappGroupSettings([]*entity.WhatsappGroupSettings{row})
+	assert.Equal(t, []string{"tgkm731xO7GiHt76", "Xm3zQp9a"}, settings.Servers)
+	assert.True(t, settings.AllowsServer("Xm3zQp9a"))
+	assert.False(t, settings.AllowsServer("other"))
+
+	// IDs are trimmed
+	settings = NewWhatsappGroupSettings([]*entity.WhatsappGroupSettings{{Key: constants.GroupServers, Value: " a , b"}})
+	assert.Equal(t, []string{"a", "b"}, settings.Servers)
+
+	// every server if empty, blank IDs are skipped
+	for _, value := range []string{"", " , "} {
+		settings = NewWhatsappGroupSettings([]*entity.WhatsappGroupSettings{{Key: constants.GroupServers, Value: value}})
+		assert.Empty(t, settings.Servers, value)
+		assert.True(t, settings.AllowsServer("other"), value)
+	}
+}
@@ -0,0 +1,127 @@
+package dto
+
+import (
+	"exaroton-wa-bot/internal/constants"
+	"exaroton-wa-bot/internal/database/entity"
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// maxRegistrationCodeLifetime is how long a registration code can stay valid.
+const maxRegistrationCodeLifetime = 7 * 24 * time.Hour
+
+// WhatsappRegistrationCodeStatus is the state of a registration code.
+type WhatsappRegistrationCodeStatus string
+
+const (
+	RegistrationCodeActive   WhatsappRegistrationCodeStatus = "active"
+	RegistrationCodeExpired  WhatsappRegistrationCodeStatus = "expired"
+	RegistrationCodeRedeemed WhatsappRegistrationCodeStatus = "redeemed"
+)
+
+// WhatsappRegistrationCode is a one-time code a group redeems with /register
+// to be whitelisted, with the settings it presets.
+type WhatsappRegistrationCode struct {
+	ID                 uint                           `json:"id"`
+	Code               string                         `json:"code"`
+	Servers            []string                       `json:"servers"` // exaroton server IDs, empty for every server
+	AdminsAreOperators bool                           `json:"admins_are_operators"`
+	Status             WhatsappRegistrationCodeStatus `json:"status"`
+	ExpiresAt          time.Time                      `json:"expires_at"`
+	RedeemedAt         *time.Time                     `json:"redeemed_at,omitempty"`
+	CreatedAt          time.Time                      `json:"created_at"`
+}
+
+func NewWhatsappRegistrationCode(e *entity.WhatsappRegistrationCode, now time.Time) *WhatsappRegistrationCode {
+	return &WhatsappRegistrationCode{
+		ID:                 e.ID,
+		Code:               e.Code,
+		Servers:            parseServerIDs(e.Servers),
+		AdminsAreOperators: e.AdminsAreOperators,
+		Status:             RegistrationCodeStatus(e, now),
+		ExpiresAt:          e.ExpiresAt,
+		RedeemedAt:         e.RedeemedAt,
+		CreatedAt:          e.CreatedAt,
+	}
+}
+
+// RegistrationCodeStatus returns the state of a code at now.
+func RegistrationCodeStatus(e *entity.WhatsappRegistrationCode, now time.Time) WhatsappRegistrationCodeStatus {
+	switch {
+	case e.RedeemedAt != nil:
+		return RegistrationCodeRedeemed
+	case !e.ExpiresAt.After(now):
+		return RegistrationCodeExpired
+	}
+
+	return RegistrationCodeActive
+}
+
+// NewRegistrationGroupSettings are the settings a code presets for the group
+// that redeemed it.
+func NewRegistrationGroupSettings(group WhatsappJID, e *entity.WhatsappRegistrationCode) []*entity.WhatsappGroupSettings {
+	return []*entity.WhatsappGroupSettings{
+		{JID: group.User, ServerJID: group.Server, Key: constants.GroupServers, Value: e.Servers},
+		{JID: group.User, ServerJID: group.Server, Key: constants.GroupAdminsAreOperators, Value: strconv.FormatBool(e.AdminsAreOperators)},
+	}
+}
+
+type CreateRegistrationCodeReq struct {
+	ExpiresInMinutes   int      `json:"expires_in_minutes"`
+	Servers            []string `json:"servers"` // the exaroton IDs of the servers the group can use, empty for every server
+	AdminsAreOperators bool     `json:"admins_are_operators"`
+}
+
+func (r *CreateRegistrationCodeReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ExpiresInMinutes, validation.Required, validation.Min(1), validation.Max(int(maxRegistrationCodeLifetime/time.Minute))),
+	)
+}
+
+// ToEntity returns the code to store, it expires ExpiresInMinutes after now.
+func (r *CreateRegistrationCodeReq) ToEntity(code string, now time.Time) *entity.WhatsappRegistrationCode {
+	return &entity.WhatsappRegistrationCode{
+		Code:               code,
+		Servers:            formatServerIDs(r.Servers),
+		AdminsAreOperators: r.AdminsAreOperators,
+		ExpiresAt:          now.Add(time.Duration(r.ExpiresInMinutes) * time.Minute),
+	}
+}
+
+type DeleteRegistrationCodeReq struct {
+	ID uint `json:"id"`
+}
+
+func (r *DeleteRegistrationCodeReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.ID, validation.Required),
+	)
+}
+
+// RedeemRegistrationCodeReq is a /register in a group.
+type RedeemRegistrationCodeReq struct {
+	Code       string
+	Group      WhatsappJID
+	RedeemedBy WhatsappJID
+}
+
+// WhatsappGroupRegistration is an entry of the registrations audit trail.
+type WhatsappGroupRegistration struct {
+	Code       string    `json:"code"`
+	Group      string    `json:"group"` // user@server
+	GroupName  string    `json:"group_name"`
+	RedeemedBy string    `json:"redeemed_by"`
+	At         time.Time `json:"at"`
+}
+
+func NewWhatsappGroupRegistration(e *entity.WhatsappGroupRegistration) *WhatsappGroupRegistration {
+	return &WhatsappGroupRegistration{
+		Code:       e.Code,
+		Group:      e.GroupJID + "@" + e.GroupServerJID,
+		GroupName:  e.GroupName,
+		RedeemedBy: e.RedeemedBy,
+		At:         e.CreatedAt,
+	}
+}
@@ -0,0 +1,174 @@
+package dto
+
+import (
+	"errors"
+	"exaroton-wa-bot/internal/database/entity"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// WhatsappRole is what a user is allowed to do with the bot, each role can
+// do everything the roles below it can.
+type WhatsappRole int
+
+const (
+	RoleGuest    WhatsappRole = iota // read-only commands, e.g. /status
+	RolePlayer                       // starts servers
+	RoleOperator                     // stops and restarts servers
+	RoleAdmin
+)
+
+var roleNames = []string{"guest", "player", "operator", "admin"}
+
+func (r WhatsappRole) String() string {
+	if r < RoleGuest || int(r) >= len(roleNames) {
+		return "unknown"
+	}
+
+	return roleNames[r]
+}
+
+// ParseWhatsappRole parses a role name, e.g. "operator".
+func ParseWhatsappRole(s string) (WhatsappRole, bool) {
+	for i, name := range roleNames {
+		if strings.EqualFold(name, s) {
+			return WhatsappRole(i), true
+		}
+	}
+
+	return RoleGuest, false
+}
+
+// WhatsappRoleNames returns every role name, from the lowest role.
+func WhatsappRoleNames() []string {
+	return append([]string(nil), roleNames...)
+}
+
+// ParseWhatsappUser parses a user from a phone number (e.g. "6281234567890")
+// or a full jid (e.g. "123456@lid").
+func ParseWhatsappUser(s string) (WhatsappJID, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "+")
+	if phoneNumberRegex.MatchString(s) {
+		return WhatsappJID{User: s, Server: types.DefaultUserServer}, nil
+	}
+
+	jid, err := types.ParseJID(s)
+	if err != nil || jid.User == "" || (jid.Server != types.DefaultUserServer && jid.Server != types.HiddenUserServer) {
+		return WhatsappJID{}, errors.New("must be a phone number or a user jid")
+	}
+
+	return WhatsappJID{User: jid.User, Server: jid.Server}, nil
+}
+
+type SettingsWhatsappRolesPageData struct {
+	Roles []string
+}
+
+// WhatsappUserRole is a role assigned to a user, in a group or in every chat.
+type WhatsappUserRole struct {
+	Group string `json:"group"` // "user@server", empty for every chat
+	User  string `json:"user"`  // "user@server"
+	Role  string `json:"role"`
+}
+
+func NewWhatsappUserRole(e *entity.WhatsappUserRole) *WhatsappUserRole {
+	res := &WhatsappUserRole{
+		User: e.JID + "@" + e.ServerJID,
+		Role: e.Role,
+	}
+
+	if e.GroupJID != "" {
+		res.Group = e.GroupJID + "@" + e.GroupServerJID
+	}
+
+	return res
+}
+
+type AssignWhatsappRoleReq struct {
+	Group string `json:"group"` // "user@server", empty for every chat
+	User  string `json:"user"`  // phone number or jid
+	Role  string `json:"role"`
+}
+
+func (r *AssignWhatsappRoleReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Group, validation.By(validateGroupJID)),
+		validation.Field(&r.User, validation.Required, validation.By(validateUserJID)),
+		validation.Field(&r.Role, validation.Required, validation.By(func(any) error {
+			if _, ok := ParseWhatsappRole(r.Role); !ok {
+				return errors.New("must be one of: " + strings.Join(roleNames, ", "))
+			}
+			return nil
+		})),
+	)
+}
+
+// ToEntity turns the validated request into a db row.
+func (r *AssignWhatsappRoleReq) ToEntity() *entity.WhatsappUserRole {
+	group, _ := parseGroupJID(r.Group)
+	user, _ := ParseWhatsappUser(r.User)
+	role, _ := ParseWhatsappRole(r.Role)
+
+	return &entity.WhatsappUserRole{
+		GroupJID:       group.User,
+		GroupServerJID: group.Server,
+		JID:            user.User,
+		ServerJID:      user.Server,
+		Role:           role.String(),
+	}
+}
+
+type UnassignWhatsappRoleReq struct {
+	Group string `json:"group"`
+	User  string `json:"user"`
+}
+
+func (r *UnassignWhatsappRoleReq) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.Group, validation.By(validateGroupJID)),
+		validation.Field(&r.User, validation.Required, validation.By(validateUserJID)),
+	)
+}
+
+// ToEntity turns the validated request into the key of a db row.
+func (r *UnassignWhatsappRoleReq) ToEntity() *entity.WhatsappUserRole {
+	group, _ := parseGroupJID(r.Group)
+	user, _ := ParseWhatsappUser(r.User)
+
+	return &entity.WhatsappUserRole{
+		GroupJID:       group.User,
+		GroupServerJID: group.Server,
+		JID:            user.User,
+		ServerJID:      user.Server,
+	}
+}
+
+// parseGroupJID parses a group jid, empty means every chat.
+func parseGroupJID(s string) (WhatsappJID, error) {
+	if s == "" {
+		return WhatsappJID{}, nil
+	}
+
+	jid, err := types.ParseJID(s)
+	if err != nil || jid.User == "" || jid.Server != types.GroupServer {
+		return WhatsappJID{}, errors.New("must be a group jid")
+	}
+
+	return WhatsappJID{User: jid.User, Server: jid.Server}, nil
+}
+
+func validateGroupJID(v any) error {
+	_, err := parseGroupJID(v.(string))
+	return err
+}
+
+func validateUserJID(v any) error {
+	if v.(string) == "" {
+		return nil // checked by validation.Required
+	}
+
+	_, err := ParseWhatsappUser(v.(string))
+	return err
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWhatsappRole(t *testing.T) {
+	role, ok := ParseWhatsappRole("Operator")
+	assert.True(t, ok)
+	assert.Equal(t, RoleOperator, role)
+	assert.Equal(t, "operator", role.String())
+
+	_, ok = ParseWhatsappRole("owner")
+	assert.False(t, ok)
+
+	assert.True(t, RoleAdmin > RoleOperator && RoleOperator > RolePlayer && RolePlayer > RoleGuest)
+}
+
+func TestParseWhatsappUser(t *testing.T) {
+	user, err := ParseWhatsappUser("+6281234567890")
+	require.NoError(t, err)
+	assert.Equal(t, WhatsappJID{User: "6281234567890", Server: "s.whatsapp.net"}, user)
+
+	user, err = ParseWhatsappUser("123456@lid")
+	require.NoError(t, err)
+	assert.Equal(t, WhatsappJID{User: "123456", Server: "lid"}, user)
+
+	_, err = ParseWhatsappUser("1203630@g.us")
+	assert.Error(t, err)
+
+	_, err = ParseWhatsappUser("alice")
+	assert.Error(t, err)
+}
+
+func TestAssignWhatsappRoleReq(t *testing.T) {
+	req := &AssignWhatsappRoleReq{Group: "1203630@g.us", User: "6281234567890", Role: "Admin"}
+	require.NoError(t, req.Validate())
+
+	e := req.ToEntity()
+	assert.Equal(t, "1203630", e.GroupJID)
+	assert.Equal(t, "g.us", e.GroupServerJID)
+	assert.Equal(t, "6281234567890", e.JID)
+	assert.Equal(t, "s.whatsapp.net", e.ServerJID)
+	assert.Equal(t, "admin", e.Role)
+
+	assert.Equal(t, &WhatsappUserRole{Group: "1203630@g.us", User: "6281234567890@s.whatsapp.net", Role: "admin"}, NewWhatsappUserRole(e))
+
+	assert.Error(t, (&AssignWhatsappRoleReq{User: "6281234567890", Role: "owner"}).Validate())
+	assert.Error(t, (&AssignWhatsappRoleReq{Group: "6281234567890@s.whatsapp.net", User: "6281234567890", Role: "admin"}).Validate())
+	assert.NoError(t, (&AssignWhatsappRoleReq{User: "6281234567890", Role: "guest"}).Validate()) // every chat
+}
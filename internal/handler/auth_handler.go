@@ -1,14 +1,19 @@
 package handler
 
 import (
+	"context"
 	"errors"
+	"exaroton-wa-bot/internal/config"
 	"exaroton-wa-bot/internal/constants/errs"
 	"exaroton-wa-bot/internal/constants/messages"
 	"exaroton-wa-bot/internal/dto"
 	"exaroton-wa-bot/pages"
+	"fmt"
 	"net/http"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
 	"go.mau.fi/whatsmeow"
 )
@@ -85,7 +90,7 @@ func (w *Web) APIWhatsappQRLogin() echo.HandlerFunc {
 		if err != nil {
 			err2 := ws.WriteJSON(dto.WhatsappQRWSRes{
 				Event: dto.WhatsappQREventError,
-				Error: err.Error(),
+				Error: whatsappLoginError(c, err),
 			})
 			if err2 != nil {
 				return err2
@@ -102,72 +107,165 @@ func (w *Web) APIWhatsappQRLogin() echo.HandlerFunc {
 			})
 		}
 
-		for qr := range qrChan {
-			var err error
-			switch qr.Event {
-
-			// keep sending qr codes
-			case whatsmeow.QRChannelEventCode: // "code"
-				err = ws.WriteJSON(dto.WhatsappQRWSRes{
-					Event: qr.Event,
-					Code:  qr.Code,
-				})
-				if err != nil {
-					return err
-				}
-				continue
-
-			// pairing error
-			case whatsmeow.QRChannelEventError:
-				err = ws.WriteJSON(dto.WhatsappQRWSRes{
-					Event: dto.WhatsappQREventError,
-					Error: errs.ErrWAQRError.Error(),
-				})
-
-			// login success
-			case whatsmeow.QRChannelSuccess.Event:
-				err = ws.WriteJSON(dto.WhatsappQRWSRes{
-					Event:   dto.WhatsappQREventSuccess,
-					Message: messages.MsgWALoginSuccess,
-				})
-
-			// pairing timeout
-			case whatsmeow.QRChannelTimeout.Event:
-				err = ws.WriteJSON(dto.WhatsappQRWSRes{
-					Event: dto.WhatsappQREventTimeout,
-					Error: errs.ErrWAQRTimeout.Error(),
-				})
-
-			// unexpected event, pairing already happened
-			case whatsmeow.QRChannelErrUnexpectedEvent.Event:
-				err = ws.WriteJSON(dto.WhatsappQRWSRes{
-					Event: dto.WhatsappQREventIsPairing,
-					Error: errs.ErrWAQRIsPairing.Error(),
-				})
-
-			// client outdated
-			case whatsmeow.QRChannelClientOutdated.Event:
-				err = ws.WriteJSON(dto.WhatsappQRWSRes{
-					Event: dto.WhatsappQREventClientOutdated,
-					Error: errs.ErrWAQRClientOutdated.Error(),
-				})
-
-			// multidevice not enabled
-			case whatsmeow.QRChannelScannedWithoutMultidevice.Event:
-				err = ws.WriteJSON(dto.WhatsappQRWSRes{
-					Event: dto.WhatsappQREventMultideviceNotEnabled,
-					Error: errs.ErrWAQREnableMultidevice.Error(),
-				})
+		return streamWhatsappLogin(ws, qrChan)
+	}
+}
+
+// APIWhatsappNumberLogin links WhatsApp with a pairing code instead of a QR
+// code: the code is sent once, then the outcome of the pairing.
+func (w *Web) APIWhatsappNumberLogin() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ws, err := wsUpgrader.Upgrade(c.Response(), c.Request(), nil)
+		if err != nil {
+			return err
+		}
+		defer ws.Close()
+
+		req := new(dto.WhatsappLoginNumberReq)
+		if err := c.Bind(req); err == nil {
+			err = c.Validate(req)
+		}
+		if err != nil {
+			return ws.WriteJSON(dto.WhatsappQRWSRes{
+				Event: dto.WhatsappQREventError,
+				Error: whatsappLoginError(c, err),
+			})
+		}
+
+		code, qrChan, err := w.svc.AuthService.WhatsappLoginWithNumber(c.Request().Context(), req)
+		if err != nil {
+			err2 := ws.WriteJSON(dto.WhatsappQRWSRes{
+				Event: dto.WhatsappQREventError,
+				Error: whatsappLoginError(c, err),
+			})
+			if err2 != nil {
+				return err2
 			}
 
+			return err
+		}
+
+		// if already logged in, send success event
+		if qrChan == nil {
+			return ws.WriteJSON(dto.WhatsappQRWSRes{
+				Event:   dto.WhatsappQREventSuccess,
+				Message: messages.MsgWALoginSuccess,
+			})
+		}
+
+		err = ws.WriteJSON(dto.WhatsappQRWSRes{
+			Event: dto.WhatsappQREventCode,
+			Code:  code,
+		})
+		if err != nil {
+			return err
+		}
+
+		return streamWhatsappLogin(ws, qrChan)
+	}
+}
+
+// whatsappLoginErrors are the login errors shown as is on the login page.
+var whatsappLoginErrors = []error{
+	errs.ErrWAAlreadyLoggedIn,
+	errs.ErrWAQRIsPairing,
+	errs.ErrWAQRError,
+	errs.ErrWADeviceNotFound,
+}
+
+// whatsappLoginError returns the message of a login error for the websocket.
+// The errors the user can act on are shown, the others are only referred to by
+// a request ID, which is added to the request's log line.
+func whatsappLoginError(c echo.Context, err error) string {
+	var valErr validation.Errors
+	if errors.As(err, &valErr) {
+		return valErr.Error()
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return errs.ErrWAQRTimeout.Error()
+	}
+
+	for _, known := range whatsappLoginErrors {
+		if errors.Is(err, known) {
+			return known.Error()
+		}
+	}
+
+	requestID := uuid.NewString()[:8]
+	c.SetRequest(c.Request().WithContext(config.WithRequestID(c.Request().Context(), requestID)))
+
+	return fmt.Sprintf(messages.MsgWALoginFailed, requestID)
+}
+
+// streamWhatsappLogin sends the login events to the websocket until the
+// pairing succeeds or fails.
+func streamWhatsappLogin(ws *websocket.Conn, qrChan <-chan whatsmeow.QRChannelItem) error {
+	for qr := range qrChan {
+		var err error
+		switch qr.Event {
+
+		// keep sending qr codes
+		case whatsmeow.QRChannelEventCode: // "code"
+			err = ws.WriteJSON(dto.WhatsappQRWSRes{
+				Event: qr.Event,
+				Code:  qr.Code,
+			})
 			if err != nil {
 				return err
 			}
+			continue
+
+		// pairing error
+		case whatsmeow.QRChannelEventError:
+			err = ws.WriteJSON(dto.WhatsappQRWSRes{
+				Event: dto.WhatsappQREventError,
+				Error: errs.ErrWAQRError.Error(),
+			})
+
+		// login success
+		case whatsmeow.QRChannelSuccess.Event:
+			err = ws.WriteJSON(dto.WhatsappQRWSRes{
+				Event:   dto.WhatsappQREventSuccess,
+				Message: messages.MsgWALoginSuccess,
+			})
 
-			return nil // quit (success/errors)
+		// pairing timeout
+		case whatsmeow.QRChannelTimeout.Event:
+			err = ws.WriteJSON(dto.WhatsappQRWSRes{
+				Event: dto.WhatsappQREventTimeout,
+				Error: errs.ErrWAQRTimeout.Error(),
+			})
 
-		} // end of qrChan loop
+		// unexpected event, pairing already happened
+		case whatsmeow.QRChannelErrUnexpectedEvent.Event:
+			err = ws.WriteJSON(dto.WhatsappQRWSRes{
+				Event: dto.WhatsappQREventIsPairing,
+				Error: errs.ErrWAQRIsPairing.Error(),
+			})
 
-		return nil
-	}
+		// client outdated
+		case whatsmeow.QRChannelClientOutdated.Event:
+			err = ws.WriteJSON(dto.WhatsappQRWSRes{
+				Event: dto.WhatsappQREventClientOutdated,
+				Error: errs.ErrWAQRClientOutdated.Error(),
+			})
+
+		// multidevice not enabled
+		case whatsmeow.QRChannelScannedWithoutMultidevice.Event:
+			err = ws.WriteJSON(dto.WhatsappQRWSRes{
+				Event: dto.WhatsappQREventMultideviceNotEnabled,
+				Error: errs.ErrWAQREnableMultidevice.Error(),
+			})
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return nil // quit (success/errors)
+
+	} // end of qrChan loop
+
+	return nil
 }
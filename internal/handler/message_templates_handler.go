@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/pages"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (w *Web) SettingsTemplatesPage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.Render(http.StatusOK, pages.SettingsTemplates, nil)
+	}
+}
+
+func (w *Web) APIGetMessageTemplates() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res, err := w.svc.MessageTemplateService.GetAll(c.Request().Context())
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func (w *Web) APIUpdateMessageTemplate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.UpdateMessageTemplateReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		if err = w.svc.MessageTemplateService.Update(c.Request().Context(), req); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: messages.TemplateSaved,
+		})
+	}
+}
+
+func (w *Web) APIResetMessageTemplate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.ResetMessageTemplateReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		if err = w.svc.MessageTemplateService.Reset(c.Request().Context(), req); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: messages.TemplateReset,
+		})
+	}
+}
@@ -1,138 +1,210 @@
 package wahandler
 
 import (
+	"context"
 	"exaroton-wa-bot/internal/config/warouter"
-	"exaroton-wa-bot/internal/constants/errs"
 	"exaroton-wa-bot/internal/constants/messages"
 	"exaroton-wa-bot/internal/dto"
-	"exaroton-wa-bot/internal/helper"
+	"exaroton-wa-bot/internal/i18n"
+	"exaroton-wa-bot/internal/render"
+	"exaroton-wa-bot/internal/service"
 	"exaroton-wa-bot/internal/service/command"
+	"log/slog"
 )
 
 func (h *WaHandler) StartServer() warouter.HandlerFunc {
 	return func(c *warouter.Context) error {
-		startCmd, ok := h.cmdRegis.Get(command.StartServerCmdName)
-		if !ok {
-			return errs.ErrCommandNotFound
-		}
-
-		_, err := c.SendMessage(c, c.Chat, &dto.WhatsappMessage{
-			Conversation: helper.Ptr(messages.ServerIsStarting),
-		})
+		settings, err := h.waSvc.GetGroupSettings(c, c.Chat)
 		if err != nil {
 			return err
 		}
 
-		// iz blocking, will poll then return last server status.
-		res := startCmd.Execute(c, c.Args)
-		if res.Error != nil {
-			return res.Error
+		if settings.IsStartVoteEnabled() {
+			return h.voteStartServer(c, settings)
 		}
 
-		_, err = c.SendMessage(c, c.Chat, &dto.WhatsappMessage{
-			Conversation: &res.Text,
-		})
-
-		return err
+		return h.startServer(c, c.Args)
 	}
 }
 
+// startServer starts the server right away, used by /start and by a passed start vote.
+// The start runs as a background job, its progress is shown by editing a single message.
+func (h *WaHandler) startServer(c *warouter.Context, args []string) error {
+	return h.runCommand(c, command.StartServerCmdName, args)
+}
+
 func (h *WaHandler) StopServer() warouter.HandlerFunc {
 	return func(c *warouter.Context) error {
-		stopCmd, ok := h.cmdRegis.Get(command.StopServerCmdName)
-		if !ok {
-			return errs.ErrCommandNotFound
+		args := c.Args
+		stop := func(c *warouter.Context) error {
+			return h.runCommand(c, command.StopServerCmdName, args)
 		}
 
-		res := stopCmd.Execute(c, c.Args)
-		if res.Error != nil {
-			return res.Error
+		server, err := h.serverFromArgs(c, command.StopServerCmdName, args)
+		if err != nil {
+			return err
 		}
 
-		_, err := c.SendMessage(c, c.Chat, &dto.WhatsappMessage{
-			Conversation: &res.Text,
-		})
+		if server.Players.Count == 0 {
+			return stop(c)
+		}
 
-		return err
+		return c.Confirm(c.N(messages.ConfirmStopServer, server.Players.Count, server.Name), stop)
 	}
 }
 
-func (h *WaHandler) HelpCommand() warouter.HandlerFunc {
+func (h *WaHandler) RestartServer() warouter.HandlerFunc {
 	return func(c *warouter.Context) error {
-		helpCmd, ok := h.cmdRegis.Get(command.HelpCmdName)
-		if !ok {
-			return errs.ErrCommandNotFound
+		args := c.Args
+		restart := func(c *warouter.Context) error {
+			return h.runCommand(c, command.RestartServerCmdName, args)
 		}
 
-		res := helpCmd.Execute(c, c.Args)
-		if res.Error != nil {
-			return res.Error
+		server, err := h.serverFromArgs(c, command.RestartServerCmdName, args)
+		if err != nil {
+			return err
 		}
 
-		_, err := c.SendMessage(c, c.Chat, &dto.WhatsappMessage{
-			Conversation: &res.Text,
-		})
+		return c.Confirm(c.T(messages.ConfirmRestartServer, server.Name), restart)
+	}
+}
 
-		return err
+func (h *WaHandler) ListJobs() warouter.HandlerFunc {
+	return func(c *warouter.Context) error {
+		return h.runCommand(c, command.JobsCmdName, c.Args)
 	}
 }
 
-func (h *WaHandler) ListServers() warouter.HandlerFunc {
+func (h *WaHandler) WhoAmI() warouter.HandlerFunc {
 	return func(c *warouter.Context) error {
-		listCmd, ok := h.cmdRegis.Get(command.ListServerCmdName)
-		if !ok {
-			return errs.ErrCommandNotFound
-		}
+		return h.runCommand(c, command.WhoAmICmdName, c.Args)
+	}
+}
 
-		res := listCmd.Execute(c, c.Args)
-		if res.Error != nil {
-			return res.Error
-		}
+func (h *WaHandler) Language() warouter.HandlerFunc {
+	return func(c *warouter.Context) error {
+		return h.runCommand(c, command.LangCmdName, c.Args)
+	}
+}
 
-		_, err := c.SendMessage(c, c.Chat, &dto.WhatsappMessage{
-			Conversation: &res.Text,
-		})
+func (h *WaHandler) Register() warouter.HandlerFunc {
+	return func(c *warouter.Context) error {
+		return h.runCommand(c, command.RegisterCmdName, c.Args)
+	}
+}
 
-		return err
+func (h *WaHandler) CancelJob() warouter.HandlerFunc {
+	return func(c *warouter.Context) error {
+		return h.runCommand(c, command.CancelJobCmdName, c.Args)
 	}
 }
 
+func (h *WaHandler) HelpCommand() warouter.HandlerFunc {
+	return func(c *warouter.Context) error {
+		return h.runCommand(c, command.HelpCmdName, c.Args)
+	}
+}
+
+func (h *WaHandler) ListServers() warouter.HandlerFunc {
+	return func(c *warouter.Context) error {
+		return h.runCommand(c, command.ListServerCmdName, c.Args)
+	}
+}
+
+// ServerInfo replies the server info, a ▶️ or ⏹️ reaction on the reply
+// starts or stops the server.
 func (h *WaHandler) ServerInfo() warouter.HandlerFunc {
 	return func(c *warouter.Context) error {
-		infoCmd, ok := h.cmdRegis.Get(command.InfoCmdName)
-		if !ok {
-			return errs.ErrCommandNotFound
+		args, err := h.cmdRegis.Parse(command.InfoCmdName, c.Args)
+		if err != nil {
+			return err
 		}
 
-		res := infoCmd.Execute(c, c.Args)
-		if res.Error != nil {
-			return res.Error
+		sent, err := h.sendCommand(c, command.InfoCmdName, c.Args)
+		if err != nil || sent == nil {
+			return err
 		}
 
-		_, err := c.SendMessage(c, c.Chat, &dto.WhatsappMessage{
-			Conversation: &res.Text,
-		})
+		h.statusMessages.add(c.Chat, sent.ID, uint(args.Int(command.ServerIDArg.Name)))
 
-		return err
+		return nil
 	}
 }
 
 func (h *WaHandler) ListPlayers() warouter.HandlerFunc {
 	return func(c *warouter.Context) error {
-		listPlayersCmd, ok := h.cmdRegis.Get(command.ListPlayersCmdName)
-		if !ok {
-			return errs.ErrCommandNotFound
-		}
+		return h.runCommand(c, command.ListPlayersCmdName, c.Args)
+	}
+}
 
-		res := listPlayersCmd.Execute(c, c.Args)
-		if res.Error != nil {
-			return res.Error
-		}
+func (h *WaHandler) ServersStatus() warouter.HandlerFunc {
+	return func(c *warouter.Context) error {
+		return h.runCommand(c, command.StatusCmdName, c.Args)
+	}
+}
 
-		_, err := c.SendMessage(c, c.Chat, &dto.WhatsappMessage{
-			Conversation: &res.Text,
+// runCommand parses the args, executes a command and replies its result.
+// If the command started a background job, the job replies its progress instead.
+func (h *WaHandler) runCommand(c *warouter.Context, name string, args []string) error {
+	_, err := h.sendCommand(c, name, args)
+	return err
+}
+
+// sendCommand is runCommand returning the sent reply, nil if the command started a job.
+func (h *WaHandler) sendCommand(c *warouter.Context, name string, args []string) (*dto.WhatsappSendResponse, error) {
+	// the reply and the job's reports are in the chat's language
+	ctx := service.WithJobOrigin(i18n.WithLang(c, c.Lang()), &service.JobOrigin{
+		Chat:   c.Chat,
+		Report: h.progressReporter(c.Chat),
+		Done:   h.jobReactor(c),
+	})
+
+	res := h.cmdRegis.Execute(ctx, name, args)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	// the job reacts to the command once it's done, editing the command cancels it
+	if res.Job != nil {
+		job := res.Job
+		c.Defer(func() bool {
+			return h.jobSvc.Cancel(context.WithoutCancel(c), c.Chat, job.ID) == nil
 		})
+		return nil, nil
+	}
 
-		return err
+	if res.Card != nil && h.isCardMode(c) {
+		card, err := res.Card()
+		if err == nil {
+			return c.ReplyImage(card, render.MimeType, "")
+		}
+		slog.WarnContext(c, "failed to render card, replying with text", "command", name, "error", err.Error())
 	}
+
+	return c.Reply(res.Text)
+}
+
+// isCardMode reports whether the chat is a group replying with image cards.
+func (h *WaHandler) isCardMode(c *warouter.Context) bool {
+	if c.IsDirect() {
+		return false
+	}
+
+	settings, err := h.waSvc.GetGroupSettings(c, c.Chat)
+	if err != nil {
+		slog.WarnContext(c, "failed to get group settings", "error", err.Error())
+		return false
+	}
+
+	return settings.CardMode
+}
+
+// serverFromArgs parses the args of a command taking a server ID and gets that server.
+func (h *WaHandler) serverFromArgs(c *warouter.Context, name string, raw []string) (*dto.ExarotonServerInfo, error) {
+	args, err := h.cmdRegis.Parse(name, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.serverSettingsSvc.GetExarotonServerInfo(c, uint(args.Int(command.ServerIDArg.Name)))
 }
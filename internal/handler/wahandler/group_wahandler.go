@@ -0,0 +1,73 @@
+package wahandler
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/dto"
+	"log/slog"
+	"slices"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// groupEvents keeps the whitelisted groups' info current: their name and
+// topic, and whether the bot is still in them. The cached group admins are
+// dropped on any change of the group.
+func (h *WaHandler) groupEvents(evt any) {
+	ctx := h.deviceContext(context.Background())
+
+	var err error
+	switch e := evt.(type) {
+	case *events.Connected:
+		// the bot might have left groups while offline, the group list is
+		// fetched from the server so it's not done in the event loop
+		go func() {
+			if err := h.waSvc.ReconcileGroups(ctx); err != nil {
+				slog.WarnContext(ctx, "failed to reconcile whitelisted groups", "error", err.Error())
+			}
+		}()
+
+	case *events.JoinedGroup:
+		err = h.waSvc.SyncGroup(ctx, &e.GroupInfo)
+
+	case *events.GroupInfo:
+		group := dto.NewWhatsappJID(e.JID)
+
+		// e.g. admins promoted or demoted
+		h.roleSvc.ForgetGroupAdmins(ctx, group)
+
+		if h.leftGroup(ctx, e) {
+			err = h.waSvc.MarkGroupLeft(ctx, group)
+			break
+		}
+
+		if e.Name == nil && e.Topic == nil {
+			break
+		}
+
+		req := &dto.WhatsappGroupInfoUpdate{Group: group}
+		if e.Name != nil {
+			req.Name = &e.Name.Name
+		}
+		if e.Topic != nil {
+			req.Topic = &e.Topic.Topic
+		}
+		err = h.waSvc.UpdateGroupInfo(ctx, req)
+	}
+
+	if err != nil {
+		slog.WarnContext(ctx, "failed to update whitelisted group", "error", err.Error())
+	}
+}
+
+// leftGroup reports whether the bot left or was removed from the group, or
+// the group was deleted.
+func (h *WaHandler) leftGroup(ctx context.Context, e *events.GroupInfo) bool {
+	if e.Delete != nil {
+		return true
+	}
+
+	return slices.ContainsFunc(e.Leave, func(jid types.JID) bool {
+		return h.wa.IsSelf(ctx, dto.NewWhatsappJID(jid))
+	})
+}
@@ -0,0 +1,80 @@
+package wahandler
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/config/warouter"
+	"exaroton-wa-bot/internal/constants"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/service"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const jobShutdownTimeout = 5 * time.Second
+
+// progressReporter shows a job's progress as a single message in a chat:
+// the first report sends the message, the next ones edit it.
+func (h *WaHandler) progressReporter(chat dto.WhatsappJID) service.JobReporter {
+	var (
+		mu        sync.Mutex
+		messageID string
+	)
+
+	return func(text string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		ctx := context.Background()
+		message := &dto.WhatsappMessage{Conversation: &text}
+
+		if messageID == "" {
+			res, err := h.wa.SendMessage(ctx, chat, message)
+			if err != nil {
+				slog.WarnContext(ctx, "failed to send job progress", "error", err.Error())
+				return
+			}
+
+			messageID = res.ID
+			return
+		}
+
+		if _, err := h.wa.EditMessage(ctx, chat, messageID, message); err != nil {
+			slog.WarnContext(ctx, "failed to edit job progress", "error", err.Error())
+		}
+	}
+}
+
+// jobReactor reacts to the message of the command that started a job once the
+// job is done, like wamiddleware.StatusReactions does for the other commands.
+// The job outlives the command's context, so the message is copied.
+func (h *WaHandler) jobReactor(c *warouter.Context) func(err error) {
+	ctx := context.WithoutCancel(c)
+	chat, sender, messageID := c.Chat, c.Sender, c.ReactionTarget()
+
+	return func(err error) {
+		if messageID == "" {
+			return
+		}
+
+		emoji := constants.ReactionDone
+		if err != nil {
+			emoji = constants.ReactionFailed
+		}
+
+		if err := h.wa.SendReaction(ctx, chat, sender, messageID, emoji); err != nil {
+			slog.WarnContext(ctx, "failed to react to a job's command", "error", err.Error())
+		}
+	}
+}
+
+// stopJobs cancels the running jobs of the device and waits a bit for them to
+// report it.
+func (h *WaHandler) stopJobs() {
+	ctx, cancel := context.WithTimeout(h.deviceContext(context.Background()), jobShutdownTimeout)
+	defer cancel()
+
+	if err := h.jobSvc.Shutdown(ctx); err != nil {
+		slog.Warn("some jobs didn't stop in time", "error", err.Error())
+	}
+}
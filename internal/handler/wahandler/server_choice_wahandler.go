@@ -0,0 +1,117 @@
+package wahandler
+
+import (
+	"exaroton-wa-bot/internal/config/warouter"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/service/command"
+	"fmt"
+	"strconv"
+)
+
+// serverChoiceQuestions are the poll questions per command, the others use
+// messages.PollChooseServerOther.
+var serverChoiceQuestions = map[string]string{
+	command.StartServerCmdName:   messages.PollChooseServerStart,
+	command.StopServerCmdName:    messages.PollChooseServerStop,
+	command.RestartServerCmdName: messages.PollChooseServerRestart,
+	command.InfoCmdName:          messages.PollChooseServerInfo,
+	command.ListPlayersCmdName:   messages.PollChooseServerPlayers,
+}
+
+// needsServerID reports whether a command's first argument is a required server ID.
+func needsServerID(cmd command.Command) bool {
+	args := cmd.Args().Args
+	return len(args) > 0 && args[0].Name == command.ServerIDArg.Name && !args[0].Optional
+}
+
+// serverChoice returns a middleware asking which server to run a command on
+// with a poll when the server ID is missing, the sender's vote runs the
+// command. With no servers or too many for a poll, the command reports the
+// missing argument.
+func (h *WaHandler) serverChoice(name string) warouter.MiddlewareFunc {
+	question, ok := serverChoiceQuestions[name]
+
+	return func(next warouter.HandlerFunc) warouter.HandlerFunc {
+		return func(c *warouter.Context) error {
+			// reactions choose by the reacted message
+			if len(c.Args) > 0 || c.Reaction != nil {
+				return next(c)
+			}
+
+			servers, err := h.serverSettingsSvc.ListExarotonServer(c)
+			if err != nil {
+				return err
+			}
+
+			settings, err := h.waSvc.GetGroupSettings(c, c.Chat)
+			if err != nil {
+				return err
+			}
+
+			// only the servers the chat can use are offered
+			options := make([]string, 0, len(servers))
+			serverIdx := make(map[string]string, len(servers)) // option -> server idx
+			for i, srv := range servers {
+				if !settings.AllowsServer(srv.ID) {
+					continue
+				}
+
+				option := fmt.Sprintf("#%d %s", i, srv.Name)
+				options = append(options, option)
+				serverIdx[option] = strconv.Itoa(i)
+			}
+
+			if len(options) == 0 || len(options) > warouter.MaxPollOptions {
+				return next(c)
+			}
+
+			text := c.T(question)
+			if !ok {
+				text = c.T(messages.PollChooseServerOther, name)
+			}
+
+			return c.Poll(text, options, warouter.DefaultPollTimeout, func(vote *warouter.Context, choice string) error {
+				return vote.RunCommand(name, serverIdx[choice])
+			})
+		}
+	}
+}
+
+// serverScope rejects a command on a server the chat can't use, see
+// dto.WhatsappGroupSettings.Servers.
+func (h *WaHandler) serverScope(name string) warouter.MiddlewareFunc {
+	return func(next warouter.HandlerFunc) warouter.HandlerFunc {
+		return func(c *warouter.Context) error {
+			// bad args are reported by the handler, start vote reactions have
+			// no args and vote on a server that was checked by /start
+			args, err := h.cmdRegis.Parse(name, c.Args)
+			if err != nil {
+				return next(c)
+			}
+
+			settings, err := h.waSvc.GetGroupSettings(c, c.Chat)
+			if err != nil {
+				return err
+			}
+
+			if len(settings.Servers) == 0 {
+				return next(c)
+			}
+
+			// the scope holds exaroton IDs, the arg is the server's index
+			servers, err := h.serverSettingsSvc.ListExarotonServer(c)
+			if err != nil {
+				return err
+			}
+
+			// an unknown index is reported by the handler
+			idx := args.Int(command.ServerIDArg.Name)
+			if idx >= 0 && idx < len(servers) && !settings.AllowsServer(servers[idx].ID) {
+				return errs.ErrServerNotInScope
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package wahandler
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/config/warouter"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/helper"
+	"exaroton-wa-bot/internal/i18n"
+	"exaroton-wa-bot/internal/service/command"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+const (
+	startVoteEmoji         = "👍"
+	startVoteWatchInterval = 30 * time.Second
+)
+
+// voteStartServer opens or joins a vote to start a server, the server is
+// started once the vote reaches the group's threshold.
+func (h *WaHandler) voteStartServer(c *warouter.Context, settings *dto.WhatsappGroupSettings) error {
+	args, err := h.cmdRegis.Parse(command.StartServerCmdName, c.Args)
+	if err != nil {
+		return err
+	}
+	serverIdx := args.Int(command.ServerIDArg.Name)
+
+	// the vote keeps the server's id, its index changes with the server list
+	server, err := h.serverSettingsSvc.GetExarotonServerInfo(c, uint(serverIdx))
+	if err != nil {
+		return err
+	}
+
+	res, err := h.startVoteSvc.Vote(c, &dto.StartVoteReq{
+		Chat:       c.Chat,
+		ServerID:   server.ID,
+		Voter:      c.Sender.String(),
+		ServerName: server.Name,
+		Threshold:  settings.StartVoteThreshold,
+		Deadline:   settings.StartVoteDeadline,
+	})
+	if err != nil {
+		return err
+	}
+
+	vote := res.Vote
+	if res.Opened && !res.Passed {
+		sent, err := c.Reply(c.T(messages.StartVoteOpened,
+			vote.ServerName, vote.Votes, vote.Threshold, serverIdx, settings.StartVoteDeadline.String()))
+		if err != nil {
+			return err
+		}
+
+		return h.startVoteSvc.SetVoteMessageID(c, vote.ID, sent.ID)
+	}
+
+	return h.replyStartVote(c, res)
+}
+
+// isStartVoteMessage filters the 👍 reactions down to the ones on an open start
+// vote, before the middlewares use up a rate limit token or call exaroton.
+func (h *WaHandler) isStartVoteMessage(c *warouter.Context) (bool, error) {
+	return h.startVoteSvc.IsOpenVoteMessage(c, c.Chat, c.Reaction.MessageID)
+}
+
+// StartVoteReaction handles a 👍 reaction on an open start vote message.
+func (h *WaHandler) StartVoteReaction() warouter.HandlerFunc {
+	return func(c *warouter.Context) error {
+		res, err := h.startVoteSvc.VoteByMessageID(c, c.Chat, c.Reaction.MessageID, c.Sender.String())
+		if err != nil || res == nil {
+			return err
+		}
+
+		// no reply for a repeated reaction
+		if !res.Joined {
+			return nil
+		}
+
+		return h.replyStartVote(c, res)
+	}
+}
+
+// replyStartVote replies the vote's progress and starts the server if the vote passed.
+func (h *WaHandler) replyStartVote(c *warouter.Context, res *dto.StartVoteRes) error {
+	vote := res.Vote
+
+	id := messages.StartVoteJoined
+	switch {
+	case res.Passed:
+		id = messages.StartVotePassed
+	case !res.Joined:
+		id = messages.StartVoteAlreadyVoted
+	}
+
+	if _, err := c.Reply(c.T(id, vote.ServerName, vote.Votes, vote.Threshold)); err != nil {
+		return err
+	}
+
+	if !res.Passed {
+		return nil
+	}
+
+	serverIdx, err := h.serverIdxByID(c, vote.ServerID)
+	if err != nil {
+		return err
+	}
+
+	return h.startServer(c, []string{strconv.Itoa(serverIdx)})
+}
+
+// serverIdxByID returns the current index of a server in the exaroton list.
+func (h *WaHandler) serverIdxByID(c *warouter.Context, serverID string) (int, error) {
+	servers, err := h.serverSettingsSvc.ListExarotonServer(c)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, server := range servers {
+		if server.ID == serverID {
+			return i, nil
+		}
+	}
+
+	return 0, errs.ErrServerNotFound
+}
+
+// watchStartVotes closes expired start votes and announces them until ctx is done.
+// Votes are stored in the db, so votes that expired while the bot was down
+// are announced on the next tick.
+func (h *WaHandler) watchStartVotes(ctx context.Context) {
+	ticker := time.NewTicker(startVoteWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !h.wa.IsSyncComplete(ctx) {
+			continue
+		}
+
+		votes, err := h.startVoteSvc.CloseExpiredVotes(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to close expired start votes", "error", err.Error())
+			continue
+		}
+
+		for _, vote := range votes {
+			_, err := h.wa.SendMessage(ctx, vote.Chat, &dto.WhatsappMessage{
+				Conversation: helper.Ptr(i18n.T(h.chatLang(ctx, vote.Chat), messages.StartVoteExpired, vote.ServerName, vote.Votes, vote.Threshold)),
+			})
+			if err != nil {
+				slog.WarnContext(ctx, "failed to announce expired start vote", "error", err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package wahandler
+
+import (
+	"exaroton-wa-bot/internal/config/warouter"
+	"exaroton-wa-bot/internal/dto"
+	"strconv"
+	"sync"
+)
+
+const (
+	startServerEmoji = "▶️"
+	stopServerEmoji  = "⏹️"
+
+	// status messages remembered for reactions, the oldest are forgotten first
+	maxStatusMessages = 256
+)
+
+// statusMessages maps the bot's server status messages (e.g. /info replies)
+// to their server, so a reaction on them can start or stop that server. They
+// are kept in memory only, reactions on messages sent before a restart are ignored.
+type statusMessages struct {
+	mu      sync.Mutex
+	servers map[string]uint // key: chat + message id, value: server idx
+	order   []string        // keys, oldest first
+}
+
+func newStatusMessages() *statusMessages {
+	return &statusMessages{servers: make(map[string]uint)}
+}
+
+func statusMessageKey(chat dto.WhatsappJID, messageID string) string {
+	return chat.String() + "|" + messageID
+}
+
+func (s *statusMessages) add(chat dto.WhatsappJID, messageID string, serverIdx uint) {
+	key := statusMessageKey(chat, messageID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.servers[key]; !ok {
+		s.order = append(s.order, key)
+	}
+	s.servers[key] = serverIdx
+
+	if len(s.order) > maxStatusMessages {
+		delete(s.servers, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// get returns the server of a status message, false if it isn't one.
+func (s *statusMessages) get(chat dto.WhatsappJID, messageID string) (uint, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	serverIdx, ok := s.servers[statusMessageKey(chat, messageID)]
+	return serverIdx, ok
+}
+
+// reactionMiddlewares returns the middlewares of a reaction running a command
+// on the server of a status message: the reaction becomes the command's args,
+// then it goes thru the same checks as the text command.
+func (h *WaHandler) reactionMiddlewares(name string) []warouter.MiddlewareFunc {
+	return append([]warouter.MiddlewareFunc{h.statusMessageArgs()}, h.commandMiddlewares(name)...)
+}
+
+// statusMessageArgs sets the args to the server of the reacted status message,
+// reactions on other messages are ignored.
+func (h *WaHandler) statusMessageArgs() warouter.MiddlewareFunc {
+	return func(next warouter.HandlerFunc) warouter.HandlerFunc {
+		return func(c *warouter.Context) error {
+			serverIdx, ok := h.statusMessages.get(c.Chat, c.Reaction.MessageID)
+			if !ok {
+				return nil
+			}
+
+			c.Args = []string{strconv.FormatUint(uint64(serverIdx), 10)}
+
+			return next(c)
+		}
+	}
+}
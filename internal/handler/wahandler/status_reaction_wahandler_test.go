@@ -0,0 +1,35 @@
+package wahandler
+
+import (
+	"exaroton-wa-bot/internal/dto"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusMessages(t *testing.T) {
+	s := newStatusMessages()
+	chat := dto.WhatsappJID{User: "1203630", Server: "g.us"}
+	other := dto.WhatsappJID{User: "1203631", Server: "g.us"}
+
+	s.add(chat, "abc", 2)
+
+	serverIdx, ok := s.get(chat, "abc")
+	assert.True(t, ok)
+	assert.Equal(t, uint(2), serverIdx)
+
+	_, ok = s.get(other, "abc")
+	assert.False(t, ok, "message ids are per chat")
+
+	// the oldest are forgotten first
+	for i := range maxStatusMessages {
+		s.add(chat, fmt.Sprint("msg", i), 1)
+	}
+
+	_, ok = s.get(chat, "abc")
+	assert.False(t, ok)
+	_, ok = s.get(chat, "msg0")
+	assert.True(t, ok)
+	assert.Len(t, s.servers, maxStatusMessages)
+}
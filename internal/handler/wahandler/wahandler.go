@@ -1,38 +1,74 @@
 package wahandler
 
 import (
+	"context"
 	"exaroton-wa-bot/internal/config"
 	"exaroton-wa-bot/internal/config/warouter"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
 	"exaroton-wa-bot/internal/middleware/wamiddleware"
 	"exaroton-wa-bot/internal/service"
 	"exaroton-wa-bot/internal/service/command"
+	"log/slog"
 )
 
+// WaHandler handles the messages of a device (bot account), see WaHandlers.
 type WaHandler struct {
+	deviceID          uint
 	router            *warouter.Router
+	wa                warouter.WhatsappService
 	cfg               *config.Cfg
 	cmdRegis          *command.Registry
 	mdw               *wamiddleware.Middleware
 	authSvc           service.IAuthService
 	serverSettingsSvc service.IServerSettingsService
+	waSvc             service.IWhatsappService
+	startVoteSvc      service.IStartVoteService
+	jobSvc            service.IJobService
+	roleSvc           service.IRoleService
+
+	statusMessages *statusMessages
+
+	stopWatchers context.CancelFunc // nil if not running
 
 	// event handler codes
-	HandlerCodeCommandWA uint32
+	HandlerCodeCommandWA   uint32
+	HandlerCodeGroupEvents uint32
 }
 
-func NewWAHandler(cfg *config.Cfg, wa warouter.WhatsappService, cmdRegis *command.Registry, authSvc service.IAuthService, serverSettingsSvc service.IServerSettingsService) *WaHandler {
+func NewWAHandler(
+	cfg *config.Cfg,
+	deviceID uint,
+	wa warouter.WhatsappService,
+	cmdRegis *command.Registry,
+	authSvc service.IAuthService,
+	serverSettingsSvc service.IServerSettingsService,
+	waSvc service.IWhatsappService,
+	startVoteSvc service.IStartVoteService,
+	jobSvc service.IJobService,
+	roleSvc service.IRoleService,
+) *WaHandler {
 	router := warouter.NewRouter(cfg, wa)
 	router.ErrorHandlerFunc = errHandler
 
 	h := &WaHandler{
+		deviceID:          deviceID,
 		router:            router,
+		wa:                wa,
 		cfg:               cfg,
 		cmdRegis:          cmdRegis,
-		mdw:               wamiddleware.NewMiddleware(cfg, authSvc, serverSettingsSvc),
+		mdw:               wamiddleware.NewMiddleware(cfg, authSvc, serverSettingsSvc, roleSvc),
 		authSvc:           authSvc,
 		serverSettingsSvc: serverSettingsSvc,
+		waSvc:             waSvc,
+		startVoteSvc:      startVoteSvc,
+		jobSvc:            jobSvc,
+		roleSvc:           roleSvc,
+		statusMessages:    newStatusMessages(),
 	}
 
+	router.LangFunc = h.chatLang
+	router.ContextFunc = h.deviceContext
 	h.LoadCommandRoutes()
 
 	return h
@@ -40,8 +76,46 @@ func NewWAHandler(cfg *config.Cfg, wa warouter.WhatsappService, cmdRegis *comman
 
 func (h *WaHandler) Run() {
 	h.router.Run()
+	if h.HandlerCodeGroupEvents == 0 {
+		h.HandlerCodeGroupEvents = h.wa.RegisterEventHandler(h.groupEvents)
+	}
+
+	ctx, cancel := context.WithCancel(h.deviceContext(context.Background()))
+	h.stopWatchers = cancel
+	go h.watchStartVotes(ctx)
 }
 
 func (h *WaHandler) Stop() {
+	if h.stopWatchers != nil {
+		h.stopWatchers()
+	}
+	if h.HandlerCodeGroupEvents != 0 {
+		h.wa.UnregisterEventHandler(h.HandlerCodeGroupEvents)
+		h.HandlerCodeGroupEvents = 0
+	}
 	h.router.Stop()
+	h.stopJobs()
+}
+
+// deviceContext scopes ctx to the handler's device, the services then use its
+// whitelists and settings.
+func (h *WaHandler) deviceContext(ctx context.Context) context.Context {
+	return dto.WithWhatsappDevice(ctx, h.deviceID)
+}
+
+// chatLang returns the language of a chat: the one set with /lang, else the
+// configured default.
+func (h *WaHandler) chatLang(ctx context.Context, chat dto.WhatsappJID) i18n.Lang {
+	settings, err := h.waSvc.GetGroupSettings(ctx, chat)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to get group settings", "error", err.Error())
+	} else if settings.Language != "" {
+		return settings.Language
+	}
+
+	if lang, ok := i18n.ParseLang(h.cfg.String(config.KeyBotDefaultLanguage)); ok {
+		return lang
+	}
+
+	return i18n.Default
 }
@@ -1,30 +1,53 @@
 package wahandler
 
 import (
+	"context"
 	"errors"
+	"exaroton-wa-bot/internal/config"
 	"exaroton-wa-bot/internal/config/warouter"
 	"exaroton-wa-bot/internal/constants/errs"
-	"exaroton-wa-bot/internal/dto"
-	"exaroton-wa-bot/internal/helper"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/i18n"
+	"exaroton-wa-bot/internal/service/command"
 	"log/slog"
 )
 
 func errHandler(c *warouter.Context, err error) {
-	var resp dto.WhatsappMessage
+	var (
+		reply  string
+		argErr *command.ArgError
+		msgErr *i18n.Error
+	)
 
 	switch {
-	case errors.Is(err, errs.ErrServerNotFound),
-		errors.Is(err, errs.ErrCommandNotFound),
-		errors.Is(err, errs.ErrServerIsAlreadyStopping),
-		errors.Is(err, errs.ErrForbidden):
-		resp.Conversation = helper.Ptr(err.Error())
-	}
+	case errors.As(err, &argErr):
+		reply = c.T(messages.CmdUsage, argErr.In(c.Lang()), argErr.Usage)
+	case errors.As(err, &msgErr):
+		reply = msgErr.In(c.Lang())
+	case errors.Is(err, context.DeadlineExceeded):
+		reply = c.T(messages.CmdTimeout)
 
-	if (resp != dto.WhatsappMessage{}) {
-		_, _ = c.SendMessage(c, c.Chat, &resp)
+	// not for the bot, or shutting down
+	case errors.Is(err, errs.ErrWAGroupNotWhitelisted),
+		errors.Is(err, errs.ErrWAUserNotWhitelisted),
+		errors.Is(err, context.Canceled):
+		slog.DebugContext(c, "wa command ignored", config.KeyLogErr, err.Error())
 		return
+
+	default:
+		if id, ok := messages.ErrorID(err); ok {
+			reply = c.T(id)
+			break
+		}
+
+		// already logged with its stack
+		if !errors.Is(err, errs.ErrCommandPanicked) {
+			config.ErrLog(c, err, nil)
+		}
+		reply = c.T(messages.CmdFailed, c.RequestID)
 	}
 
-	// debug
-	slog.Warn("Unhandled error in wa handler", "error", err.Error())
+	if _, sendErr := c.Reply(reply); sendErr != nil {
+		slog.WarnContext(c, "failed to send error reply", config.KeyLogErr, sendErr.Error())
+	}
 }
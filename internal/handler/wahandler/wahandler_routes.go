@@ -1,17 +1,80 @@
 package wahandler
 
+import (
+	"exaroton-wa-bot/internal/config/warouter"
+	"exaroton-wa-bot/internal/service/command"
+	"fmt"
+)
+
 func (h *WaHandler) LoadCommandRoutes() {
 	router := h.router
 	mdw := h.mdw
 
 	// middlewares
+	router.Use(mdw.Recover())
+	router.Use(mdw.WhitelistedWAChat(command.RegisterCmdName)) // a group whitelists itself with /register
+	router.Use(mdw.RateLimit())
+	router.Use(mdw.StatusReactions())
 	router.Use(mdw.ValidExarotonAPIKey())
-	router.Use(mdw.WhitelistedWAGroup())
-
-	router.Register("/help", h.HelpCommand())    // shows the manual page/guide thru WhatsApp chat for commands available
-	router.Register("/servers", h.ListServers()) // shows available server ids
-	router.Register("/start", h.StartServer())   // [server-id] starts the server specified by its id
-	router.Register("/stop", h.StopServer())     // [server-id] stops the server specified by its id
-	router.Register("/info", h.ServerInfo())     // [server-id] shows the current server info
-	router.Register("/players", h.ListPlayers()) // [server-id] shows the players that are currently online on a server
+
+	router.Register("/help", h.HelpCommand(), h.commandMiddlewares(command.HelpCmdName)...)               // shows the manual page/guide thru WhatsApp chat for commands available
+	router.Register("/servers", h.ListServers(), h.commandMiddlewares(command.ListServerCmdName)...)      // shows available server ids
+	router.Register("/start", h.StartServer(), h.commandMiddlewares(command.StartServerCmdName)...)       // [server-id] starts the server specified by its id
+	router.Register("/stop", h.StopServer(), h.commandMiddlewares(command.StopServerCmdName)...)          // [server-id] stops the server specified by its id, asks first if players are online
+	router.Register("/restart", h.RestartServer(), h.commandMiddlewares(command.RestartServerCmdName)...) // [server-id] restarts the server specified by its id, asks first
+	router.Register("/info", h.ServerInfo(), h.commandMiddlewares(command.InfoCmdName)...)                // [server-id] shows the current server info
+	router.Register("/players", h.ListPlayers(), h.commandMiddlewares(command.ListPlayersCmdName)...)     // [server-id] shows the players that are currently online on a server
+	router.Register("/status", h.ServersStatus(), h.commandMiddlewares(command.StatusCmdName)...)         // shows an overview of every server
+	router.Register("/jobs", h.ListJobs(), h.commandMiddlewares(command.JobsCmdName)...)                  // shows the running jobs (e.g. server starts) of the chat
+	router.Register("/cancel", h.CancelJob(), h.commandMiddlewares(command.CancelJobCmdName)...)          // [job-id] cancels a running job
+	router.Register("/whoami", h.WhoAmI(), h.commandMiddlewares(command.WhoAmICmdName)...)                // shows the sender's id and role
+	router.Register("/lang", h.Language(), h.commandMiddlewares(command.LangCmdName)...)                  // [language] shows or changes the language of the chat's replies
+	router.Register("/register", h.Register(), h.commandMiddlewares(command.RegisterCmdName)...)          // [code] whitelists the group with a registration code from the web UI
+
+	// aliases declared by the commands, e.g. /s for /start
+	for _, cmd := range h.cmdRegis.List() {
+		for _, alias := range cmd.Aliases() {
+			router.Alias(alias, cmd.Name())
+		}
+	}
+
+	// reactions on the bot's messages
+	router.RegisterReactionFiltered(startVoteEmoji, h.isStartVoteMessage, h.StartVoteReaction(), h.commandMiddlewares(command.StartServerCmdName)...) // votes on an open start vote
+	router.RegisterReaction(startServerEmoji, h.StartServer(), h.reactionMiddlewares(command.StartServerCmdName)...)                                  // starts the server of a status message
+	router.RegisterReaction(stopServerEmoji, h.StopServer(), h.reactionMiddlewares(command.StopServerCmdName)...)                                     // stops the server of a status message
+}
+
+// commandMiddlewares returns the middlewares enforcing what a command declares:
+// its required role and its cooldown. A missing server ID is asked with a poll,
+// and the server must be one the chat can use.
+func (h *WaHandler) commandMiddlewares(name string) []warouter.MiddlewareFunc {
+	cmd, ok := h.cmdRegis.Get(name)
+	if !ok {
+		panic("no command registered as " + name)
+	}
+
+	mws := []warouter.MiddlewareFunc{h.mdw.RequireRole(cmd.Role())}
+
+	if needsServerID(cmd) {
+		mws = append(mws, h.serverChoice(name), h.serverScope(name))
+	}
+
+	if cd := cmd.Cooldown(); cd.Every > 0 {
+		mws = append(mws, h.mdw.Cooldown(cd.Every, func(c *warouter.Context) (string, bool) {
+			if cd.Arg == "" {
+				return "", true
+			}
+
+			// bad args are reported by the handler, without using the cooldown,
+			// start vote reactions have no args
+			args, err := h.cmdRegis.Parse(name, c.Args)
+			if err != nil {
+				return "", false
+			}
+
+			return fmt.Sprint(args.Get(cd.Arg)), true
+		}))
+	}
+
+	return mws
 }
@@ -0,0 +1,58 @@
+package wahandler
+
+import (
+	"exaroton-wa-bot/internal/config/warouter"
+	"sync"
+)
+
+// WaHandlers runs a WaHandler per opened device, see repository.WADevices.
+type WaHandlers struct {
+	newHandler func(deviceID uint, wa warouter.WhatsappService) *WaHandler
+
+	mu       sync.Mutex
+	handlers map[uint]*WaHandler // key: device id
+}
+
+func NewWaHandlers(newHandler func(deviceID uint, wa warouter.WhatsappService) *WaHandler) *WaHandlers {
+	return &WaHandlers{
+		newHandler: newHandler,
+		handlers:   make(map[uint]*WaHandler),
+	}
+}
+
+// Start creates and runs the handler of a device, replacing the running one.
+func (h *WaHandlers) Start(deviceID uint, wa warouter.WhatsappService) {
+	h.Stop(deviceID)
+
+	handler := h.newHandler(deviceID, wa)
+	handler.Run()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.handlers[deviceID] = handler
+}
+
+// Stop stops the handler of a device, if it's running.
+func (h *WaHandlers) Stop(deviceID uint) {
+	h.mu.Lock()
+	handler, ok := h.handlers[deviceID]
+	delete(h.handlers, deviceID)
+	h.mu.Unlock()
+
+	if ok {
+		handler.Stop()
+	}
+}
+
+// StopAll stops the handler of every device.
+func (h *WaHandlers) StopAll() {
+	h.mu.Lock()
+	handlers := h.handlers
+	h.handlers = make(map[uint]*WaHandler)
+	h.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler.Stop()
+	}
+}
@@ -54,7 +54,7 @@ func NewWeb(cfg *config.Cfg, svc *service.Service) *Web {
 	webSession := dto.NewWebSession()
 
 	router.Validator = config.NewValidator()
-	middleware := middleware.NewMiddleware(cfg, svc.AuthService, webSession)
+	middleware := middleware.NewMiddleware(cfg, svc.AuthService, svc.WhatsappDeviceService, webSession)
 
 	web := &Web{
 		Router:     router,
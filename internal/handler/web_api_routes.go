@@ -8,6 +8,8 @@ var APIRoutes = new(struct {
 
 	WaLoginQRRoute     *echo.Route
 	WaLoginNumberRoute *echo.Route
+	WaDevicesRoute     *echo.Route
+	WaConnectionRoute  *echo.Route
 })
 
 func (web *Web) LoadAPIRoutes() {
@@ -15,6 +17,7 @@ func (web *Web) LoadAPIRoutes() {
 
 	// middlewares
 	apiGroup.Use(web.middleware.Session())
+	apiGroup.Use(web.middleware.WhatsappDevice())
 	apiGroup.Use(web.middleware.Logger())
 	apiGroup.Use(web.middleware.Recover())
 
@@ -41,13 +44,49 @@ func (web *Web) LoadAPIRoutes() {
 			whatsappGroup.GET("/groups", web.APIGetWhatsappGroups())
 			whatsappGroup.POST("/groups/whitelist", web.APIWhatsappGroupWhitelist())
 			whatsappGroup.DELETE("/groups/whitelist", web.APIWhatsappGroupUnwhitelist())
+			whatsappGroup.DELETE("/groups/whitelist/left", web.APIWhatsappGroupsCleanup())
+			whatsappGroup.GET("/groups/invite", web.APIPreviewWhatsappGroupInvite())
+			whatsappGroup.POST("/groups/join", web.APIJoinWhatsappGroup())
+			whatsappGroup.GET("/groups/settings", web.APIGetWhatsappGroupSettings())
+			whatsappGroup.PUT("/groups/settings", web.APIUpdateWhatsappGroupSettings())
+			whatsappGroup.GET("/users", web.APIGetWhatsappUsers())
+			whatsappGroup.POST("/users/whitelist", web.APIWhatsappUserWhitelist())
+			whatsappGroup.DELETE("/users/whitelist", web.APIWhatsappUserUnwhitelist())
+			whatsappGroup.GET("/roles", web.APIGetWhatsappRoles())
+			whatsappGroup.PUT("/roles", web.APIAssignWhatsappRole())
+			whatsappGroup.DELETE("/roles", web.APIUnassignWhatsappRole())
+			whatsappGroup.GET("/registration-codes", web.APIGetRegistrationCodes())
+			whatsappGroup.POST("/registration-codes", web.APICreateRegistrationCode())
+			whatsappGroup.DELETE("/registration-codes", web.APIDeleteRegistrationCode())
+			whatsappGroup.GET("/registrations", web.APIGetGroupRegistrations())
+		}
+
+		// message templates
+		templatesGroup := settingsGroup.Group("/templates")
+		{
+			templatesGroup.GET("", web.APIGetMessageTemplates())
+			templatesGroup.PUT("", web.APIUpdateMessageTemplate())
+			templatesGroup.DELETE("", web.APIResetMessageTemplate())
 		}
 	}
 
+	// whatsapp devices
+	waDevicesGroup := apiGroup.Group("/whatsapp/devices", authMdw)
+	{
+		APIRoutes.WaDevicesRoute = waDevicesGroup.GET("", web.APIGetWhatsappDevices())
+		waDevicesGroup.POST("", web.APICreateWhatsappDevice())
+		waDevicesGroup.DELETE("", web.APIDeleteWhatsappDevice())
+		waDevicesGroup.PUT("/current", web.APISelectWhatsappDevice())
+		waDevicesGroup.POST("/logout", web.APIWhatsappDeviceLogout())
+	}
+
+	// connection state and history of the selected device
+	APIRoutes.WaConnectionRoute = apiGroup.GET("/whatsapp/connection", web.APIGetWhatsappConnection(), authMdw)
+
 	// whatsapp login
 	waLoginGroup := apiGroup.Group("/whatsapp/login", authMdw, waGuestMdw)
 	{
 		APIRoutes.WaLoginQRRoute = waLoginGroup.GET("/qr", web.APIWhatsappQRLogin())
-		APIRoutes.WaLoginNumberRoute = waLoginGroup.GET("/number", nil)
+		APIRoutes.WaLoginNumberRoute = waLoginGroup.GET("/number", web.APIWhatsappNumberLogin())
 	}
 }
@@ -33,7 +33,7 @@ func errorHandler() echo.HTTPErrorHandler {
 		// web socket errors should be handled by the handler itself
 		// so no need to handle them here
 		if strings.HasPrefix(c.Request().Header.Get("Upgrade"), "websocket") {
-			slog.Error("web socket error", "error", err)
+			slog.ErrorContext(c.Request().Context(), "web socket error", "error", err)
 			return
 		}
 
@@ -120,6 +120,14 @@ func apiErrorHandler(err error, c echo.Context) error {
 	case errors.Is(err, errs.ErrLoginFailed):
 		httpErr.Code, httpErr.Message = http.StatusUnauthorized, errs.ErrLoginFailed.Error()
 
+	// whatsapp devices
+	case errors.Is(err, errs.ErrWADeviceNotFound):
+		httpErr.Code, httpErr.Message = http.StatusNotFound, errs.ErrWADeviceNotFound.Error()
+	case errors.Is(err, errs.ErrWADeviceNotRemovable):
+		httpErr.Code, httpErr.Message = http.StatusBadRequest, errs.ErrWADeviceNotRemovable.Error()
+	case errors.Is(err, errs.ErrWAInviteLinkInvalid):
+		httpErr.Code, httpErr.Message = http.StatusBadRequest, errs.ErrWAInviteLinkInvalid.Error()
+
 	// game server related errors
 	case errors.Is(err, errs.ErrGSInvalidAPIKey):
 		httpErr.Code, httpErr.Message = http.StatusUnauthorized, errs.ErrGSInvalidAPIKey.Error()
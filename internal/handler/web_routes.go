@@ -19,6 +19,7 @@ var WebRoutes = new(struct {
 	WaLoginPageQRRoute        *echo.Route
 	WaLoginPageNumberRoute    *echo.Route
 	WaLoginQRRoute            *echo.Route
+	WaDevicesPageRoute        *echo.Route
 	SettingsExarotonPageRoute *echo.Route
 })
 
@@ -37,6 +38,7 @@ func (web *Web) LoadRoutes() {
 
 	// middlewares
 	webGroup.Use(web.middleware.Session())
+	webGroup.Use(web.middleware.WhatsappDevice())
 	webGroup.Use(web.middleware.Logger())
 	webGroup.Use(web.middleware.Recover())
 	webGroup.Use(web.middleware.FlashMessage())
@@ -59,6 +61,9 @@ func (web *Web) LoadRoutes() {
 		WebRoutes.LoginRoute = userGroup.POST("/login", web.UserLogin(), guestMdw)
 	}
 
+	// whatsapp devices, each is paired thru the login routes once selected
+	WebRoutes.WaDevicesPageRoute = webGroup.GET("/whatsapp/devices", web.WhatsappDevicesPage(), authMdw)
+
 	// whatsapp login routes
 	waGroup := webGroup.Group("/whatsapp/login", authMdw, waGuestMdw)
 	{
@@ -79,6 +84,9 @@ func (web *Web) LoadRoutes() {
 		whatsappGroup := settingsGroup.Group("/whatsapp")
 		{
 			whatsappGroup.GET("", web.SettingsWhatsappPage())
+			whatsappGroup.GET("/roles", web.SettingsWhatsappRolesPage())
 		}
+
+		settingsGroup.GET("/templates", web.SettingsTemplatesPage())
 	}
 }
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"exaroton-wa-bot/internal/dto"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// whatsappConnectionHistorySize is the number of history entries shown on the
+// dashboard.
+const whatsappConnectionHistorySize = 20
+
+func (w *Web) APIGetWhatsappConnection() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		history, err := w.svc.WhatsappConnectionService.History(ctx, whatsappConnectionHistorySize)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Data: &dto.WhatsappConnectionRes{
+				Status:  w.svc.WhatsappConnectionService.Status(ctx),
+				History: history,
+			},
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/pages"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (w *Web) WhatsappDevicesPage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.Render(http.StatusOK, pages.WhatsappDevices, nil)
+	}
+}
+
+func (w *Web) APIGetWhatsappDevices() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		res, err := w.svc.WhatsappDeviceService.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, device := range res {
+			status := w.svc.WhatsappConnectionService.Status(dto.WithWhatsappDevice(ctx, device.ID))
+			device.Connection = &status
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func (w *Web) APICreateWhatsappDevice() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.CreateWhatsappDeviceReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		res, err := w.svc.WhatsappDeviceService.Create(c.Request().Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, &dto.APIResponse{
+			Success: true,
+			Message: messages.DeviceCreated,
+			Data:    res,
+		})
+	}
+}
+
+func (w *Web) APIDeleteWhatsappDevice() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.WhatsappDeviceReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		if err = w.svc.WhatsappDeviceService.Delete(c.Request().Context(), req); err != nil {
+			return err
+		}
+
+		// the session falls back to the default device, see middleware.WhatsappDevice
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: messages.DeviceRemoved,
+		})
+	}
+}
+
+// APISelectWhatsappDevice sets the device managed in the web ui, e.g. the one
+// the whatsapp login pages pair.
+func (w *Web) APISelectWhatsappDevice() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.WhatsappDeviceReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		ok, err := w.svc.WhatsappDeviceService.Exists(c.Request().Context(), req.ID)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return errs.ErrWADeviceNotFound
+		}
+
+		if err = w.session.SetWhatsappDevice(c, req.ID); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: messages.DeviceSelected,
+		})
+	}
+}
+
+func (w *Web) APIWhatsappDeviceLogout() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.WhatsappDeviceReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		ctx := dto.WithWhatsappDevice(c.Request().Context(), req.ID)
+		if !w.svc.AuthService.WhatsappIsLoggedIn(ctx) {
+			return errs.ErrWANotLoggedIn
+		}
+
+		if err = w.svc.AuthService.WhatsappLogout(ctx); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: messages.DeviceLoggedOut,
+		})
+	}
+}
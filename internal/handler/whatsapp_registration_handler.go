@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// registrationsLimit is how many redemptions the audit trail shows.
+const registrationsLimit = 50
+
+func (w *Web) APIGetRegistrationCodes() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res, err := w.svc.WhatsappRegistrationService.GetCodes(c.Request().Context())
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func (w *Web) APICreateRegistrationCode() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.CreateRegistrationCodeReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		res, err := w.svc.WhatsappRegistrationService.CreateCode(c.Request().Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: messages.RegistrationCodeCreated,
+			Data:    res,
+		})
+	}
+}
+
+func (w *Web) APIDeleteRegistrationCode() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.DeleteRegistrationCodeReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		if err = w.svc.WhatsappRegistrationService.DeleteCode(c.Request().Context(), req); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: messages.RegistrationCodeDeleted,
+		})
+	}
+}
+
+// APIGetGroupRegistrations returns the audit trail of the redeemed codes.
+func (w *Web) APIGetGroupRegistrations() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res, err := w.svc.WhatsappRegistrationService.GetRegistrations(c.Request().Context(), registrationsLimit)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/pages"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (w *Web) SettingsWhatsappRolesPage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.Render(http.StatusOK, pages.SettingsWhatsappRoles, dto.SettingsWhatsappRolesPageData{
+			Roles: dto.WhatsappRoleNames(),
+		})
+	}
+}
+
+func (w *Web) APIGetWhatsappRoles() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res, err := w.svc.RoleService.GetAll(c.Request().Context())
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func (w *Web) APIAssignWhatsappRole() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.AssignWhatsappRoleReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		if err = w.svc.RoleService.Assign(c.Request().Context(), req); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: messages.RoleAssigned,
+			Data:    dto.NewWhatsappUserRole(req.ToEntity()),
+		})
+	}
+}
+
+func (w *Web) APIUnassignWhatsappRole() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.UnassignWhatsappRoleReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		if err = w.svc.RoleService.Unassign(c.Request().Context(), req); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: messages.RoleUnassigned,
+		})
+	}
+}
@@ -12,7 +12,7 @@ import (
 
 func (w *Web) SettingsWhatsappPage() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		number := w.svc.AuthService.GetWhatsappPhoneNumber()
+		number := w.svc.AuthService.GetWhatsappPhoneNumber(c.Request().Context())
 		if number == "" {
 			return errs.ErrWANotLoggedIn
 		}
@@ -69,6 +69,124 @@ func (w *Web) APIWhatsappGroupUnwhitelist() echo.HandlerFunc {
 	}
 }
 
+func (w *Web) APIPreviewWhatsappGroupInvite() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.WhatsappGroupInviteReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		res, err := w.svc.WhatsappService.PreviewGroupInvite(c.Request().Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func (w *Web) APIJoinWhatsappGroup() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.JoinWhatsappGroupReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		res, err := w.svc.WhatsappService.JoinGroup(c.Request().Context(), req)
+		if err != nil {
+			return err
+		}
+
+		message := messages.GroupJoined
+		if req.Whitelist {
+			message = messages.GroupJoinedWhitelisted
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: message,
+			Data:    res,
+		})
+	}
+}
+
+// APIWhatsappGroupsCleanup unwhitelists the groups the bot isn't in anymore.
+func (w *Web) APIWhatsappGroupsCleanup() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res, err := w.svc.WhatsappService.CleanupGroups(c.Request().Context())
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: messages.GroupsCleanedUp,
+			Data:    res,
+		})
+	}
+}
+
+func (w *Web) APIGetWhatsappUsers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		res, err := w.svc.AuthService.GetWhatsappWhitelistedUsers(c.Request().Context())
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func (w *Web) APIWhatsappUserWhitelist() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.WhitelistWhatsappUserReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		if err = w.svc.WhatsappService.WhitelistUser(c.Request().Context(), req); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: messages.UserWhitelistSuccess,
+		})
+	}
+}
+
+func (w *Web) APIWhatsappUserUnwhitelist() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.UnwhitelistWhatsappUserReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		if err = w.svc.WhatsappService.UnwhitelistUser(c.Request().Context(), req); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: messages.UserUnwhitelistSuccess,
+		})
+	}
+}
+
 func (w *Web) APIGetWhatsappGroups() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		req := new(dto.GetWhatsappGroupReq)
@@ -90,6 +208,50 @@ func (w *Web) APIGetWhatsappGroups() echo.HandlerFunc {
 	}
 }
 
+func (w *Web) APIGetWhatsappGroupSettings() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.GetWhatsappGroupSettingsReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		res, err := w.svc.WhatsappService.GetGroupSettings(c.Request().Context(), dto.WhatsappJID{
+			User:   req.User,
+			Server: req.Server,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Data:    res,
+		})
+	}
+}
+
+func (w *Web) APIUpdateWhatsappGroupSettings() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.UpdateWhatsappGroupSettingsReq)
+
+		err := w.shouldBind(c, req)
+		if err != nil {
+			return err
+		}
+
+		if err = w.svc.WhatsappService.UpdateGroupSettings(c.Request().Context(), req); err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, &dto.APIResponse{
+			Success: true,
+			Message: messages.GroupSettingsSaved,
+		})
+	}
+}
+
 func (w *Web) APIWhatsappIsSync() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		return c.JSON(http.StatusOK, &dto.APIResponse{
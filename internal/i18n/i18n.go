@@ -0,0 +1,243 @@
+// Package i18n translates the replies of the bot. The catalog (locales/*.json)
+// has the text of every message ID in each language, as fmt formats. A
+// message can have plural forms, picked by N with the plural rule of the
+// language.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+)
+
+type Lang string
+
+const (
+	EN Lang = "en"
+	ID Lang = "id"
+
+	// Default is the language of the chats without one, and the fallback of
+	// the messages missing in a language.
+	Default = EN
+)
+
+var langNames = map[Lang]string{
+	EN: "English",
+	ID: "Bahasa Indonesia",
+}
+
+// Langs returns the supported languages, sorted by code.
+func Langs() []Lang {
+	langs := make([]Lang, 0, len(langNames))
+	for lang := range langNames {
+		langs = append(langs, lang)
+	}
+	slices.Sort(langs)
+
+	return langs
+}
+
+// ParseLang parses a language code case-insensitively, e.g. "ID".
+func ParseLang(s string) (Lang, bool) {
+	lang := Lang(strings.ToLower(strings.TrimSpace(s)))
+	_, ok := langNames[lang]
+
+	return lang, ok
+}
+
+func (l Lang) String() string {
+	return string(l)
+}
+
+// Name returns the name of the language in itself, e.g. "Bahasa Indonesia".
+func (l Lang) Name() string {
+	if name, ok := langNames[l]; ok {
+		return name
+	}
+
+	return string(l)
+}
+
+// plural forms of a message, as named in the catalog
+const (
+	pluralOne   = "one"
+	pluralOther = "other"
+)
+
+// pluralRules picks the plural form of a count per language, languages
+// without a rule only use the "other" form.
+var pluralRules = map[Lang]func(n int) string{
+	EN: func(n int) string {
+		if n == 1 {
+			return pluralOne
+		}
+		return pluralOther
+	},
+}
+
+// message is a catalog entry, either a string or an object of plural forms.
+type message map[string]string
+
+func (m *message) UnmarshalJSON(b []byte) error {
+	var text string
+	if err := json.Unmarshal(b, &text); err == nil {
+		*m = message{pluralOther: text}
+		return nil
+	}
+
+	forms := make(map[string]string)
+	if err := json.Unmarshal(b, &forms); err != nil {
+		return err
+	}
+	if _, ok := forms[pluralOther]; !ok {
+		return fmt.Errorf("plural forms without %q", pluralOther)
+	}
+
+	*m = forms
+	return nil
+}
+
+//go:embed locales/*.json
+var locales embed.FS
+
+var catalog = mustLoadCatalog()
+
+// mustLoadCatalog loads a catalog file per language, it panics if one is
+// missing or invalid.
+func mustLoadCatalog() map[Lang]map[string]message {
+	c := make(map[Lang]map[string]message, len(langNames))
+
+	for lang := range langNames {
+		b, err := locales.ReadFile(path.Join("locales", string(lang)+".json"))
+		if err != nil {
+			panic(fmt.Sprintf("catalog of %s not found", lang))
+		}
+
+		messages := make(map[string]message)
+		if err := json.Unmarshal(b, &messages); err != nil {
+			panic(fmt.Sprintf("invalid catalog of %s: %s", lang, err.Error()))
+		}
+
+		c[lang] = messages
+	}
+
+	return c
+}
+
+// Has reports whether a message ID is in the catalog.
+func Has(id string) bool {
+	_, ok := catalog[Default][id]
+	return ok
+}
+
+// T translates a message, formatting args. Args that are a Message or an
+// *Error are translated too. A message missing in lang falls back to the
+// default language, then to its ID.
+func T(lang Lang, id string, args ...any) string {
+	return format(lang, id, pluralOther, args)
+}
+
+// N translates a message with plural forms, the form is picked for n which
+// is also the first formatted arg, e.g. N(lang, "players", 2) for "%d players".
+func N(lang Lang, id string, n int, args ...any) string {
+	form := pluralOther
+	if rule, ok := pluralRules[lang]; ok {
+		form = rule(n)
+	}
+
+	return format(lang, id, form, append([]any{n}, args...))
+}
+
+func format(lang Lang, id, form string, args []any) string {
+	m, ok := catalog[lang][id]
+	if !ok {
+		if m, ok = catalog[Default][id]; !ok {
+			slog.Warn("message missing in the catalog", "id", id, "lang", lang)
+			return id
+		}
+	}
+
+	text, ok := m[form]
+	if !ok {
+		text = m[pluralOther]
+	}
+
+	args = slices.Clone(args)
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case Message:
+			args[i] = v.In(lang)
+		case *Error:
+			args[i] = v.In(lang)
+		}
+	}
+
+	if len(args) == 0 {
+		return text
+	}
+
+	return fmt.Sprintf(text, args...)
+}
+
+// Message is a message translated later, e.g. once the language of the chat is known.
+type Message struct {
+	ID   string
+	Args []any
+}
+
+// M returns a message to translate later.
+func M(id string, args ...any) Message {
+	return Message{ID: id, Args: args}
+}
+
+// In translates the message.
+func (m Message) In(lang Lang) string {
+	return T(lang, m.ID, m.Args...)
+}
+
+// Error is an error with a message for the user, it wraps the error to check
+// with errors.Is, e.g. errs.ErrRateLimited. Its Error is the message in the
+// default language.
+type Error struct {
+	err error
+	msg Message
+}
+
+// NewError returns an error wrapping err, with the message id formatted with args.
+func NewError(err error, id string, args ...any) *Error {
+	return &Error{err: err, msg: M(id, args...)}
+}
+
+func (e *Error) Error() string {
+	return e.msg.In(Default)
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// In translates the message of the error.
+func (e *Error) In(lang Lang) string {
+	return e.msg.In(lang)
+}
+
+type langKey struct{}
+
+// WithLang returns a copy of ctx carrying the language of the replies.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langKey{}, lang)
+}
+
+// FromContext returns the language carried by ctx, the default one if none.
+func FromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langKey{}).(Lang); ok && lang != "" {
+		return lang
+	}
+
+	return Default
+}
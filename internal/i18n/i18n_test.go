@@ -0,0 +1,80 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fmt verbs, without "%%"
+var verbRe = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*\d*(\.\d+)?[a-zA-Z]`)
+
+func TestCatalog(t *testing.T) {
+	for _, lang := range Langs() {
+		t.Run(lang.String(), func(t *testing.T) {
+			for id, m := range catalog[Default] {
+				translated, ok := catalog[lang][id]
+				if !assert.True(t, ok, "%s is missing", id) {
+					continue
+				}
+
+				// the translation formats the same args
+				want := len(verbRe.FindAllString(m[pluralOther], -1))
+				for form, text := range translated {
+					assert.Len(t, verbRe.FindAllString(text, -1), want, "%s (%s): %q", id, form, text)
+				}
+			}
+
+			for id := range catalog[lang] {
+				assert.True(t, Has(id), "%s isn't in the default catalog", id)
+			}
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Unknown command /strat, see /help", T(EN, "cmd.unknown", "/", "strat"))
+	assert.Equal(t, "Perintah /strat tidak dikenal, lihat /help", T(ID, "cmd.unknown", "/", "strat"))
+	assert.Equal(t, "Cancelled.", T(Lang("xx"), "confirm.cancelled"), "unknown language")
+	assert.Equal(t, "nope", T(EN, "nope"), "unknown message")
+
+	// nested messages are translated in the same language
+	assert.Equal(t, "argumen 'id' harus berupa angka", T(ID, "arg.invalid", "id", M("arg.must_be_number")))
+}
+
+func TestN(t *testing.T) {
+	assert.Equal(t, "1 player", N(EN, "tmpl.players.count", 1))
+	assert.Equal(t, "2 players", N(EN, "tmpl.players.count", 2))
+	assert.Equal(t, "0 players", N(EN, "tmpl.players.count", 0))
+	assert.Equal(t, "1 pemain", N(ID, "tmpl.players.count", 1))
+
+	assert.Equal(t, "3 players are online on Survival. Stop it anyway? Reply yes or no.", N(EN, "confirm.stop_server", 3, "Survival"))
+}
+
+func TestError(t *testing.T) {
+	base := errors.New("rate limited")
+	err := NewError(base, "err.rate_limited", "5s")
+
+	assert.ErrorIs(t, err, base)
+	assert.Equal(t, "You are sending commands too fast, please try again in 5s 🙏", err.Error())
+	assert.Equal(t, "Kamu mengirim perintah terlalu cepat, silakan coba lagi dalam 5s 🙏", err.In(ID))
+}
+
+func TestParseLang(t *testing.T) {
+	lang, ok := ParseLang(" ID ")
+	assert.True(t, ok)
+	assert.Equal(t, ID, lang)
+
+	_, ok = ParseLang("fr")
+	assert.False(t, ok)
+
+	assert.Equal(t, []Lang{EN, ID}, Langs())
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, Default, FromContext(context.Background()))
+	assert.Equal(t, ID, FromContext(WithLang(context.Background(), ID)))
+}
@@ -7,19 +7,22 @@ import (
 )
 
 type Middleware struct {
-	cfg     *config.Cfg
-	authSvc service.IAuthService
-	session dto.WebSession
+	cfg       *config.Cfg
+	authSvc   service.IAuthService
+	deviceSvc service.IWhatsappDeviceService
+	session   dto.WebSession
 }
 
 func NewMiddleware(
 	cfg *config.Cfg,
 	authSvc service.IAuthService,
+	deviceSvc service.IWhatsappDeviceService,
 	session dto.WebSession,
 ) *Middleware {
 	return &Middleware{
-		cfg:     cfg,
-		authSvc: authSvc,
-		session: session,
+		cfg:       cfg,
+		authSvc:   authSvc,
+		deviceSvc: deviceSvc,
+		session:   session,
 	}
 }
@@ -0,0 +1,117 @@
+package wamiddleware
+
+import (
+	"sync"
+	"time"
+)
+
+// maxIdleBuckets is how many buckets are kept before the full ones are
+// pruned, a full bucket behaves the same as a missing one.
+const maxIdleBuckets = 1024
+
+// limiter is a token bucket rate limiter per key, e.g. per sender. Each
+// bucket holds up to burst tokens and gets a new one every interval, a call
+// takes one token. With a burst of 1 it's a cooldown of one interval.
+type limiter struct {
+	mu       sync.Mutex
+	burst    float64
+	interval time.Duration
+	buckets  map[string]*bucket
+
+	now func() time.Time
+}
+
+type bucket struct {
+	tokens   float64
+	last     time.Time // last refill
+	notified bool      // the caller was told about the limit, until a token is available again
+}
+
+func newLimiter(burst int, interval time.Duration) *limiter {
+	return &limiter{
+		burst:    float64(burst),
+		interval: interval,
+		buckets:  make(map[string]*bucket),
+		now:      time.Now,
+	}
+}
+
+// allow takes a token of the key's bucket. If there is none, it returns how
+// long until the next token and notify is true only for the first refused
+// call, so the caller is told once and then ignored until the limit resets.
+// A nil limiter allows everything.
+func (l *limiter) allow(key string) (ok bool, notify bool, retryAfter time.Duration) {
+	if l == nil {
+		return true, false, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, found := l.buckets[key]
+	if !found {
+		l.prune(now)
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.refill(now, l.burst, l.interval)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		b.notified = false
+		return true, false, 0
+	}
+
+	retryAfter = time.Duration((1 - b.tokens) * float64(l.interval))
+	notify = !b.notified
+	b.notified = true
+
+	return false, notify, retryAfter
+}
+
+// refund gives back the token of a call that shouldn't count, e.g. a command
+// that failed.
+func (l *limiter) refund(key string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[key]; ok {
+		b.refill(l.now(), l.burst, l.interval)
+		b.tokens = min(l.burst, b.tokens+1)
+	}
+}
+
+func (b *bucket) refill(now time.Time, burst float64, interval time.Duration) {
+	elapsed := now.Sub(b.last)
+	if elapsed <= 0 {
+		return
+	}
+
+	b.tokens = min(burst, b.tokens+float64(elapsed)/float64(interval))
+	b.last = now
+}
+
+// prune drops the full buckets once there are too many, l.mu must be held.
+func (l *limiter) prune(now time.Time) {
+	if len(l.buckets) < maxIdleBuckets {
+		return
+	}
+
+	for key, b := range l.buckets {
+		b.refill(now, l.burst, l.interval)
+		if b.tokens >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// retryIn formats how long to wait, rounded up to a second.
+func retryIn(d time.Duration) string {
+	return (d + time.Second - 1).Truncate(time.Second).String()
+}
@@ -0,0 +1,109 @@
+package wamiddleware
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestLimiter returns a limiter with a clock moved by the returned func.
+func newTestLimiter(burst int, interval time.Duration) (*limiter, func(time.Duration)) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	l := newLimiter(burst, interval)
+	l.now = func() time.Time { return now }
+
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	l, advance := newTestLimiter(2, 10*time.Second)
+
+	for range 2 {
+		ok, _, _ := l.allow("a")
+		assert.True(t, ok)
+	}
+
+	// out of tokens, notified once
+	ok, notify, retryAfter := l.allow("a")
+	assert.False(t, ok)
+	assert.True(t, notify)
+	assert.Equal(t, 10*time.Second, retryAfter)
+
+	advance(4 * time.Second)
+	ok, notify, retryAfter = l.allow("a")
+	assert.False(t, ok)
+	assert.False(t, notify)
+	assert.Equal(t, 6*time.Second, retryAfter)
+
+	// other keys have their own bucket
+	ok, _, _ = l.allow("b")
+	assert.True(t, ok)
+
+	// a token is back, the next limit is notified again
+	advance(6 * time.Second)
+	ok, _, _ = l.allow("a")
+	assert.True(t, ok)
+
+	ok, notify, _ = l.allow("a")
+	assert.False(t, ok)
+	assert.True(t, notify)
+
+	// never more than the burst
+	advance(time.Hour)
+	for range 2 {
+		ok, _, _ = l.allow("a")
+		assert.True(t, ok)
+	}
+	ok, _, _ = l.allow("a")
+	assert.False(t, ok)
+}
+
+func TestLimiter_Refund(t *testing.T) {
+	l, advance := newTestLimiter(1, 10*time.Second)
+
+	ok, _, _ := l.allow("a")
+	assert.True(t, ok)
+	l.refund("a")
+	ok, _, _ = l.allow("a")
+	assert.True(t, ok, "the refunded token is back")
+
+	// never more than the burst
+	advance(time.Hour)
+	l.refund("a")
+	ok, _, _ = l.allow("a")
+	assert.True(t, ok)
+	ok, _, _ = l.allow("a")
+	assert.False(t, ok)
+
+	l.refund("b") // unknown key
+}
+
+func TestLimiter_Nil(t *testing.T) {
+	var l *limiter
+
+	ok, _, _ := l.allow("a")
+	assert.True(t, ok)
+	l.refund("a")
+}
+
+func TestLimiter_Prune(t *testing.T) {
+	l, advance := newTestLimiter(1, time.Second)
+
+	for i := range maxIdleBuckets {
+		l.allow(strconv.Itoa(i))
+	}
+	assert.Len(t, l.buckets, maxIdleBuckets)
+
+	// the old buckets are full again
+	advance(time.Second)
+	l.allow("new")
+	assert.Len(t, l.buckets, 1)
+}
+
+func TestRetryIn(t *testing.T) {
+	assert.Equal(t, "10s", retryIn(9100*time.Millisecond))
+	assert.Equal(t, "2m0s", retryIn(2*time.Minute))
+}
@@ -3,25 +3,37 @@ package wamiddleware
 import (
 	"exaroton-wa-bot/internal/config"
 	"exaroton-wa-bot/internal/config/warouter"
+	"exaroton-wa-bot/internal/constants"
 	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
 	"exaroton-wa-bot/internal/service"
+	"log/slog"
+	"slices"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
 )
 
 type Middleware struct {
 	cfg               *config.Cfg
 	authSvc           service.IAuthService
 	serverSettingsSvc service.IServerSettingsService
+	roleSvc           service.IRoleService
 }
 
 func NewMiddleware(
 	cfg *config.Cfg,
 	authSvc service.IAuthService,
 	serverSettingsSvc service.IServerSettingsService,
+	roleSvc service.IRoleService,
 ) *Middleware {
 	return &Middleware{
 		cfg:               cfg,
 		authSvc:           authSvc,
 		serverSettingsSvc: serverSettingsSvc,
+		roleSvc:           roleSvc,
 	}
 }
 
@@ -31,28 +43,225 @@ func NewMiddleware(
 
 // - Valid Exaroton API Key middleware: ValidExarotonAPIKeyMiddleware
 
-// WhitelistedWAGroup returns a middleware that checks if the user (group) is in a whitelisted.
-func (m *Middleware) WhitelistedWAGroup() warouter.MiddlewareFunc {
+// Recover returns a middleware that recovers from panics in the handlers, the
+// panic is logged with its stack and returned as errs.ErrCommandPanicked.
+func (m *Middleware) Recover() warouter.MiddlewareFunc {
+	return warouter.Recover
+}
+
+// StatusReactions returns a middleware that reacts to the command's message:
+// ⏳ while it runs, then ✅ or ❌. Deferred commands (e.g. background jobs,
+// or questions to answer) react once they're done instead, see
+// warouter.Context.OnAnswered. Unknown commands only get their reply.
+func (m *Middleware) StatusReactions() warouter.MiddlewareFunc {
+	return func(next warouter.HandlerFunc) warouter.HandlerFunc {
+		return func(c *warouter.Context) error {
+			if c.Command == "" {
+				return next(c)
+			}
+
+			react(c, constants.ReactionRunning)
+			c.OnAnswered(reactDone)
+
+			err := next(c)
+			reactDone(c, err)
+
+			return err
+		}
+	}
+}
+
+// reactDone reacts ✅ or ❌ to the command's message, unless it's deferred.
+func reactDone(c *warouter.Context, err error) {
+	switch {
+	case err != nil:
+		react(c, constants.ReactionFailed)
+	case !c.Deferred():
+		react(c, constants.ReactionDone)
+	}
+}
+
+// react reacts to the command's message, a failed reaction doesn't fail the command.
+func react(c *warouter.Context, emoji string) {
+	if err := c.React(emoji); err != nil {
+		slog.WarnContext(c, "failed to react to a command", config.KeyLogErr, err.Error())
+	}
+}
+
+// WhitelistedWAChat returns a middleware that checks if the chat is whitelisted:
+// groups must be in the whitelisted groups, and direct messages must be from a
+// user in the direct messages allowlist. The openCommands can be used in any
+// group, e.g. /register to whitelist it.
+func (m *Middleware) WhitelistedWAChat(openCommands ...string) warouter.MiddlewareFunc {
 	return func(next warouter.HandlerFunc) warouter.HandlerFunc {
 		return func(c *warouter.Context) error {
-			whitelisted := false
-			whitelistedGroups, err := m.authSvc.GetWhatsappWhitelistedGroupJIDs(c.Context)
+			check := m.isWhitelistedGroup
+			if c.IsDirect() {
+				check = m.isWhitelistedUser
+			} else if slices.Contains(openCommands, c.Command) {
+				return next(c)
+			}
+
+			if err := check(c); err != nil {
+				return err
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func (m *Middleware) isWhitelistedGroup(c *warouter.Context) error {
+	whitelistedGroups, err := m.authSvc.GetWhatsappWhitelistedGroupJIDs(c.Context)
+	if err != nil {
+		return err
+	}
+
+	for _, g := range whitelistedGroups {
+		if g.UserJID == c.Chat.User && g.ServerJID == c.Chat.Server {
+			return nil
+		}
+	}
+
+	return errs.ErrWAGroupNotWhitelisted
+}
+
+// isWhitelistedUser matches the sender's phone number, the sender might be
+// addressed by its LID, so the alternative JID is checked too.
+func (m *Middleware) isWhitelistedUser(c *warouter.Context) error {
+	users, err := m.authSvc.GetWhatsappWhitelistedUsers(c.Context)
+	if err != nil {
+		return err
+	}
+
+	for _, jid := range []dto.WhatsappJID{c.Sender, c.SenderAlt} {
+		if jid.Server != types.DefaultUserServer {
+			continue
+		}
+
+		for _, u := range users {
+			if u.Phone == jid.User {
+				return nil
+			}
+		}
+	}
+
+	return errs.ErrWAUserNotWhitelisted
+}
+
+// RequireRole returns a middleware that checks if the sender has at least the
+// given role in the chat. The sender is passed to the handler as the
+// service.Caller of the context, its role is only resolved as far as needed
+// for the check, e.g. the group admins aren't looked up for guest commands.
+func (m *Middleware) RequireRole(role dto.WhatsappRole) warouter.MiddlewareFunc {
+	return func(next warouter.HandlerFunc) warouter.HandlerFunc {
+		return func(c *warouter.Context) error {
+			senderRole, err := m.roleSvc.GetRole(c.Context, c.Chat, role, c.Sender, c.SenderAlt)
 			if err != nil {
 				return err
 			}
 
-			for _, g := range whitelistedGroups {
-				if g.UserJID == c.Chat.User && g.ServerJID == c.Chat.Server {
-					whitelisted = true
-					break
+			if senderRole < role {
+				return i18n.NewError(errs.ErrForbidden, messages.ErrForbiddenRole, role, senderRole)
+			}
+
+			c.Context = service.WithCaller(c.Context, &service.Caller{
+				Chat:    c.Chat,
+				User:    c.Sender,
+				UserAlt: c.SenderAlt,
+				Role:    senderRole,
+			})
+
+			return next(c)
+		}
+	}
+}
+
+// RateLimit returns a middleware that limits how many commands a sender and
+// a chat can send, see config.KeyBotRateLimitSenderBurst and friends. The first
+// refused command gets a reply, the next ones are ignored until the limit resets.
+func (m *Middleware) RateLimit() warouter.MiddlewareFunc {
+	senders := m.newLimiter(config.KeyBotRateLimitSenderBurst, config.KeyBotRateLimitSenderEvery, 5, 10*time.Second)
+	chats := m.newLimiter(config.KeyBotRateLimitChatBurst, config.KeyBotRateLimitChatEvery, 20, 3*time.Second)
+
+	return func(next warouter.HandlerFunc) warouter.HandlerFunc {
+		return func(c *warouter.Context) error {
+			for _, l := range []struct {
+				limiter *limiter
+				key     string
+			}{
+				{senders, c.Chat.String() + "/" + c.Sender.String()},
+				{chats, c.Chat.String()},
+			} {
+				ok, notify, retryAfter := l.limiter.allow(l.key)
+				if ok {
+					continue
 				}
+
+				if !notify {
+					return nil
+				}
+
+				return i18n.NewError(errs.ErrRateLimited, messages.ErrRateLimited, retryIn(retryAfter))
 			}
 
-			if whitelisted {
+			return next(c)
+		}
+	}
+}
+
+// newLimiter builds a limiter from the config, nil (no limit) if the burst is 0.
+func (m *Middleware) newLimiter(burstKey, everyKey string, burst int, every time.Duration) *limiter {
+	if m.cfg != nil && m.cfg.Koanf != nil {
+		if m.cfg.Exists(burstKey) {
+			burst = m.cfg.Int(burstKey)
+		}
+		if d := m.cfg.Duration(everyKey); d > 0 {
+			every = d
+		}
+	}
+
+	if burst <= 0 {
+		return nil
+	}
+
+	return newLimiter(burst, every)
+}
+
+// Cooldown returns a middleware that lets a chat use a command once every
+// period, whoever sends it. key scopes the cooldown, e.g. to a server id, ok is
+// false to skip the cooldown (e.g. bad args, left to the handler to report).
+// Only a command that ran counts: not a failed one, and a confirmed one once
+// it's answered yes. Like RateLimit, only the first refused command gets a
+// reply.
+func (m *Middleware) Cooldown(every time.Duration, key func(c *warouter.Context) (string, bool)) warouter.MiddlewareFunc {
+	cooldowns := newLimiter(1, every)
+
+	return func(next warouter.HandlerFunc) warouter.HandlerFunc {
+		return func(c *warouter.Context) error {
+			k, ok := key(c)
+			if !ok {
 				return next(c)
 			}
 
-			return errs.ErrWAGroupNotWhitelisted
+			k = c.Chat.String() + "/" + k
+			ok, notify, retryAfter := cooldowns.allow(k)
+			if !ok {
+				if !notify {
+					return nil
+				}
+
+				return i18n.NewError(errs.ErrCommandCooldown, messages.ErrCommandCooldown, retryIn(retryAfter))
+			}
+
+			c.OnConfirmed(func() { cooldowns.allow(k) })
+
+			err := next(c)
+			if err != nil || c.Asked() {
+				cooldowns.refund(k)
+			}
+
+			return err
 		}
 	}
 }
@@ -0,0 +1,206 @@
+package wamiddleware
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/config/warouter"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	mockService "exaroton-wa-bot/internal/mocks/service"
+	"exaroton-wa-bot/internal/service"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware_Recover(t *testing.T) {
+	m := NewMiddleware(nil, nil, nil, nil)
+	c := &warouter.Context{Context: context.Background()}
+
+	err := m.Recover()(func(c *warouter.Context) error { panic("boom") })(c)
+	assert.ErrorIs(t, err, errs.ErrCommandPanicked)
+	assert.ErrorContains(t, err, "boom")
+
+	assert.NoError(t, m.Recover()(func(c *warouter.Context) error { return nil })(c))
+}
+
+func TestMiddleware_WhitelistedWAChat(t *testing.T) {
+	authSvc := mockService.NewMockIAuthService(t)
+	authSvc.EXPECT().GetWhatsappWhitelistedGroupJIDs(mock.Anything).Return([]*dto.WhatsappWhitelistedGroup{
+		{UserJID: "1203630", ServerJID: "g.us"},
+	}, nil).Maybe()
+	authSvc.EXPECT().GetWhatsappWhitelistedUsers(mock.Anything).Return([]*dto.WhatsappWhitelistedUser{
+		{Phone: "6285", Name: "Alice"},
+	}, nil).Maybe()
+
+	m := NewMiddleware(nil, authSvc, nil, nil)
+	h := m.WhitelistedWAChat("register")(func(c *warouter.Context) error { return nil })
+
+	pn := dto.WhatsappJID{User: "6285", Server: "s.whatsapp.net"}
+	lid := dto.WhatsappJID{User: "77", Server: "lid"}
+
+	tests := []struct {
+		name string
+		c    *warouter.Context
+		err  error
+	}{
+		{
+			name: "whitelisted group",
+			c:    &warouter.Context{Chat: dto.WhatsappJID{User: "1203630", Server: "g.us"}, Sender: lid},
+		},
+		{
+			name: "other group",
+			c:    &warouter.Context{Chat: dto.WhatsappJID{User: "1203631", Server: "g.us"}, Sender: pn},
+			err:  errs.ErrWAGroupNotWhitelisted,
+		},
+		{
+			name: "open command in other group",
+			c:    &warouter.Context{Chat: dto.WhatsappJID{User: "1203631", Server: "g.us"}, Sender: pn, Command: "register"},
+		},
+		{
+			name: "open command from other user",
+			c:    &warouter.Context{Chat: dto.WhatsappJID{User: "6286", Server: "s.whatsapp.net"}, Sender: dto.WhatsappJID{User: "6286", Server: "s.whatsapp.net"}, Command: "register"},
+			err:  errs.ErrWAUserNotWhitelisted,
+		},
+		{
+			name: "allowed user",
+			c:    &warouter.Context{Chat: pn, Sender: pn},
+		},
+		{
+			name: "allowed user by LID",
+			c:    &warouter.Context{Chat: lid, Sender: lid, SenderAlt: pn},
+		},
+		{
+			name: "unknown LID",
+			c:    &warouter.Context{Chat: lid, Sender: lid},
+			err:  errs.ErrWAUserNotWhitelisted,
+		},
+		{
+			name: "other user",
+			c:    &warouter.Context{Chat: dto.WhatsappJID{User: "6286", Server: "s.whatsapp.net"}, Sender: dto.WhatsappJID{User: "6286", Server: "s.whatsapp.net"}},
+			err:  errs.ErrWAUserNotWhitelisted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.c.Context = context.Background()
+			assert.ErrorIs(t, h(tt.c), tt.err)
+		})
+	}
+}
+
+func TestMiddleware_RequireRole(t *testing.T) {
+	chat := dto.WhatsappJID{User: "1203630", Server: "g.us"}
+	sender := dto.WhatsappJID{User: "77", Server: "lid"}
+	senderAlt := dto.WhatsappJID{User: "6285", Server: "s.whatsapp.net"}
+
+	roleSvc := mockService.NewMockIRoleService(t)
+	// the role is resolved up to the required one
+	roleSvc.EXPECT().GetRole(mock.Anything, chat, dto.RolePlayer, []dto.WhatsappJID{sender, senderAlt}).Return(dto.RolePlayer, nil)
+	roleSvc.EXPECT().GetRole(mock.Anything, chat, dto.RoleOperator, []dto.WhatsappJID{sender, senderAlt}).Return(dto.RolePlayer, nil)
+
+	m := NewMiddleware(nil, nil, nil, roleSvc)
+
+	var caller *service.Caller
+	h := func(c *warouter.Context) error {
+		caller = service.CallerFromContext(c)
+		return nil
+	}
+
+	newCtx := func() *warouter.Context {
+		return &warouter.Context{Context: context.Background(), Chat: chat, Sender: sender, SenderAlt: senderAlt}
+	}
+
+	assert.NoError(t, m.RequireRole(dto.RolePlayer)(h)(newCtx()))
+	assert.Equal(t, &service.Caller{Chat: chat, User: sender, UserAlt: senderAlt, Role: dto.RolePlayer}, caller)
+
+	caller = nil
+	err := m.RequireRole(dto.RoleOperator)(h)(newCtx())
+	assert.ErrorIs(t, err, errs.ErrForbidden)
+	assert.Contains(t, err.Error(), "needs the operator role")
+	assert.Nil(t, caller)
+
+	var msgErr *i18n.Error
+	require.ErrorAs(t, err, &msgErr)
+	assert.Equal(t, i18n.T(i18n.ID, messages.ErrForbiddenRole, dto.RoleOperator, dto.RolePlayer), msgErr.In(i18n.ID))
+}
+
+func TestMiddleware_RateLimit(t *testing.T) {
+	cfg := &config.Cfg{Koanf: koanf.New(".")}
+	require.NoError(t, cfg.Set(config.KeyBotRateLimitSenderBurst, 2))
+	require.NoError(t, cfg.Set(config.KeyBotRateLimitChatBurst, 3))
+
+	m := NewMiddleware(cfg, nil, nil, nil)
+
+	calls := 0
+	h := m.RateLimit()(func(c *warouter.Context) error {
+		calls++
+		return nil
+	})
+
+	chat := dto.WhatsappJID{User: "1203630", Server: "g.us"}
+	newCtx := func(sender string) *warouter.Context {
+		return &warouter.Context{Context: context.Background(), Chat: chat, Sender: dto.WhatsappJID{User: sender, Server: "lid"}}
+	}
+
+	assert.NoError(t, h(newCtx("1")))
+	assert.NoError(t, h(newCtx("1")))
+
+	// the sender is limited, replied once then ignored
+	assert.ErrorIs(t, h(newCtx("1")), errs.ErrRateLimited)
+	assert.NoError(t, h(newCtx("1")))
+	assert.Equal(t, 2, calls)
+
+	// then the chat
+	assert.NoError(t, h(newCtx("2")))
+	assert.ErrorIs(t, h(newCtx("3")), errs.ErrRateLimited)
+	assert.Equal(t, 3, calls)
+}
+
+func TestMiddleware_Cooldown(t *testing.T) {
+	m := NewMiddleware(nil, nil, nil, nil)
+
+	calls := 0
+	failed := errors.New("failed")
+	h := m.Cooldown(time.Minute, func(c *warouter.Context) (string, bool) {
+		if len(c.Args) == 0 {
+			return "", false
+		}
+		return c.Args[0], true
+	})(func(c *warouter.Context) error {
+		calls++
+		if slices.Contains(c.Args, "fail") {
+			return failed
+		}
+		return nil
+	})
+
+	group := dto.WhatsappJID{User: "1203630", Server: "g.us"}
+	newCtx := func(chat dto.WhatsappJID, sender string, args ...string) *warouter.Context {
+		return &warouter.Context{Context: context.Background(), Chat: chat, Sender: dto.WhatsappJID{User: sender, Server: "lid"}, Args: args}
+	}
+
+	assert.ErrorIs(t, h(newCtx(group, "1", "1", "fail")), failed)
+	assert.NoError(t, h(newCtx(group, "1", "1")), "a failed command doesn't count")
+	err := h(newCtx(group, "1", "1"))
+	assert.ErrorIs(t, err, errs.ErrCommandCooldown)
+	assert.Contains(t, err.Error(), "try again in 1m0s")
+	assert.NoError(t, h(newCtx(group, "1", "1"))) // ignored
+	assert.NoError(t, h(newCtx(group, "2", "1"))) // another member of the group, ignored too
+	assert.Equal(t, 2, calls)
+
+	assert.NoError(t, h(newCtx(group, "1", "2")))                                            // another server
+	assert.NoError(t, h(newCtx(dto.WhatsappJID{User: "1203631", Server: "g.us"}, "1", "1"))) // another chat
+	assert.NoError(t, h(newCtx(group, "1")))                                                 // skipped
+	assert.NoError(t, h(newCtx(group, "1")))
+	assert.Equal(t, 6, calls)
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"exaroton-wa-bot/internal/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WhatsappDevice scopes the request to the whatsapp device selected in the
+// session, the default device if none is selected or it was removed.
+func (m *Middleware) WhatsappDevice() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+
+			deviceID, _ := m.session.GetWhatsappDevice(c)
+			if deviceID != 0 {
+				ok, err := m.deviceSvc.Exists(ctx, deviceID)
+				if err != nil {
+					return err
+				}
+
+				if !ok {
+					deviceID = dto.DefaultWhatsappDevice
+				}
+			}
+
+			c.SetRequest(c.Request().WithContext(dto.WithWhatsappDevice(ctx, deviceID)))
+
+			return next(c)
+		}
+	}
+}
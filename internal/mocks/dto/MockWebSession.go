@@ -287,6 +287,66 @@ func (_c *MockWebSession_GetValidationError_Call) RunAndReturn(run func(c echo.C
 	return _c
 }
 
+// GetWhatsappDevice provides a mock function for the type MockWebSession
+func (_mock *MockWebSession) GetWhatsappDevice(c echo.Context) (uint, error) {
+	ret := _mock.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWhatsappDevice")
+	}
+
+	var r0 uint
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(echo.Context) (uint, error)); ok {
+		return returnFunc(c)
+	}
+	if returnFunc, ok := ret.Get(0).(func(echo.Context) uint); ok {
+		r0 = returnFunc(c)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+	if returnFunc, ok := ret.Get(1).(func(echo.Context) error); ok {
+		r1 = returnFunc(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebSession_GetWhatsappDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWhatsappDevice'
+type MockWebSession_GetWhatsappDevice_Call struct {
+	*mock.Call
+}
+
+// GetWhatsappDevice is a helper method to define mock.On call
+//   - c echo.Context
+func (_e *MockWebSession_Expecter) GetWhatsappDevice(c interface{}) *MockWebSession_GetWhatsappDevice_Call {
+	return &MockWebSession_GetWhatsappDevice_Call{Call: _e.mock.On("GetWhatsappDevice", c)}
+}
+
+func (_c *MockWebSession_GetWhatsappDevice_Call) Run(run func(c echo.Context)) *MockWebSession_GetWhatsappDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 echo.Context
+		if args[0] != nil {
+			arg0 = args[0].(echo.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebSession_GetWhatsappDevice_Call) Return(v uint, err error) *MockWebSession_GetWhatsappDevice_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockWebSession_GetWhatsappDevice_Call) RunAndReturn(run func(c echo.Context) (uint, error)) *MockWebSession_GetWhatsappDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetFlash provides a mock function for the type MockWebSession
 func (_mock *MockWebSession) SetFlash(c echo.Context, key string, msg string) error {
 	ret := _mock.Called(c, key, msg)
@@ -526,3 +586,60 @@ func (_c *MockWebSession_SetValidationError_Call) RunAndReturn(run func(c echo.C
 	_c.Call.Return(run)
 	return _c
 }
+
+// SetWhatsappDevice provides a mock function for the type MockWebSession
+func (_mock *MockWebSession) SetWhatsappDevice(c echo.Context, deviceID uint) error {
+	ret := _mock.Called(c, deviceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWhatsappDevice")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(echo.Context, uint) error); ok {
+		r0 = returnFunc(c, deviceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebSession_SetWhatsappDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWhatsappDevice'
+type MockWebSession_SetWhatsappDevice_Call struct {
+	*mock.Call
+}
+
+// SetWhatsappDevice is a helper method to define mock.On call
+//   - c echo.Context
+//   - deviceID uint
+func (_e *MockWebSession_Expecter) SetWhatsappDevice(c interface{}, deviceID interface{}) *MockWebSession_SetWhatsappDevice_Call {
+	return &MockWebSession_SetWhatsappDevice_Call{Call: _e.mock.On("SetWhatsappDevice", c, deviceID)}
+}
+
+func (_c *MockWebSession_SetWhatsappDevice_Call) Run(run func(c echo.Context, deviceID uint)) *MockWebSession_SetWhatsappDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 echo.Context
+		if args[0] != nil {
+			arg0 = args[0].(echo.Context)
+		}
+		var arg1 uint
+		if args[1] != nil {
+			arg1 = args[1].(uint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebSession_SetWhatsappDevice_Call) Return(err error) *MockWebSession_SetWhatsappDevice_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebSession_SetWhatsappDevice_Call) RunAndReturn(run func(c echo.Context, deviceID uint) error) *MockWebSession_SetWhatsappDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -254,6 +254,69 @@ func (_c *MockIExarotonRepo_ListServers_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// RestartServer provides a mock function for the type MockIExarotonRepo
+func (_mock *MockIExarotonRepo) RestartServer(ctx context.Context, apiKey string, serverID string) error {
+	ret := _mock.Called(ctx, apiKey, serverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestartServer")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, apiKey, serverID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIExarotonRepo_RestartServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestartServer'
+type MockIExarotonRepo_RestartServer_Call struct {
+	*mock.Call
+}
+
+// RestartServer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - apiKey string
+//   - serverID string
+func (_e *MockIExarotonRepo_Expecter) RestartServer(ctx interface{}, apiKey interface{}, serverID interface{}) *MockIExarotonRepo_RestartServer_Call {
+	return &MockIExarotonRepo_RestartServer_Call{Call: _e.mock.On("RestartServer", ctx, apiKey, serverID)}
+}
+
+func (_c *MockIExarotonRepo_RestartServer_Call) Run(run func(ctx context.Context, apiKey string, serverID string)) *MockIExarotonRepo_RestartServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIExarotonRepo_RestartServer_Call) Return(err error) *MockIExarotonRepo_RestartServer_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIExarotonRepo_RestartServer_Call) RunAndReturn(run func(ctx context.Context, apiKey string, serverID string) error) *MockIExarotonRepo_RestartServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StartServer provides a mock function for the type MockIExarotonRepo
 func (_mock *MockIExarotonRepo) StartServer(ctx context.Context, apiKey string, serverID string, opt dto.StartExarotonServerReq) error {
 	ret := _mock.Called(ctx, apiKey, serverID, opt)
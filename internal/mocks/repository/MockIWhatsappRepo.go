@@ -42,6 +42,103 @@ func (_m *MockIWhatsappRepo) EXPECT() *MockIWhatsappRepo_Expecter {
 	return &MockIWhatsappRepo_Expecter{mock: &_m.Mock}
 }
 
+// CloseDevice provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) CloseDevice(deviceID uint) {
+	_mock.Called(deviceID)
+	return
+}
+
+// MockIWhatsappRepo_CloseDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseDevice'
+type MockIWhatsappRepo_CloseDevice_Call struct {
+	*mock.Call
+}
+
+// CloseDevice is a helper method to define mock.On call
+//   - deviceID uint
+func (_e *MockIWhatsappRepo_Expecter) CloseDevice(deviceID interface{}) *MockIWhatsappRepo_CloseDevice_Call {
+	return &MockIWhatsappRepo_CloseDevice_Call{Call: _e.mock.On("CloseDevice", deviceID)}
+}
+
+func (_c *MockIWhatsappRepo_CloseDevice_Call) Run(run func(deviceID uint)) *MockIWhatsappRepo_CloseDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uint
+		if args[0] != nil {
+			arg0 = args[0].(uint)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_CloseDevice_Call) Return() *MockIWhatsappRepo_CloseDevice_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_CloseDevice_Call) RunAndReturn(run func(deviceID uint)) *MockIWhatsappRepo_CloseDevice_Call {
+	_c.Run(run)
+	return _c
+}
+
+// DeleteStoredDevice provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) DeleteStoredDevice(ctx context.Context, jid string) error {
+	ret := _mock.Called(ctx, jid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStoredDevice")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, jid)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIWhatsappRepo_DeleteStoredDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStoredDevice'
+type MockIWhatsappRepo_DeleteStoredDevice_Call struct {
+	*mock.Call
+}
+
+// DeleteStoredDevice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jid string
+func (_e *MockIWhatsappRepo_Expecter) DeleteStoredDevice(ctx interface{}, jid interface{}) *MockIWhatsappRepo_DeleteStoredDevice_Call {
+	return &MockIWhatsappRepo_DeleteStoredDevice_Call{Call: _e.mock.On("DeleteStoredDevice", ctx, jid)}
+}
+
+func (_c *MockIWhatsappRepo_DeleteStoredDevice_Call) Run(run func(ctx context.Context, jid string)) *MockIWhatsappRepo_DeleteStoredDevice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_DeleteStoredDevice_Call) Return(err error) *MockIWhatsappRepo_DeleteStoredDevice_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_DeleteStoredDevice_Call) RunAndReturn(run func(ctx context.Context, jid string) error) *MockIWhatsappRepo_DeleteStoredDevice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Disconnect provides a mock function for the type MockIWhatsappRepo
 func (_mock *MockIWhatsappRepo) Disconnect() {
 	_mock.Called()
@@ -75,6 +172,199 @@ func (_c *MockIWhatsappRepo_Disconnect_Call) RunAndReturn(run func()) *MockIWhat
 	return _c
 }
 
+// GetDeviceStatus provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) GetDeviceStatus(ctx context.Context, deviceID uint) dto.WhatsappDeviceStatus {
+	ret := _mock.Called(ctx, deviceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceStatus")
+	}
+
+	var r0 dto.WhatsappDeviceStatus
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint) dto.WhatsappDeviceStatus); ok {
+		r0 = returnFunc(ctx, deviceID)
+	} else {
+		r0 = ret.Get(0).(dto.WhatsappDeviceStatus)
+	}
+	return r0
+}
+
+// MockIWhatsappRepo_GetDeviceStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeviceStatus'
+type MockIWhatsappRepo_GetDeviceStatus_Call struct {
+	*mock.Call
+}
+
+// GetDeviceStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deviceID uint
+func (_e *MockIWhatsappRepo_Expecter) GetDeviceStatus(ctx interface{}, deviceID interface{}) *MockIWhatsappRepo_GetDeviceStatus_Call {
+	return &MockIWhatsappRepo_GetDeviceStatus_Call{Call: _e.mock.On("GetDeviceStatus", ctx, deviceID)}
+}
+
+func (_c *MockIWhatsappRepo_GetDeviceStatus_Call) Run(run func(ctx context.Context, deviceID uint)) *MockIWhatsappRepo_GetDeviceStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint
+		if args[1] != nil {
+			arg1 = args[1].(uint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetDeviceStatus_Call) Return(whatsappDeviceStatus dto.WhatsappDeviceStatus) *MockIWhatsappRepo_GetDeviceStatus_Call {
+	_c.Call.Return(whatsappDeviceStatus)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetDeviceStatus_Call) RunAndReturn(run func(ctx context.Context, deviceID uint) dto.WhatsappDeviceStatus) *MockIWhatsappRepo_GetDeviceStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroupInfo provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) GetGroupInfo(ctx context.Context, group dto.WhatsappJID) (*types.GroupInfo, error) {
+	ret := _mock.Called(ctx, group)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupInfo")
+	}
+
+	var r0 *types.GroupInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.WhatsappJID) (*types.GroupInfo, error)); ok {
+		return returnFunc(ctx, group)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.WhatsappJID) *types.GroupInfo); ok {
+		r0 = returnFunc(ctx, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GroupInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.WhatsappJID) error); ok {
+		r1 = returnFunc(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIWhatsappRepo_GetGroupInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroupInfo'
+type MockIWhatsappRepo_GetGroupInfo_Call struct {
+	*mock.Call
+}
+
+// GetGroupInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - group dto.WhatsappJID
+func (_e *MockIWhatsappRepo_Expecter) GetGroupInfo(ctx interface{}, group interface{}) *MockIWhatsappRepo_GetGroupInfo_Call {
+	return &MockIWhatsappRepo_GetGroupInfo_Call{Call: _e.mock.On("GetGroupInfo", ctx, group)}
+}
+
+func (_c *MockIWhatsappRepo_GetGroupInfo_Call) Run(run func(ctx context.Context, group dto.WhatsappJID)) *MockIWhatsappRepo_GetGroupInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.WhatsappJID
+		if args[1] != nil {
+			arg1 = args[1].(dto.WhatsappJID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetGroupInfo_Call) Return(groupInfo *types.GroupInfo, err error) *MockIWhatsappRepo_GetGroupInfo_Call {
+	_c.Call.Return(groupInfo, err)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetGroupInfo_Call) RunAndReturn(run func(ctx context.Context, group dto.WhatsappJID) (*types.GroupInfo, error)) *MockIWhatsappRepo_GetGroupInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroupInfoFromLink provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) GetGroupInfoFromLink(ctx context.Context, link string) (*types.GroupInfo, error) {
+	ret := _mock.Called(ctx, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupInfoFromLink")
+	}
+
+	var r0 *types.GroupInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*types.GroupInfo, error)); ok {
+		return returnFunc(ctx, link)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *types.GroupInfo); ok {
+		r0 = returnFunc(ctx, link)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GroupInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, link)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIWhatsappRepo_GetGroupInfoFromLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroupInfoFromLink'
+type MockIWhatsappRepo_GetGroupInfoFromLink_Call struct {
+	*mock.Call
+}
+
+// GetGroupInfoFromLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link string
+func (_e *MockIWhatsappRepo_Expecter) GetGroupInfoFromLink(ctx interface{}, link interface{}) *MockIWhatsappRepo_GetGroupInfoFromLink_Call {
+	return &MockIWhatsappRepo_GetGroupInfoFromLink_Call{Call: _e.mock.On("GetGroupInfoFromLink", ctx, link)}
+}
+
+func (_c *MockIWhatsappRepo_GetGroupInfoFromLink_Call) Run(run func(ctx context.Context, link string)) *MockIWhatsappRepo_GetGroupInfoFromLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetGroupInfoFromLink_Call) Return(groupInfo *types.GroupInfo, err error) *MockIWhatsappRepo_GetGroupInfoFromLink_Call {
+	_c.Call.Return(groupInfo, err)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetGroupInfoFromLink_Call) RunAndReturn(run func(ctx context.Context, link string) (*types.GroupInfo, error)) *MockIWhatsappRepo_GetGroupInfoFromLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetGroups provides a mock function for the type MockIWhatsappRepo
 func (_mock *MockIWhatsappRepo) GetGroups(ctx context.Context) ([]*types.GroupInfo, error) {
 	ret := _mock.Called(ctx)
@@ -138,16 +428,16 @@ func (_c *MockIWhatsappRepo_GetGroups_Call) RunAndReturn(run func(ctx context.Co
 }
 
 // GetPhoneNumber provides a mock function for the type MockIWhatsappRepo
-func (_mock *MockIWhatsappRepo) GetPhoneNumber() string {
-	ret := _mock.Called()
+func (_mock *MockIWhatsappRepo) GetPhoneNumber(ctx context.Context) string {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPhoneNumber")
 	}
 
 	var r0 string
-	if returnFunc, ok := ret.Get(0).(func() string); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
@@ -160,13 +450,20 @@ type MockIWhatsappRepo_GetPhoneNumber_Call struct {
 }
 
 // GetPhoneNumber is a helper method to define mock.On call
-func (_e *MockIWhatsappRepo_Expecter) GetPhoneNumber() *MockIWhatsappRepo_GetPhoneNumber_Call {
-	return &MockIWhatsappRepo_GetPhoneNumber_Call{Call: _e.mock.On("GetPhoneNumber")}
+//   - ctx context.Context
+func (_e *MockIWhatsappRepo_Expecter) GetPhoneNumber(ctx interface{}) *MockIWhatsappRepo_GetPhoneNumber_Call {
+	return &MockIWhatsappRepo_GetPhoneNumber_Call{Call: _e.mock.On("GetPhoneNumber", ctx)}
 }
 
-func (_c *MockIWhatsappRepo_GetPhoneNumber_Call) Run(run func()) *MockIWhatsappRepo_GetPhoneNumber_Call {
+func (_c *MockIWhatsappRepo_GetPhoneNumber_Call) Run(run func(ctx context.Context)) *MockIWhatsappRepo_GetPhoneNumber_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
@@ -176,22 +473,22 @@ func (_c *MockIWhatsappRepo_GetPhoneNumber_Call) Return(s string) *MockIWhatsapp
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_GetPhoneNumber_Call) RunAndReturn(run func() string) *MockIWhatsappRepo_GetPhoneNumber_Call {
+func (_c *MockIWhatsappRepo_GetPhoneNumber_Call) RunAndReturn(run func(ctx context.Context) string) *MockIWhatsappRepo_GetPhoneNumber_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetSelfLID provides a mock function for the type MockIWhatsappRepo
-func (_mock *MockIWhatsappRepo) GetSelfLID() *dto.WhatsappJID {
-	ret := _mock.Called()
+func (_mock *MockIWhatsappRepo) GetSelfLID(ctx context.Context) *dto.WhatsappJID {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetSelfLID")
 	}
 
 	var r0 *dto.WhatsappJID
-	if returnFunc, ok := ret.Get(0).(func() *dto.WhatsappJID); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *dto.WhatsappJID); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dto.WhatsappJID)
@@ -205,231 +502,820 @@ type MockIWhatsappRepo_GetSelfLID_Call struct {
 	*mock.Call
 }
 
-// GetSelfLID is a helper method to define mock.On call
-func (_e *MockIWhatsappRepo_Expecter) GetSelfLID() *MockIWhatsappRepo_GetSelfLID_Call {
-	return &MockIWhatsappRepo_GetSelfLID_Call{Call: _e.mock.On("GetSelfLID")}
+// GetSelfLID is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIWhatsappRepo_Expecter) GetSelfLID(ctx interface{}) *MockIWhatsappRepo_GetSelfLID_Call {
+	return &MockIWhatsappRepo_GetSelfLID_Call{Call: _e.mock.On("GetSelfLID", ctx)}
+}
+
+func (_c *MockIWhatsappRepo_GetSelfLID_Call) Run(run func(ctx context.Context)) *MockIWhatsappRepo_GetSelfLID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetSelfLID_Call) Return(whatsappJID *dto.WhatsappJID) *MockIWhatsappRepo_GetSelfLID_Call {
+	_c.Call.Return(whatsappJID)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetSelfLID_Call) RunAndReturn(run func(ctx context.Context) *dto.WhatsappJID) *MockIWhatsappRepo_GetSelfLID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStoredDeviceJIDs provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) GetStoredDeviceJIDs(ctx context.Context) ([]string, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStoredDeviceJIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIWhatsappRepo_GetStoredDeviceJIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStoredDeviceJIDs'
+type MockIWhatsappRepo_GetStoredDeviceJIDs_Call struct {
+	*mock.Call
+}
+
+// GetStoredDeviceJIDs is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIWhatsappRepo_Expecter) GetStoredDeviceJIDs(ctx interface{}) *MockIWhatsappRepo_GetStoredDeviceJIDs_Call {
+	return &MockIWhatsappRepo_GetStoredDeviceJIDs_Call{Call: _e.mock.On("GetStoredDeviceJIDs", ctx)}
+}
+
+func (_c *MockIWhatsappRepo_GetStoredDeviceJIDs_Call) Run(run func(ctx context.Context)) *MockIWhatsappRepo_GetStoredDeviceJIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetStoredDeviceJIDs_Call) Return(ss []string, err error) *MockIWhatsappRepo_GetStoredDeviceJIDs_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetStoredDeviceJIDs_Call) RunAndReturn(run func(ctx context.Context) ([]string, error)) *MockIWhatsappRepo_GetStoredDeviceJIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWhitelistedGroupJIDs provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) GetWhitelistedGroupJIDs(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappWhitelistedGroup, error) {
+	ret := _mock.Called(ctx, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWhitelistedGroupJIDs")
+	}
+
+	var r0 []*entity.WhatsappWhitelistedGroup
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *gorm.DB) ([]*entity.WhatsappWhitelistedGroup, error)); ok {
+		return returnFunc(ctx, tx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *gorm.DB) []*entity.WhatsappWhitelistedGroup); ok {
+		r0 = returnFunc(ctx, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.WhatsappWhitelistedGroup)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *gorm.DB) error); ok {
+		r1 = returnFunc(ctx, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWhitelistedGroupJIDs'
+type MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call struct {
+	*mock.Call
+}
+
+// GetWhitelistedGroupJIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx *gorm.DB
+func (_e *MockIWhatsappRepo_Expecter) GetWhitelistedGroupJIDs(ctx interface{}, tx interface{}) *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call {
+	return &MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call{Call: _e.mock.On("GetWhitelistedGroupJIDs", ctx, tx)}
+}
+
+func (_c *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call) Run(run func(ctx context.Context, tx *gorm.DB)) *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *gorm.DB
+		if args[1] != nil {
+			arg1 = args[1].(*gorm.DB)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call) Return(whatsappWhitelistedGroups []*entity.WhatsappWhitelistedGroup, err error) *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call {
+	_c.Call.Return(whatsappWhitelistedGroups, err)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call) RunAndReturn(run func(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappWhitelistedGroup, error)) *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWhitelistedUsers provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) GetWhitelistedUsers(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappWhitelistedUser, error) {
+	ret := _mock.Called(ctx, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWhitelistedUsers")
+	}
+
+	var r0 []*entity.WhatsappWhitelistedUser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *gorm.DB) ([]*entity.WhatsappWhitelistedUser, error)); ok {
+		return returnFunc(ctx, tx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *gorm.DB) []*entity.WhatsappWhitelistedUser); ok {
+		r0 = returnFunc(ctx, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.WhatsappWhitelistedUser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *gorm.DB) error); ok {
+		r1 = returnFunc(ctx, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIWhatsappRepo_GetWhitelistedUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWhitelistedUsers'
+type MockIWhatsappRepo_GetWhitelistedUsers_Call struct {
+	*mock.Call
+}
+
+// GetWhitelistedUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx *gorm.DB
+func (_e *MockIWhatsappRepo_Expecter) GetWhitelistedUsers(ctx interface{}, tx interface{}) *MockIWhatsappRepo_GetWhitelistedUsers_Call {
+	return &MockIWhatsappRepo_GetWhitelistedUsers_Call{Call: _e.mock.On("GetWhitelistedUsers", ctx, tx)}
+}
+
+func (_c *MockIWhatsappRepo_GetWhitelistedUsers_Call) Run(run func(ctx context.Context, tx *gorm.DB)) *MockIWhatsappRepo_GetWhitelistedUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *gorm.DB
+		if args[1] != nil {
+			arg1 = args[1].(*gorm.DB)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetWhitelistedUsers_Call) Return(whatsappWhitelistedUsers []*entity.WhatsappWhitelistedUser, err error) *MockIWhatsappRepo_GetWhitelistedUsers_Call {
+	_c.Call.Return(whatsappWhitelistedUsers, err)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_GetWhitelistedUsers_Call) RunAndReturn(run func(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappWhitelistedUser, error)) *MockIWhatsappRepo_GetWhitelistedUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsLoggedIn provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) IsLoggedIn(ctx context.Context) bool {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsLoggedIn")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockIWhatsappRepo_IsLoggedIn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsLoggedIn'
+type MockIWhatsappRepo_IsLoggedIn_Call struct {
+	*mock.Call
+}
+
+// IsLoggedIn is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIWhatsappRepo_Expecter) IsLoggedIn(ctx interface{}) *MockIWhatsappRepo_IsLoggedIn_Call {
+	return &MockIWhatsappRepo_IsLoggedIn_Call{Call: _e.mock.On("IsLoggedIn", ctx)}
+}
+
+func (_c *MockIWhatsappRepo_IsLoggedIn_Call) Run(run func(ctx context.Context)) *MockIWhatsappRepo_IsLoggedIn_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_IsLoggedIn_Call) Return(b bool) *MockIWhatsappRepo_IsLoggedIn_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_IsLoggedIn_Call) RunAndReturn(run func(ctx context.Context) bool) *MockIWhatsappRepo_IsLoggedIn_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsSyncComplete provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) IsSyncComplete(ctx context.Context) bool {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsSyncComplete")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockIWhatsappRepo_IsSyncComplete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsSyncComplete'
+type MockIWhatsappRepo_IsSyncComplete_Call struct {
+	*mock.Call
+}
+
+// IsSyncComplete is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIWhatsappRepo_Expecter) IsSyncComplete(ctx interface{}) *MockIWhatsappRepo_IsSyncComplete_Call {
+	return &MockIWhatsappRepo_IsSyncComplete_Call{Call: _e.mock.On("IsSyncComplete", ctx)}
+}
+
+func (_c *MockIWhatsappRepo_IsSyncComplete_Call) Run(run func(ctx context.Context)) *MockIWhatsappRepo_IsSyncComplete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_IsSyncComplete_Call) Return(b bool) *MockIWhatsappRepo_IsSyncComplete_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_IsSyncComplete_Call) RunAndReturn(run func(ctx context.Context) bool) *MockIWhatsappRepo_IsSyncComplete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// JoinGroupWithLink provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) JoinGroupWithLink(ctx context.Context, link string) (dto.WhatsappJID, error) {
+	ret := _mock.Called(ctx, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JoinGroupWithLink")
+	}
+
+	var r0 dto.WhatsappJID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (dto.WhatsappJID, error)); ok {
+		return returnFunc(ctx, link)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) dto.WhatsappJID); ok {
+		r0 = returnFunc(ctx, link)
+	} else {
+		r0 = ret.Get(0).(dto.WhatsappJID)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, link)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIWhatsappRepo_JoinGroupWithLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JoinGroupWithLink'
+type MockIWhatsappRepo_JoinGroupWithLink_Call struct {
+	*mock.Call
+}
+
+// JoinGroupWithLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - link string
+func (_e *MockIWhatsappRepo_Expecter) JoinGroupWithLink(ctx interface{}, link interface{}) *MockIWhatsappRepo_JoinGroupWithLink_Call {
+	return &MockIWhatsappRepo_JoinGroupWithLink_Call{Call: _e.mock.On("JoinGroupWithLink", ctx, link)}
+}
+
+func (_c *MockIWhatsappRepo_JoinGroupWithLink_Call) Run(run func(ctx context.Context, link string)) *MockIWhatsappRepo_JoinGroupWithLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_JoinGroupWithLink_Call) Return(whatsappJID dto.WhatsappJID, err error) *MockIWhatsappRepo_JoinGroupWithLink_Call {
+	_c.Call.Return(whatsappJID, err)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_JoinGroupWithLink_Call) RunAndReturn(run func(ctx context.Context, link string) (dto.WhatsappJID, error)) *MockIWhatsappRepo_JoinGroupWithLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Login provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) Login(ctx context.Context) (<-chan whatsmeow.QRChannelItem, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Login")
+	}
+
+	var r0 <-chan whatsmeow.QRChannelItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (<-chan whatsmeow.QRChannelItem, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) <-chan whatsmeow.QRChannelItem); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan whatsmeow.QRChannelItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIWhatsappRepo_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
+type MockIWhatsappRepo_Login_Call struct {
+	*mock.Call
+}
+
+// Login is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIWhatsappRepo_Expecter) Login(ctx interface{}) *MockIWhatsappRepo_Login_Call {
+	return &MockIWhatsappRepo_Login_Call{Call: _e.mock.On("Login", ctx)}
+}
+
+func (_c *MockIWhatsappRepo_Login_Call) Run(run func(ctx context.Context)) *MockIWhatsappRepo_Login_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_Login_Call) Return(qRChannelItemCh <-chan whatsmeow.QRChannelItem, err error) *MockIWhatsappRepo_Login_Call {
+	_c.Call.Return(qRChannelItemCh, err)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_Login_Call) RunAndReturn(run func(ctx context.Context) (<-chan whatsmeow.QRChannelItem, error)) *MockIWhatsappRepo_Login_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginWithExistingSession provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) LoginWithExistingSession(ctx context.Context) (bool, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginWithExistingSession")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIWhatsappRepo_LoginWithExistingSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginWithExistingSession'
+type MockIWhatsappRepo_LoginWithExistingSession_Call struct {
+	*mock.Call
+}
+
+// LoginWithExistingSession is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIWhatsappRepo_Expecter) LoginWithExistingSession(ctx interface{}) *MockIWhatsappRepo_LoginWithExistingSession_Call {
+	return &MockIWhatsappRepo_LoginWithExistingSession_Call{Call: _e.mock.On("LoginWithExistingSession", ctx)}
+}
+
+func (_c *MockIWhatsappRepo_LoginWithExistingSession_Call) Run(run func(ctx context.Context)) *MockIWhatsappRepo_LoginWithExistingSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_LoginWithExistingSession_Call) Return(b bool, err error) *MockIWhatsappRepo_LoginWithExistingSession_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_LoginWithExistingSession_Call) RunAndReturn(run func(ctx context.Context) (bool, error)) *MockIWhatsappRepo_LoginWithExistingSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LoginWithNumber provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) LoginWithNumber(ctx context.Context, phone string) (string, <-chan whatsmeow.QRChannelItem, error) {
+	ret := _mock.Called(ctx, phone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginWithNumber")
+	}
+
+	var r0 string
+	var r1 <-chan whatsmeow.QRChannelItem
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, <-chan whatsmeow.QRChannelItem, error)); ok {
+		return returnFunc(ctx, phone)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, phone)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) <-chan whatsmeow.QRChannelItem); ok {
+		r1 = returnFunc(ctx, phone)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(<-chan whatsmeow.QRChannelItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, phone)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockIWhatsappRepo_LoginWithNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LoginWithNumber'
+type MockIWhatsappRepo_LoginWithNumber_Call struct {
+	*mock.Call
+}
+
+// LoginWithNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - phone string
+func (_e *MockIWhatsappRepo_Expecter) LoginWithNumber(ctx interface{}, phone interface{}) *MockIWhatsappRepo_LoginWithNumber_Call {
+	return &MockIWhatsappRepo_LoginWithNumber_Call{Call: _e.mock.On("LoginWithNumber", ctx, phone)}
+}
+
+func (_c *MockIWhatsappRepo_LoginWithNumber_Call) Run(run func(ctx context.Context, phone string)) *MockIWhatsappRepo_LoginWithNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_LoginWithNumber_Call) Return(s string, qRChannelItemCh <-chan whatsmeow.QRChannelItem, err error) *MockIWhatsappRepo_LoginWithNumber_Call {
+	_c.Call.Return(s, qRChannelItemCh, err)
+	return _c
+}
+
+func (_c *MockIWhatsappRepo_LoginWithNumber_Call) RunAndReturn(run func(ctx context.Context, phone string) (string, <-chan whatsmeow.QRChannelItem, error)) *MockIWhatsappRepo_LoginWithNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Logout provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) Logout(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIWhatsappRepo_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
+type MockIWhatsappRepo_Logout_Call struct {
+	*mock.Call
+}
+
+// Logout is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIWhatsappRepo_Expecter) Logout(ctx interface{}) *MockIWhatsappRepo_Logout_Call {
+	return &MockIWhatsappRepo_Logout_Call{Call: _e.mock.On("Logout", ctx)}
 }
 
-func (_c *MockIWhatsappRepo_GetSelfLID_Call) Run(run func()) *MockIWhatsappRepo_GetSelfLID_Call {
+func (_c *MockIWhatsappRepo_Logout_Call) Run(run func(ctx context.Context)) *MockIWhatsappRepo_Logout_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_GetSelfLID_Call) Return(whatsappJID *dto.WhatsappJID) *MockIWhatsappRepo_GetSelfLID_Call {
-	_c.Call.Return(whatsappJID)
+func (_c *MockIWhatsappRepo_Logout_Call) Return(err error) *MockIWhatsappRepo_Logout_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_GetSelfLID_Call) RunAndReturn(run func() *dto.WhatsappJID) *MockIWhatsappRepo_GetSelfLID_Call {
+func (_c *MockIWhatsappRepo_Logout_Call) RunAndReturn(run func(ctx context.Context) error) *MockIWhatsappRepo_Logout_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWhitelistedGroupJIDs provides a mock function for the type MockIWhatsappRepo
-func (_mock *MockIWhatsappRepo) GetWhitelistedGroupJIDs(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappWhitelistedGroup, error) {
-	ret := _mock.Called(ctx, tx)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetWhitelistedGroupJIDs")
-	}
-
-	var r0 []*entity.WhatsappWhitelistedGroup
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *gorm.DB) ([]*entity.WhatsappWhitelistedGroup, error)); ok {
-		return returnFunc(ctx, tx)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *gorm.DB) []*entity.WhatsappWhitelistedGroup); ok {
-		r0 = returnFunc(ctx, tx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.WhatsappWhitelistedGroup)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *gorm.DB) error); ok {
-		r1 = returnFunc(ctx, tx)
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
+// OnConnectionEvent provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) OnConnectionEvent(f func(deviceID uint, evt dto.WhatsappConnEvent)) {
+	_mock.Called(f)
+	return
 }
 
-// MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWhitelistedGroupJIDs'
-type MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call struct {
+// MockIWhatsappRepo_OnConnectionEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OnConnectionEvent'
+type MockIWhatsappRepo_OnConnectionEvent_Call struct {
 	*mock.Call
 }
 
-// GetWhitelistedGroupJIDs is a helper method to define mock.On call
-//   - ctx context.Context
-//   - tx *gorm.DB
-func (_e *MockIWhatsappRepo_Expecter) GetWhitelistedGroupJIDs(ctx interface{}, tx interface{}) *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call {
-	return &MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call{Call: _e.mock.On("GetWhitelistedGroupJIDs", ctx, tx)}
+// OnConnectionEvent is a helper method to define mock.On call
+//   - f func(deviceID uint, evt dto.WhatsappConnEvent)
+func (_e *MockIWhatsappRepo_Expecter) OnConnectionEvent(f interface{}) *MockIWhatsappRepo_OnConnectionEvent_Call {
+	return &MockIWhatsappRepo_OnConnectionEvent_Call{Call: _e.mock.On("OnConnectionEvent", f)}
 }
 
-func (_c *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call) Run(run func(ctx context.Context, tx *gorm.DB)) *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call {
+func (_c *MockIWhatsappRepo_OnConnectionEvent_Call) Run(run func(f func(deviceID uint, evt dto.WhatsappConnEvent))) *MockIWhatsappRepo_OnConnectionEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
+		var arg0 func(deviceID uint, evt dto.WhatsappConnEvent)
 		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 *gorm.DB
-		if args[1] != nil {
-			arg1 = args[1].(*gorm.DB)
+			arg0 = args[0].(func(deviceID uint, evt dto.WhatsappConnEvent))
 		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call) Return(whatsappWhitelistedGroups []*entity.WhatsappWhitelistedGroup, err error) *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call {
-	_c.Call.Return(whatsappWhitelistedGroups, err)
+func (_c *MockIWhatsappRepo_OnConnectionEvent_Call) Return() *MockIWhatsappRepo_OnConnectionEvent_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call) RunAndReturn(run func(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappWhitelistedGroup, error)) *MockIWhatsappRepo_GetWhitelistedGroupJIDs_Call {
-	_c.Call.Return(run)
+func (_c *MockIWhatsappRepo_OnConnectionEvent_Call) RunAndReturn(run func(f func(deviceID uint, evt dto.WhatsappConnEvent))) *MockIWhatsappRepo_OnConnectionEvent_Call {
+	_c.Run(run)
 	return _c
 }
 
-// IsLoggedIn provides a mock function for the type MockIWhatsappRepo
-func (_mock *MockIWhatsappRepo) IsLoggedIn() bool {
-	ret := _mock.Called()
-
-	if len(ret) == 0 {
-		panic("no return value specified for IsLoggedIn")
-	}
-
-	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func() bool); ok {
-		r0 = returnFunc()
-	} else {
-		r0 = ret.Get(0).(bool)
-	}
-	return r0
+// OnDeviceJID provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) OnDeviceJID(f func(deviceID uint, jid string)) {
+	_mock.Called(f)
+	return
 }
 
-// MockIWhatsappRepo_IsLoggedIn_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsLoggedIn'
-type MockIWhatsappRepo_IsLoggedIn_Call struct {
+// MockIWhatsappRepo_OnDeviceJID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OnDeviceJID'
+type MockIWhatsappRepo_OnDeviceJID_Call struct {
 	*mock.Call
 }
 
-// IsLoggedIn is a helper method to define mock.On call
-func (_e *MockIWhatsappRepo_Expecter) IsLoggedIn() *MockIWhatsappRepo_IsLoggedIn_Call {
-	return &MockIWhatsappRepo_IsLoggedIn_Call{Call: _e.mock.On("IsLoggedIn")}
+// OnDeviceJID is a helper method to define mock.On call
+//   - f func(deviceID uint, jid string)
+func (_e *MockIWhatsappRepo_Expecter) OnDeviceJID(f interface{}) *MockIWhatsappRepo_OnDeviceJID_Call {
+	return &MockIWhatsappRepo_OnDeviceJID_Call{Call: _e.mock.On("OnDeviceJID", f)}
 }
 
-func (_c *MockIWhatsappRepo_IsLoggedIn_Call) Run(run func()) *MockIWhatsappRepo_IsLoggedIn_Call {
+func (_c *MockIWhatsappRepo_OnDeviceJID_Call) Run(run func(f func(deviceID uint, jid string))) *MockIWhatsappRepo_OnDeviceJID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 func(deviceID uint, jid string)
+		if args[0] != nil {
+			arg0 = args[0].(func(deviceID uint, jid string))
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_IsLoggedIn_Call) Return(b bool) *MockIWhatsappRepo_IsLoggedIn_Call {
-	_c.Call.Return(b)
+func (_c *MockIWhatsappRepo_OnDeviceJID_Call) Return() *MockIWhatsappRepo_OnDeviceJID_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_IsLoggedIn_Call) RunAndReturn(run func() bool) *MockIWhatsappRepo_IsLoggedIn_Call {
-	_c.Call.Return(run)
+func (_c *MockIWhatsappRepo_OnDeviceJID_Call) RunAndReturn(run func(f func(deviceID uint, jid string))) *MockIWhatsappRepo_OnDeviceJID_Call {
+	_c.Run(run)
 	return _c
 }
 
-// IsSyncComplete provides a mock function for the type MockIWhatsappRepo
-func (_mock *MockIWhatsappRepo) IsSyncComplete(ctx context.Context) bool {
-	ret := _mock.Called(ctx)
+// OpenDevice provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) OpenDevice(ctx context.Context, deviceID uint, jid string) error {
+	ret := _mock.Called(ctx, deviceID, jid)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IsSyncComplete")
+		panic("no return value specified for OpenDevice")
 	}
 
-	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(context.Context) bool); ok {
-		r0 = returnFunc(ctx)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = returnFunc(ctx, deviceID, jid)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockIWhatsappRepo_IsSyncComplete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsSyncComplete'
-type MockIWhatsappRepo_IsSyncComplete_Call struct {
+// MockIWhatsappRepo_OpenDevice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenDevice'
+type MockIWhatsappRepo_OpenDevice_Call struct {
 	*mock.Call
 }
 
-// IsSyncComplete is a helper method to define mock.On call
+// OpenDevice is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockIWhatsappRepo_Expecter) IsSyncComplete(ctx interface{}) *MockIWhatsappRepo_IsSyncComplete_Call {
-	return &MockIWhatsappRepo_IsSyncComplete_Call{Call: _e.mock.On("IsSyncComplete", ctx)}
+//   - deviceID uint
+//   - jid string
+func (_e *MockIWhatsappRepo_Expecter) OpenDevice(ctx interface{}, deviceID interface{}, jid interface{}) *MockIWhatsappRepo_OpenDevice_Call {
+	return &MockIWhatsappRepo_OpenDevice_Call{Call: _e.mock.On("OpenDevice", ctx, deviceID, jid)}
 }
 
-func (_c *MockIWhatsappRepo_IsSyncComplete_Call) Run(run func(ctx context.Context)) *MockIWhatsappRepo_IsSyncComplete_Call {
+func (_c *MockIWhatsappRepo_OpenDevice_Call) Run(run func(ctx context.Context, deviceID uint, jid string)) *MockIWhatsappRepo_OpenDevice_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 uint
+		if args[1] != nil {
+			arg1 = args[1].(uint)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_IsSyncComplete_Call) Return(b bool) *MockIWhatsappRepo_IsSyncComplete_Call {
-	_c.Call.Return(b)
+func (_c *MockIWhatsappRepo_OpenDevice_Call) Return(err error) *MockIWhatsappRepo_OpenDevice_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_IsSyncComplete_Call) RunAndReturn(run func(ctx context.Context) bool) *MockIWhatsappRepo_IsSyncComplete_Call {
+func (_c *MockIWhatsappRepo_OpenDevice_Call) RunAndReturn(run func(ctx context.Context, deviceID uint, jid string) error) *MockIWhatsappRepo_OpenDevice_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Login provides a mock function for the type MockIWhatsappRepo
-func (_mock *MockIWhatsappRepo) Login(ctx context.Context) (<-chan whatsmeow.QRChannelItem, error) {
+// Reconnect provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) Reconnect(ctx context.Context) error {
 	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Login")
+		panic("no return value specified for Reconnect")
 	}
 
-	var r0 <-chan whatsmeow.QRChannelItem
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (<-chan whatsmeow.QRChannelItem, error)); ok {
-		return returnFunc(ctx)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) <-chan whatsmeow.QRChannelItem); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
 		r0 = returnFunc(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(<-chan whatsmeow.QRChannelItem)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockIWhatsappRepo_Login_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Login'
-type MockIWhatsappRepo_Login_Call struct {
+// MockIWhatsappRepo_Reconnect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reconnect'
+type MockIWhatsappRepo_Reconnect_Call struct {
 	*mock.Call
 }
 
-// Login is a helper method to define mock.On call
+// Reconnect is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockIWhatsappRepo_Expecter) Login(ctx interface{}) *MockIWhatsappRepo_Login_Call {
-	return &MockIWhatsappRepo_Login_Call{Call: _e.mock.On("Login", ctx)}
+func (_e *MockIWhatsappRepo_Expecter) Reconnect(ctx interface{}) *MockIWhatsappRepo_Reconnect_Call {
+	return &MockIWhatsappRepo_Reconnect_Call{Call: _e.mock.On("Reconnect", ctx)}
 }
 
-func (_c *MockIWhatsappRepo_Login_Call) Run(run func(ctx context.Context)) *MockIWhatsappRepo_Login_Call {
+func (_c *MockIWhatsappRepo_Reconnect_Call) Run(run func(ctx context.Context)) *MockIWhatsappRepo_Reconnect_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -442,200 +1328,236 @@ func (_c *MockIWhatsappRepo_Login_Call) Run(run func(ctx context.Context)) *Mock
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_Login_Call) Return(qRChannelItemCh <-chan whatsmeow.QRChannelItem, err error) *MockIWhatsappRepo_Login_Call {
-	_c.Call.Return(qRChannelItemCh, err)
+func (_c *MockIWhatsappRepo_Reconnect_Call) Return(err error) *MockIWhatsappRepo_Reconnect_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_Login_Call) RunAndReturn(run func(ctx context.Context) (<-chan whatsmeow.QRChannelItem, error)) *MockIWhatsappRepo_Login_Call {
+func (_c *MockIWhatsappRepo_Reconnect_Call) RunAndReturn(run func(ctx context.Context) error) *MockIWhatsappRepo_Reconnect_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Logout provides a mock function for the type MockIWhatsappRepo
-func (_mock *MockIWhatsappRepo) Logout(ctx context.Context) error {
-	ret := _mock.Called(ctx)
+// UnwhitelistGroup provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) UnwhitelistGroup(ctx context.Context, tx *gorm.DB, req *dto.UnwhitelistWhatsappGroupReq) error {
+	ret := _mock.Called(ctx, tx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Logout")
+		panic("no return value specified for UnwhitelistGroup")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *gorm.DB, *dto.UnwhitelistWhatsappGroupReq) error); ok {
+		r0 = returnFunc(ctx, tx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockIWhatsappRepo_Logout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Logout'
-type MockIWhatsappRepo_Logout_Call struct {
+// MockIWhatsappRepo_UnwhitelistGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnwhitelistGroup'
+type MockIWhatsappRepo_UnwhitelistGroup_Call struct {
 	*mock.Call
 }
 
-// Logout is a helper method to define mock.On call
+// UnwhitelistGroup is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockIWhatsappRepo_Expecter) Logout(ctx interface{}) *MockIWhatsappRepo_Logout_Call {
-	return &MockIWhatsappRepo_Logout_Call{Call: _e.mock.On("Logout", ctx)}
+//   - tx *gorm.DB
+//   - req *dto.UnwhitelistWhatsappGroupReq
+func (_e *MockIWhatsappRepo_Expecter) UnwhitelistGroup(ctx interface{}, tx interface{}, req interface{}) *MockIWhatsappRepo_UnwhitelistGroup_Call {
+	return &MockIWhatsappRepo_UnwhitelistGroup_Call{Call: _e.mock.On("UnwhitelistGroup", ctx, tx, req)}
 }
 
-func (_c *MockIWhatsappRepo_Logout_Call) Run(run func(ctx context.Context)) *MockIWhatsappRepo_Logout_Call {
+func (_c *MockIWhatsappRepo_UnwhitelistGroup_Call) Run(run func(ctx context.Context, tx *gorm.DB, req *dto.UnwhitelistWhatsappGroupReq)) *MockIWhatsappRepo_UnwhitelistGroup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 *gorm.DB
+		if args[1] != nil {
+			arg1 = args[1].(*gorm.DB)
+		}
+		var arg2 *dto.UnwhitelistWhatsappGroupReq
+		if args[2] != nil {
+			arg2 = args[2].(*dto.UnwhitelistWhatsappGroupReq)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_Logout_Call) Return(err error) *MockIWhatsappRepo_Logout_Call {
+func (_c *MockIWhatsappRepo_UnwhitelistGroup_Call) Return(err error) *MockIWhatsappRepo_UnwhitelistGroup_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_Logout_Call) RunAndReturn(run func(ctx context.Context) error) *MockIWhatsappRepo_Logout_Call {
+func (_c *MockIWhatsappRepo_UnwhitelistGroup_Call) RunAndReturn(run func(ctx context.Context, tx *gorm.DB, req *dto.UnwhitelistWhatsappGroupReq) error) *MockIWhatsappRepo_UnwhitelistGroup_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RegisterEventHandler provides a mock function for the type MockIWhatsappRepo
-func (_mock *MockIWhatsappRepo) RegisterEventHandler(f func(any)) uint32 {
-	ret := _mock.Called(f)
+// UnwhitelistUser provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) UnwhitelistUser(ctx context.Context, tx *gorm.DB, req *dto.UnwhitelistWhatsappUserReq) error {
+	ret := _mock.Called(ctx, tx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RegisterEventHandler")
+		panic("no return value specified for UnwhitelistUser")
 	}
 
-	var r0 uint32
-	if returnFunc, ok := ret.Get(0).(func(func(any)) uint32); ok {
-		r0 = returnFunc(f)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *gorm.DB, *dto.UnwhitelistWhatsappUserReq) error); ok {
+		r0 = returnFunc(ctx, tx, req)
 	} else {
-		r0 = ret.Get(0).(uint32)
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockIWhatsappRepo_RegisterEventHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterEventHandler'
-type MockIWhatsappRepo_RegisterEventHandler_Call struct {
+// MockIWhatsappRepo_UnwhitelistUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnwhitelistUser'
+type MockIWhatsappRepo_UnwhitelistUser_Call struct {
 	*mock.Call
 }
 
-// RegisterEventHandler is a helper method to define mock.On call
-//   - f func(any)
-func (_e *MockIWhatsappRepo_Expecter) RegisterEventHandler(f interface{}) *MockIWhatsappRepo_RegisterEventHandler_Call {
-	return &MockIWhatsappRepo_RegisterEventHandler_Call{Call: _e.mock.On("RegisterEventHandler", f)}
+// UnwhitelistUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx *gorm.DB
+//   - req *dto.UnwhitelistWhatsappUserReq
+func (_e *MockIWhatsappRepo_Expecter) UnwhitelistUser(ctx interface{}, tx interface{}, req interface{}) *MockIWhatsappRepo_UnwhitelistUser_Call {
+	return &MockIWhatsappRepo_UnwhitelistUser_Call{Call: _e.mock.On("UnwhitelistUser", ctx, tx, req)}
 }
 
-func (_c *MockIWhatsappRepo_RegisterEventHandler_Call) Run(run func(f func(any))) *MockIWhatsappRepo_RegisterEventHandler_Call {
+func (_c *MockIWhatsappRepo_UnwhitelistUser_Call) Run(run func(ctx context.Context, tx *gorm.DB, req *dto.UnwhitelistWhatsappUserReq)) *MockIWhatsappRepo_UnwhitelistUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 func(any)
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(func(any))
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *gorm.DB
+		if args[1] != nil {
+			arg1 = args[1].(*gorm.DB)
+		}
+		var arg2 *dto.UnwhitelistWhatsappUserReq
+		if args[2] != nil {
+			arg2 = args[2].(*dto.UnwhitelistWhatsappUserReq)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_RegisterEventHandler_Call) Return(v uint32) *MockIWhatsappRepo_RegisterEventHandler_Call {
-	_c.Call.Return(v)
+func (_c *MockIWhatsappRepo_UnwhitelistUser_Call) Return(err error) *MockIWhatsappRepo_UnwhitelistUser_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_RegisterEventHandler_Call) RunAndReturn(run func(f func(any)) uint32) *MockIWhatsappRepo_RegisterEventHandler_Call {
+func (_c *MockIWhatsappRepo_UnwhitelistUser_Call) RunAndReturn(run func(ctx context.Context, tx *gorm.DB, req *dto.UnwhitelistWhatsappUserReq) error) *MockIWhatsappRepo_UnwhitelistUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UnregisterEventHandler provides a mock function for the type MockIWhatsappRepo
-func (_mock *MockIWhatsappRepo) UnregisterEventHandler(handlerID uint32) bool {
-	ret := _mock.Called(handlerID)
+// UpdateWhitelistedGroup provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) UpdateWhitelistedGroup(ctx context.Context, tx *gorm.DB, group *entity.WhatsappWhitelistedGroup) error {
+	ret := _mock.Called(ctx, tx, group)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UnregisterEventHandler")
+		panic("no return value specified for UpdateWhitelistedGroup")
 	}
 
-	var r0 bool
-	if returnFunc, ok := ret.Get(0).(func(uint32) bool); ok {
-		r0 = returnFunc(handlerID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *gorm.DB, *entity.WhatsappWhitelistedGroup) error); ok {
+		r0 = returnFunc(ctx, tx, group)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockIWhatsappRepo_UnregisterEventHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnregisterEventHandler'
-type MockIWhatsappRepo_UnregisterEventHandler_Call struct {
+// MockIWhatsappRepo_UpdateWhitelistedGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWhitelistedGroup'
+type MockIWhatsappRepo_UpdateWhitelistedGroup_Call struct {
 	*mock.Call
 }
 
-// UnregisterEventHandler is a helper method to define mock.On call
-//   - handlerID uint32
-func (_e *MockIWhatsappRepo_Expecter) UnregisterEventHandler(handlerID interface{}) *MockIWhatsappRepo_UnregisterEventHandler_Call {
-	return &MockIWhatsappRepo_UnregisterEventHandler_Call{Call: _e.mock.On("UnregisterEventHandler", handlerID)}
+// UpdateWhitelistedGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tx *gorm.DB
+//   - group *entity.WhatsappWhitelistedGroup
+func (_e *MockIWhatsappRepo_Expecter) UpdateWhitelistedGroup(ctx interface{}, tx interface{}, group interface{}) *MockIWhatsappRepo_UpdateWhitelistedGroup_Call {
+	return &MockIWhatsappRepo_UpdateWhitelistedGroup_Call{Call: _e.mock.On("UpdateWhitelistedGroup", ctx, tx, group)}
 }
 
-func (_c *MockIWhatsappRepo_UnregisterEventHandler_Call) Run(run func(handlerID uint32)) *MockIWhatsappRepo_UnregisterEventHandler_Call {
+func (_c *MockIWhatsappRepo_UpdateWhitelistedGroup_Call) Run(run func(ctx context.Context, tx *gorm.DB, group *entity.WhatsappWhitelistedGroup)) *MockIWhatsappRepo_UpdateWhitelistedGroup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 uint32
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(uint32)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *gorm.DB
+		if args[1] != nil {
+			arg1 = args[1].(*gorm.DB)
+		}
+		var arg2 *entity.WhatsappWhitelistedGroup
+		if args[2] != nil {
+			arg2 = args[2].(*entity.WhatsappWhitelistedGroup)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_UnregisterEventHandler_Call) Return(b bool) *MockIWhatsappRepo_UnregisterEventHandler_Call {
-	_c.Call.Return(b)
+func (_c *MockIWhatsappRepo_UpdateWhitelistedGroup_Call) Return(err error) *MockIWhatsappRepo_UpdateWhitelistedGroup_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_UnregisterEventHandler_Call) RunAndReturn(run func(handlerID uint32) bool) *MockIWhatsappRepo_UnregisterEventHandler_Call {
+func (_c *MockIWhatsappRepo_UpdateWhitelistedGroup_Call) RunAndReturn(run func(ctx context.Context, tx *gorm.DB, group *entity.WhatsappWhitelistedGroup) error) *MockIWhatsappRepo_UpdateWhitelistedGroup_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UnwhitelistGroup provides a mock function for the type MockIWhatsappRepo
-func (_mock *MockIWhatsappRepo) UnwhitelistGroup(ctx context.Context, tx *gorm.DB, req *dto.UnwhitelistWhatsappGroupReq) error {
-	ret := _mock.Called(ctx, tx, req)
+// WhitelistGroup provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) WhitelistGroup(ctx context.Context, tx *gorm.DB, group *entity.WhatsappWhitelistedGroup) error {
+	ret := _mock.Called(ctx, tx, group)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UnwhitelistGroup")
+		panic("no return value specified for WhitelistGroup")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *gorm.DB, *dto.UnwhitelistWhatsappGroupReq) error); ok {
-		r0 = returnFunc(ctx, tx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *gorm.DB, *entity.WhatsappWhitelistedGroup) error); ok {
+		r0 = returnFunc(ctx, tx, group)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockIWhatsappRepo_UnwhitelistGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnwhitelistGroup'
-type MockIWhatsappRepo_UnwhitelistGroup_Call struct {
+// MockIWhatsappRepo_WhitelistGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WhitelistGroup'
+type MockIWhatsappRepo_WhitelistGroup_Call struct {
 	*mock.Call
 }
 
-// UnwhitelistGroup is a helper method to define mock.On call
+// WhitelistGroup is a helper method to define mock.On call
 //   - ctx context.Context
 //   - tx *gorm.DB
-//   - req *dto.UnwhitelistWhatsappGroupReq
-func (_e *MockIWhatsappRepo_Expecter) UnwhitelistGroup(ctx interface{}, tx interface{}, req interface{}) *MockIWhatsappRepo_UnwhitelistGroup_Call {
-	return &MockIWhatsappRepo_UnwhitelistGroup_Call{Call: _e.mock.On("UnwhitelistGroup", ctx, tx, req)}
+//   - group *entity.WhatsappWhitelistedGroup
+func (_e *MockIWhatsappRepo_Expecter) WhitelistGroup(ctx interface{}, tx interface{}, group interface{}) *MockIWhatsappRepo_WhitelistGroup_Call {
+	return &MockIWhatsappRepo_WhitelistGroup_Call{Call: _e.mock.On("WhitelistGroup", ctx, tx, group)}
 }
 
-func (_c *MockIWhatsappRepo_UnwhitelistGroup_Call) Run(run func(ctx context.Context, tx *gorm.DB, req *dto.UnwhitelistWhatsappGroupReq)) *MockIWhatsappRepo_UnwhitelistGroup_Call {
+func (_c *MockIWhatsappRepo_WhitelistGroup_Call) Run(run func(ctx context.Context, tx *gorm.DB, group *entity.WhatsappWhitelistedGroup)) *MockIWhatsappRepo_WhitelistGroup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -645,9 +1567,9 @@ func (_c *MockIWhatsappRepo_UnwhitelistGroup_Call) Run(run func(ctx context.Cont
 		if args[1] != nil {
 			arg1 = args[1].(*gorm.DB)
 		}
-		var arg2 *dto.UnwhitelistWhatsappGroupReq
+		var arg2 *entity.WhatsappWhitelistedGroup
 		if args[2] != nil {
-			arg2 = args[2].(*dto.UnwhitelistWhatsappGroupReq)
+			arg2 = args[2].(*entity.WhatsappWhitelistedGroup)
 		}
 		run(
 			arg0,
@@ -658,26 +1580,26 @@ func (_c *MockIWhatsappRepo_UnwhitelistGroup_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_UnwhitelistGroup_Call) Return(err error) *MockIWhatsappRepo_UnwhitelistGroup_Call {
+func (_c *MockIWhatsappRepo_WhitelistGroup_Call) Return(err error) *MockIWhatsappRepo_WhitelistGroup_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_UnwhitelistGroup_Call) RunAndReturn(run func(ctx context.Context, tx *gorm.DB, req *dto.UnwhitelistWhatsappGroupReq) error) *MockIWhatsappRepo_UnwhitelistGroup_Call {
+func (_c *MockIWhatsappRepo_WhitelistGroup_Call) RunAndReturn(run func(ctx context.Context, tx *gorm.DB, group *entity.WhatsappWhitelistedGroup) error) *MockIWhatsappRepo_WhitelistGroup_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// WhitelistGroup provides a mock function for the type MockIWhatsappRepo
-func (_mock *MockIWhatsappRepo) WhitelistGroup(ctx context.Context, tx *gorm.DB, req *dto.WhitelistWhatsappGroupReq) error {
+// WhitelistUser provides a mock function for the type MockIWhatsappRepo
+func (_mock *MockIWhatsappRepo) WhitelistUser(ctx context.Context, tx *gorm.DB, req *dto.WhitelistWhatsappUserReq) error {
 	ret := _mock.Called(ctx, tx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for WhitelistGroup")
+		panic("no return value specified for WhitelistUser")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *gorm.DB, *dto.WhitelistWhatsappGroupReq) error); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *gorm.DB, *dto.WhitelistWhatsappUserReq) error); ok {
 		r0 = returnFunc(ctx, tx, req)
 	} else {
 		r0 = ret.Error(0)
@@ -685,20 +1607,20 @@ func (_mock *MockIWhatsappRepo) WhitelistGroup(ctx context.Context, tx *gorm.DB,
 	return r0
 }
 
-// MockIWhatsappRepo_WhitelistGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WhitelistGroup'
-type MockIWhatsappRepo_WhitelistGroup_Call struct {
+// MockIWhatsappRepo_WhitelistUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WhitelistUser'
+type MockIWhatsappRepo_WhitelistUser_Call struct {
 	*mock.Call
 }
 
-// WhitelistGroup is a helper method to define mock.On call
+// WhitelistUser is a helper method to define mock.On call
 //   - ctx context.Context
 //   - tx *gorm.DB
-//   - req *dto.WhitelistWhatsappGroupReq
-func (_e *MockIWhatsappRepo_Expecter) WhitelistGroup(ctx interface{}, tx interface{}, req interface{}) *MockIWhatsappRepo_WhitelistGroup_Call {
-	return &MockIWhatsappRepo_WhitelistGroup_Call{Call: _e.mock.On("WhitelistGroup", ctx, tx, req)}
+//   - req *dto.WhitelistWhatsappUserReq
+func (_e *MockIWhatsappRepo_Expecter) WhitelistUser(ctx interface{}, tx interface{}, req interface{}) *MockIWhatsappRepo_WhitelistUser_Call {
+	return &MockIWhatsappRepo_WhitelistUser_Call{Call: _e.mock.On("WhitelistUser", ctx, tx, req)}
 }
 
-func (_c *MockIWhatsappRepo_WhitelistGroup_Call) Run(run func(ctx context.Context, tx *gorm.DB, req *dto.WhitelistWhatsappGroupReq)) *MockIWhatsappRepo_WhitelistGroup_Call {
+func (_c *MockIWhatsappRepo_WhitelistUser_Call) Run(run func(ctx context.Context, tx *gorm.DB, req *dto.WhitelistWhatsappUserReq)) *MockIWhatsappRepo_WhitelistUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -708,9 +1630,9 @@ func (_c *MockIWhatsappRepo_WhitelistGroup_Call) Run(run func(ctx context.Contex
 		if args[1] != nil {
 			arg1 = args[1].(*gorm.DB)
 		}
-		var arg2 *dto.WhitelistWhatsappGroupReq
+		var arg2 *dto.WhitelistWhatsappUserReq
 		if args[2] != nil {
-			arg2 = args[2].(*dto.WhitelistWhatsappGroupReq)
+			arg2 = args[2].(*dto.WhitelistWhatsappUserReq)
 		}
 		run(
 			arg0,
@@ -721,12 +1643,12 @@ func (_c *MockIWhatsappRepo_WhitelistGroup_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_WhitelistGroup_Call) Return(err error) *MockIWhatsappRepo_WhitelistGroup_Call {
+func (_c *MockIWhatsappRepo_WhitelistUser_Call) Return(err error) *MockIWhatsappRepo_WhitelistUser_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockIWhatsappRepo_WhitelistGroup_Call) RunAndReturn(run func(ctx context.Context, tx *gorm.DB, req *dto.WhitelistWhatsappGroupReq) error) *MockIWhatsappRepo_WhitelistGroup_Call {
+func (_c *MockIWhatsappRepo_WhitelistUser_Call) RunAndReturn(run func(ctx context.Context, tx *gorm.DB, req *dto.WhitelistWhatsappUserReq) error) *MockIWhatsappRepo_WhitelistUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
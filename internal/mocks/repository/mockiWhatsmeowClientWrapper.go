@@ -11,6 +11,7 @@ import (
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
 )
 
 // newMockiWhatsmeowClientWrapper creates a new instance of mockiWhatsmeowClientWrapper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -40,6 +41,207 @@ func (_m *mockiWhatsmeowClientWrapper) EXPECT() *mockiWhatsmeowClientWrapper_Exp
 	return &mockiWhatsmeowClientWrapper_Expecter{mock: &_m.Mock}
 }
 
+// BuildEdit provides a mock function for the type mockiWhatsmeowClientWrapper
+func (_mock *mockiWhatsmeowClientWrapper) BuildEdit(chat types.JID, id types.MessageID, newContent *waE2E.Message) *waE2E.Message {
+	ret := _mock.Called(chat, id, newContent)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuildEdit")
+	}
+
+	var r0 *waE2E.Message
+	if returnFunc, ok := ret.Get(0).(func(types.JID, types.MessageID, *waE2E.Message) *waE2E.Message); ok {
+		r0 = returnFunc(chat, id, newContent)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*waE2E.Message)
+		}
+	}
+	return r0
+}
+
+// mockiWhatsmeowClientWrapper_BuildEdit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BuildEdit'
+type mockiWhatsmeowClientWrapper_BuildEdit_Call struct {
+	*mock.Call
+}
+
+// BuildEdit is a helper method to define mock.On call
+//   - chat types.JID
+//   - id types.MessageID
+//   - newContent *waE2E.Message
+func (_e *mockiWhatsmeowClientWrapper_Expecter) BuildEdit(chat interface{}, id interface{}, newContent interface{}) *mockiWhatsmeowClientWrapper_BuildEdit_Call {
+	return &mockiWhatsmeowClientWrapper_BuildEdit_Call{Call: _e.mock.On("BuildEdit", chat, id, newContent)}
+}
+
+func (_c *mockiWhatsmeowClientWrapper_BuildEdit_Call) Run(run func(chat types.JID, id types.MessageID, newContent *waE2E.Message)) *mockiWhatsmeowClientWrapper_BuildEdit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 types.JID
+		if args[0] != nil {
+			arg0 = args[0].(types.JID)
+		}
+		var arg1 types.MessageID
+		if args[1] != nil {
+			arg1 = args[1].(types.MessageID)
+		}
+		var arg2 *waE2E.Message
+		if args[2] != nil {
+			arg2 = args[2].(*waE2E.Message)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_BuildEdit_Call) Return(message *waE2E.Message) *mockiWhatsmeowClientWrapper_BuildEdit_Call {
+	_c.Call.Return(message)
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_BuildEdit_Call) RunAndReturn(run func(chat types.JID, id types.MessageID, newContent *waE2E.Message) *waE2E.Message) *mockiWhatsmeowClientWrapper_BuildEdit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BuildPollCreation provides a mock function for the type mockiWhatsmeowClientWrapper
+func (_mock *mockiWhatsmeowClientWrapper) BuildPollCreation(name string, optionNames []string, selectableOptionCount int) *waE2E.Message {
+	ret := _mock.Called(name, optionNames, selectableOptionCount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuildPollCreation")
+	}
+
+	var r0 *waE2E.Message
+	if returnFunc, ok := ret.Get(0).(func(string, []string, int) *waE2E.Message); ok {
+		r0 = returnFunc(name, optionNames, selectableOptionCount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*waE2E.Message)
+		}
+	}
+	return r0
+}
+
+// mockiWhatsmeowClientWrapper_BuildPollCreation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BuildPollCreation'
+type mockiWhatsmeowClientWrapper_BuildPollCreation_Call struct {
+	*mock.Call
+}
+
+// BuildPollCreation is a helper method to define mock.On call
+//   - name string
+//   - optionNames []string
+//   - selectableOptionCount int
+func (_e *mockiWhatsmeowClientWrapper_Expecter) BuildPollCreation(name interface{}, optionNames interface{}, selectableOptionCount interface{}) *mockiWhatsmeowClientWrapper_BuildPollCreation_Call {
+	return &mockiWhatsmeowClientWrapper_BuildPollCreation_Call{Call: _e.mock.On("BuildPollCreation", name, optionNames, selectableOptionCount)}
+}
+
+func (_c *mockiWhatsmeowClientWrapper_BuildPollCreation_Call) Run(run func(name string, optionNames []string, selectableOptionCount int)) *mockiWhatsmeowClientWrapper_BuildPollCreation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_BuildPollCreation_Call) Return(message *waE2E.Message) *mockiWhatsmeowClientWrapper_BuildPollCreation_Call {
+	_c.Call.Return(message)
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_BuildPollCreation_Call) RunAndReturn(run func(name string, optionNames []string, selectableOptionCount int) *waE2E.Message) *mockiWhatsmeowClientWrapper_BuildPollCreation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BuildReaction provides a mock function for the type mockiWhatsmeowClientWrapper
+func (_mock *mockiWhatsmeowClientWrapper) BuildReaction(chat types.JID, sender types.JID, id types.MessageID, reaction string) *waE2E.Message {
+	ret := _mock.Called(chat, sender, id, reaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuildReaction")
+	}
+
+	var r0 *waE2E.Message
+	if returnFunc, ok := ret.Get(0).(func(types.JID, types.JID, types.MessageID, string) *waE2E.Message); ok {
+		r0 = returnFunc(chat, sender, id, reaction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*waE2E.Message)
+		}
+	}
+	return r0
+}
+
+// mockiWhatsmeowClientWrapper_BuildReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BuildReaction'
+type mockiWhatsmeowClientWrapper_BuildReaction_Call struct {
+	*mock.Call
+}
+
+// BuildReaction is a helper method to define mock.On call
+//   - chat types.JID
+//   - sender types.JID
+//   - id types.MessageID
+//   - reaction string
+func (_e *mockiWhatsmeowClientWrapper_Expecter) BuildReaction(chat interface{}, sender interface{}, id interface{}, reaction interface{}) *mockiWhatsmeowClientWrapper_BuildReaction_Call {
+	return &mockiWhatsmeowClientWrapper_BuildReaction_Call{Call: _e.mock.On("BuildReaction", chat, sender, id, reaction)}
+}
+
+func (_c *mockiWhatsmeowClientWrapper_BuildReaction_Call) Run(run func(chat types.JID, sender types.JID, id types.MessageID, reaction string)) *mockiWhatsmeowClientWrapper_BuildReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 types.JID
+		if args[0] != nil {
+			arg0 = args[0].(types.JID)
+		}
+		var arg1 types.JID
+		if args[1] != nil {
+			arg1 = args[1].(types.JID)
+		}
+		var arg2 types.MessageID
+		if args[2] != nil {
+			arg2 = args[2].(types.MessageID)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_BuildReaction_Call) Return(message *waE2E.Message) *mockiWhatsmeowClientWrapper_BuildReaction_Call {
+	_c.Call.Return(message)
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_BuildReaction_Call) RunAndReturn(run func(chat types.JID, sender types.JID, id types.MessageID, reaction string) *waE2E.Message) *mockiWhatsmeowClientWrapper_BuildReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Connect provides a mock function for the type mockiWhatsmeowClientWrapper
 func (_mock *mockiWhatsmeowClientWrapper) Connect() error {
 	ret := _mock.Called()
@@ -84,6 +286,74 @@ func (_c *mockiWhatsmeowClientWrapper_Connect_Call) RunAndReturn(run func() erro
 	return _c
 }
 
+// DecryptPollVote provides a mock function for the type mockiWhatsmeowClientWrapper
+func (_mock *mockiWhatsmeowClientWrapper) DecryptPollVote(ctx context.Context, vote *events.Message) (*waE2E.PollVoteMessage, error) {
+	ret := _mock.Called(ctx, vote)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecryptPollVote")
+	}
+
+	var r0 *waE2E.PollVoteMessage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *events.Message) (*waE2E.PollVoteMessage, error)); ok {
+		return returnFunc(ctx, vote)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *events.Message) *waE2E.PollVoteMessage); ok {
+		r0 = returnFunc(ctx, vote)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*waE2E.PollVoteMessage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *events.Message) error); ok {
+		r1 = returnFunc(ctx, vote)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiWhatsmeowClientWrapper_DecryptPollVote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DecryptPollVote'
+type mockiWhatsmeowClientWrapper_DecryptPollVote_Call struct {
+	*mock.Call
+}
+
+// DecryptPollVote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - vote *events.Message
+func (_e *mockiWhatsmeowClientWrapper_Expecter) DecryptPollVote(ctx interface{}, vote interface{}) *mockiWhatsmeowClientWrapper_DecryptPollVote_Call {
+	return &mockiWhatsmeowClientWrapper_DecryptPollVote_Call{Call: _e.mock.On("DecryptPollVote", ctx, vote)}
+}
+
+func (_c *mockiWhatsmeowClientWrapper_DecryptPollVote_Call) Run(run func(ctx context.Context, vote *events.Message)) *mockiWhatsmeowClientWrapper_DecryptPollVote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *events.Message
+		if args[1] != nil {
+			arg1 = args[1].(*events.Message)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_DecryptPollVote_Call) Return(pollVoteMessage *waE2E.PollVoteMessage, err error) *mockiWhatsmeowClientWrapper_DecryptPollVote_Call {
+	_c.Call.Return(pollVoteMessage, err)
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_DecryptPollVote_Call) RunAndReturn(run func(ctx context.Context, vote *events.Message) (*waE2E.PollVoteMessage, error)) *mockiWhatsmeowClientWrapper_DecryptPollVote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Disconnect provides a mock function for the type mockiWhatsmeowClientWrapper
 func (_mock *mockiWhatsmeowClientWrapper) Disconnect() {
 	_mock.Called()
@@ -117,6 +387,208 @@ func (_c *mockiWhatsmeowClientWrapper_Disconnect_Call) RunAndReturn(run func())
 	return _c
 }
 
+// GetAltJID provides a mock function for the type mockiWhatsmeowClientWrapper
+func (_mock *mockiWhatsmeowClientWrapper) GetAltJID(ctx context.Context, jid types.JID) (types.JID, error) {
+	ret := _mock.Called(ctx, jid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAltJID")
+	}
+
+	var r0 types.JID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, types.JID) (types.JID, error)); ok {
+		return returnFunc(ctx, jid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, types.JID) types.JID); ok {
+		r0 = returnFunc(ctx, jid)
+	} else {
+		r0 = ret.Get(0).(types.JID)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, types.JID) error); ok {
+		r1 = returnFunc(ctx, jid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiWhatsmeowClientWrapper_GetAltJID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAltJID'
+type mockiWhatsmeowClientWrapper_GetAltJID_Call struct {
+	*mock.Call
+}
+
+// GetAltJID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jid types.JID
+func (_e *mockiWhatsmeowClientWrapper_Expecter) GetAltJID(ctx interface{}, jid interface{}) *mockiWhatsmeowClientWrapper_GetAltJID_Call {
+	return &mockiWhatsmeowClientWrapper_GetAltJID_Call{Call: _e.mock.On("GetAltJID", ctx, jid)}
+}
+
+func (_c *mockiWhatsmeowClientWrapper_GetAltJID_Call) Run(run func(ctx context.Context, jid types.JID)) *mockiWhatsmeowClientWrapper_GetAltJID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 types.JID
+		if args[1] != nil {
+			arg1 = args[1].(types.JID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_GetAltJID_Call) Return(jID types.JID, err error) *mockiWhatsmeowClientWrapper_GetAltJID_Call {
+	_c.Call.Return(jID, err)
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_GetAltJID_Call) RunAndReturn(run func(ctx context.Context, jid types.JID) (types.JID, error)) *mockiWhatsmeowClientWrapper_GetAltJID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroupInfo provides a mock function for the type mockiWhatsmeowClientWrapper
+func (_mock *mockiWhatsmeowClientWrapper) GetGroupInfo(ctx context.Context, jid types.JID) (*types.GroupInfo, error) {
+	ret := _mock.Called(ctx, jid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupInfo")
+	}
+
+	var r0 *types.GroupInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, types.JID) (*types.GroupInfo, error)); ok {
+		return returnFunc(ctx, jid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, types.JID) *types.GroupInfo); ok {
+		r0 = returnFunc(ctx, jid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GroupInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, types.JID) error); ok {
+		r1 = returnFunc(ctx, jid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiWhatsmeowClientWrapper_GetGroupInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroupInfo'
+type mockiWhatsmeowClientWrapper_GetGroupInfo_Call struct {
+	*mock.Call
+}
+
+// GetGroupInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jid types.JID
+func (_e *mockiWhatsmeowClientWrapper_Expecter) GetGroupInfo(ctx interface{}, jid interface{}) *mockiWhatsmeowClientWrapper_GetGroupInfo_Call {
+	return &mockiWhatsmeowClientWrapper_GetGroupInfo_Call{Call: _e.mock.On("GetGroupInfo", ctx, jid)}
+}
+
+func (_c *mockiWhatsmeowClientWrapper_GetGroupInfo_Call) Run(run func(ctx context.Context, jid types.JID)) *mockiWhatsmeowClientWrapper_GetGroupInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 types.JID
+		if args[1] != nil {
+			arg1 = args[1].(types.JID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_GetGroupInfo_Call) Return(groupInfo *types.GroupInfo, err error) *mockiWhatsmeowClientWrapper_GetGroupInfo_Call {
+	_c.Call.Return(groupInfo, err)
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_GetGroupInfo_Call) RunAndReturn(run func(ctx context.Context, jid types.JID) (*types.GroupInfo, error)) *mockiWhatsmeowClientWrapper_GetGroupInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroupInfoFromLink provides a mock function for the type mockiWhatsmeowClientWrapper
+func (_mock *mockiWhatsmeowClientWrapper) GetGroupInfoFromLink(ctx context.Context, code string) (*types.GroupInfo, error) {
+	ret := _mock.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupInfoFromLink")
+	}
+
+	var r0 *types.GroupInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*types.GroupInfo, error)); ok {
+		return returnFunc(ctx, code)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *types.GroupInfo); ok {
+		r0 = returnFunc(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GroupInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiWhatsmeowClientWrapper_GetGroupInfoFromLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroupInfoFromLink'
+type mockiWhatsmeowClientWrapper_GetGroupInfoFromLink_Call struct {
+	*mock.Call
+}
+
+// GetGroupInfoFromLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+func (_e *mockiWhatsmeowClientWrapper_Expecter) GetGroupInfoFromLink(ctx interface{}, code interface{}) *mockiWhatsmeowClientWrapper_GetGroupInfoFromLink_Call {
+	return &mockiWhatsmeowClientWrapper_GetGroupInfoFromLink_Call{Call: _e.mock.On("GetGroupInfoFromLink", ctx, code)}
+}
+
+func (_c *mockiWhatsmeowClientWrapper_GetGroupInfoFromLink_Call) Run(run func(ctx context.Context, code string)) *mockiWhatsmeowClientWrapper_GetGroupInfoFromLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_GetGroupInfoFromLink_Call) Return(groupInfo *types.GroupInfo, err error) *mockiWhatsmeowClientWrapper_GetGroupInfoFromLink_Call {
+	_c.Call.Return(groupInfo, err)
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_GetGroupInfoFromLink_Call) RunAndReturn(run func(ctx context.Context, code string) (*types.GroupInfo, error)) *mockiWhatsmeowClientWrapper_GetGroupInfoFromLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetJoinedGroups provides a mock function for the type mockiWhatsmeowClientWrapper
 func (_mock *mockiWhatsmeowClientWrapper) GetJoinedGroups(ctx context.Context) ([]*types.GroupInfo, error) {
 	ret := _mock.Called(ctx)
@@ -401,6 +873,50 @@ func (_c *mockiWhatsmeowClientWrapper_GetUserInfo_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// IsConnected provides a mock function for the type mockiWhatsmeowClientWrapper
+func (_mock *mockiWhatsmeowClientWrapper) IsConnected() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsConnected")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// mockiWhatsmeowClientWrapper_IsConnected_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsConnected'
+type mockiWhatsmeowClientWrapper_IsConnected_Call struct {
+	*mock.Call
+}
+
+// IsConnected is a helper method to define mock.On call
+func (_e *mockiWhatsmeowClientWrapper_Expecter) IsConnected() *mockiWhatsmeowClientWrapper_IsConnected_Call {
+	return &mockiWhatsmeowClientWrapper_IsConnected_Call{Call: _e.mock.On("IsConnected")}
+}
+
+func (_c *mockiWhatsmeowClientWrapper_IsConnected_Call) Run(run func()) *mockiWhatsmeowClientWrapper_IsConnected_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_IsConnected_Call) Return(b bool) *mockiWhatsmeowClientWrapper_IsConnected_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_IsConnected_Call) RunAndReturn(run func() bool) *mockiWhatsmeowClientWrapper_IsConnected_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsLoggedIn provides a mock function for the type mockiWhatsmeowClientWrapper
 func (_mock *mockiWhatsmeowClientWrapper) IsLoggedIn() bool {
 	ret := _mock.Called()
@@ -445,6 +961,72 @@ func (_c *mockiWhatsmeowClientWrapper_IsLoggedIn_Call) RunAndReturn(run func() b
 	return _c
 }
 
+// JoinGroupWithLink provides a mock function for the type mockiWhatsmeowClientWrapper
+func (_mock *mockiWhatsmeowClientWrapper) JoinGroupWithLink(ctx context.Context, code string) (types.JID, error) {
+	ret := _mock.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JoinGroupWithLink")
+	}
+
+	var r0 types.JID
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (types.JID, error)); ok {
+		return returnFunc(ctx, code)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) types.JID); ok {
+		r0 = returnFunc(ctx, code)
+	} else {
+		r0 = ret.Get(0).(types.JID)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiWhatsmeowClientWrapper_JoinGroupWithLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JoinGroupWithLink'
+type mockiWhatsmeowClientWrapper_JoinGroupWithLink_Call struct {
+	*mock.Call
+}
+
+// JoinGroupWithLink is a helper method to define mock.On call
+//   - ctx context.Context
+//   - code string
+func (_e *mockiWhatsmeowClientWrapper_Expecter) JoinGroupWithLink(ctx interface{}, code interface{}) *mockiWhatsmeowClientWrapper_JoinGroupWithLink_Call {
+	return &mockiWhatsmeowClientWrapper_JoinGroupWithLink_Call{Call: _e.mock.On("JoinGroupWithLink", ctx, code)}
+}
+
+func (_c *mockiWhatsmeowClientWrapper_JoinGroupWithLink_Call) Run(run func(ctx context.Context, code string)) *mockiWhatsmeowClientWrapper_JoinGroupWithLink_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_JoinGroupWithLink_Call) Return(jID types.JID, err error) *mockiWhatsmeowClientWrapper_JoinGroupWithLink_Call {
+	_c.Call.Return(jID, err)
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_JoinGroupWithLink_Call) RunAndReturn(run func(ctx context.Context, code string) (types.JID, error)) *mockiWhatsmeowClientWrapper_JoinGroupWithLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Logout provides a mock function for the type mockiWhatsmeowClientWrapper
 func (_mock *mockiWhatsmeowClientWrapper) Logout(ctx context.Context) error {
 	ret := _mock.Called(ctx)
@@ -762,3 +1344,75 @@ func (_c *mockiWhatsmeowClientWrapper_UnregisterEventHandler_Call) RunAndReturn(
 	_c.Call.Return(run)
 	return _c
 }
+
+// Upload provides a mock function for the type mockiWhatsmeowClientWrapper
+func (_mock *mockiWhatsmeowClientWrapper) Upload(ctx context.Context, plaintext []byte, mediaType whatsmeow.MediaType) (whatsmeow.UploadResponse, error) {
+	ret := _mock.Called(ctx, plaintext, mediaType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upload")
+	}
+
+	var r0 whatsmeow.UploadResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, whatsmeow.MediaType) (whatsmeow.UploadResponse, error)); ok {
+		return returnFunc(ctx, plaintext, mediaType)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []byte, whatsmeow.MediaType) whatsmeow.UploadResponse); ok {
+		r0 = returnFunc(ctx, plaintext, mediaType)
+	} else {
+		r0 = ret.Get(0).(whatsmeow.UploadResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []byte, whatsmeow.MediaType) error); ok {
+		r1 = returnFunc(ctx, plaintext, mediaType)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockiWhatsmeowClientWrapper_Upload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upload'
+type mockiWhatsmeowClientWrapper_Upload_Call struct {
+	*mock.Call
+}
+
+// Upload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - plaintext []byte
+//   - mediaType whatsmeow.MediaType
+func (_e *mockiWhatsmeowClientWrapper_Expecter) Upload(ctx interface{}, plaintext interface{}, mediaType interface{}) *mockiWhatsmeowClientWrapper_Upload_Call {
+	return &mockiWhatsmeowClientWrapper_Upload_Call{Call: _e.mock.On("Upload", ctx, plaintext, mediaType)}
+}
+
+func (_c *mockiWhatsmeowClientWrapper_Upload_Call) Run(run func(ctx context.Context, plaintext []byte, mediaType whatsmeow.MediaType)) *mockiWhatsmeowClientWrapper_Upload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []byte
+		if args[1] != nil {
+			arg1 = args[1].([]byte)
+		}
+		var arg2 whatsmeow.MediaType
+		if args[2] != nil {
+			arg2 = args[2].(whatsmeow.MediaType)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_Upload_Call) Return(uploadResponse whatsmeow.UploadResponse, err error) *mockiWhatsmeowClientWrapper_Upload_Call {
+	_c.Call.Return(uploadResponse, err)
+	return _c
+}
+
+func (_c *mockiWhatsmeowClientWrapper_Upload_Call) RunAndReturn(run func(ctx context.Context, plaintext []byte, mediaType whatsmeow.MediaType) (whatsmeow.UploadResponse, error)) *mockiWhatsmeowClientWrapper_Upload_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -171,16 +171,16 @@ func (_c *MockIAuthService_GetWhatsappGroups_Call) RunAndReturn(run func(ctx con
 }
 
 // GetWhatsappPhoneNumber provides a mock function for the type MockIAuthService
-func (_mock *MockIAuthService) GetWhatsappPhoneNumber() string {
-	ret := _mock.Called()
+func (_mock *MockIAuthService) GetWhatsappPhoneNumber(ctx context.Context) string {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetWhatsappPhoneNumber")
 	}
 
 	var r0 string
-	if returnFunc, ok := ret.Get(0).(func() string); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
@@ -193,13 +193,20 @@ type MockIAuthService_GetWhatsappPhoneNumber_Call struct {
 }
 
 // GetWhatsappPhoneNumber is a helper method to define mock.On call
-func (_e *MockIAuthService_Expecter) GetWhatsappPhoneNumber() *MockIAuthService_GetWhatsappPhoneNumber_Call {
-	return &MockIAuthService_GetWhatsappPhoneNumber_Call{Call: _e.mock.On("GetWhatsappPhoneNumber")}
+//   - ctx context.Context
+func (_e *MockIAuthService_Expecter) GetWhatsappPhoneNumber(ctx interface{}) *MockIAuthService_GetWhatsappPhoneNumber_Call {
+	return &MockIAuthService_GetWhatsappPhoneNumber_Call{Call: _e.mock.On("GetWhatsappPhoneNumber", ctx)}
 }
 
-func (_c *MockIAuthService_GetWhatsappPhoneNumber_Call) Run(run func()) *MockIAuthService_GetWhatsappPhoneNumber_Call {
+func (_c *MockIAuthService_GetWhatsappPhoneNumber_Call) Run(run func(ctx context.Context)) *MockIAuthService_GetWhatsappPhoneNumber_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
@@ -209,7 +216,7 @@ func (_c *MockIAuthService_GetWhatsappPhoneNumber_Call) Return(s string) *MockIA
 	return _c
 }
 
-func (_c *MockIAuthService_GetWhatsappPhoneNumber_Call) RunAndReturn(run func() string) *MockIAuthService_GetWhatsappPhoneNumber_Call {
+func (_c *MockIAuthService_GetWhatsappPhoneNumber_Call) RunAndReturn(run func(ctx context.Context) string) *MockIAuthService_GetWhatsappPhoneNumber_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -276,6 +283,68 @@ func (_c *MockIAuthService_GetWhatsappWhitelistedGroupJIDs_Call) RunAndReturn(ru
 	return _c
 }
 
+// GetWhatsappWhitelistedUsers provides a mock function for the type MockIAuthService
+func (_mock *MockIAuthService) GetWhatsappWhitelistedUsers(ctx context.Context) ([]*dto.WhatsappWhitelistedUser, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWhatsappWhitelistedUsers")
+	}
+
+	var r0 []*dto.WhatsappWhitelistedUser
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*dto.WhatsappWhitelistedUser, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*dto.WhatsappWhitelistedUser); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.WhatsappWhitelistedUser)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIAuthService_GetWhatsappWhitelistedUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWhatsappWhitelistedUsers'
+type MockIAuthService_GetWhatsappWhitelistedUsers_Call struct {
+	*mock.Call
+}
+
+// GetWhatsappWhitelistedUsers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIAuthService_Expecter) GetWhatsappWhitelistedUsers(ctx interface{}) *MockIAuthService_GetWhatsappWhitelistedUsers_Call {
+	return &MockIAuthService_GetWhatsappWhitelistedUsers_Call{Call: _e.mock.On("GetWhatsappWhitelistedUsers", ctx)}
+}
+
+func (_c *MockIAuthService_GetWhatsappWhitelistedUsers_Call) Run(run func(ctx context.Context)) *MockIAuthService_GetWhatsappWhitelistedUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIAuthService_GetWhatsappWhitelistedUsers_Call) Return(whatsappWhitelistedUsers []*dto.WhatsappWhitelistedUser, err error) *MockIAuthService_GetWhatsappWhitelistedUsers_Call {
+	_c.Call.Return(whatsappWhitelistedUsers, err)
+	return _c
+}
+
+func (_c *MockIAuthService_GetWhatsappWhitelistedUsers_Call) RunAndReturn(run func(ctx context.Context) ([]*dto.WhatsappWhitelistedUser, error)) *MockIAuthService_GetWhatsappWhitelistedUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsWhatsappSynced provides a mock function for the type MockIAuthService
 func (_mock *MockIAuthService) IsWhatsappSynced(ctx context.Context) bool {
 	ret := _mock.Called(ctx)
@@ -514,6 +583,80 @@ func (_c *MockIAuthService_WhatsappLogin_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// WhatsappLoginWithNumber provides a mock function for the type MockIAuthService
+func (_mock *MockIAuthService) WhatsappLoginWithNumber(ctx context.Context, req *dto.WhatsappLoginNumberReq) (string, <-chan whatsmeow.QRChannelItem, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WhatsappLoginWithNumber")
+	}
+
+	var r0 string
+	var r1 <-chan whatsmeow.QRChannelItem
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.WhatsappLoginNumberReq) (string, <-chan whatsmeow.QRChannelItem, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.WhatsappLoginNumberReq) string); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dto.WhatsappLoginNumberReq) <-chan whatsmeow.QRChannelItem); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(<-chan whatsmeow.QRChannelItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *dto.WhatsappLoginNumberReq) error); ok {
+		r2 = returnFunc(ctx, req)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockIAuthService_WhatsappLoginWithNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WhatsappLoginWithNumber'
+type MockIAuthService_WhatsappLoginWithNumber_Call struct {
+	*mock.Call
+}
+
+// WhatsappLoginWithNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *dto.WhatsappLoginNumberReq
+func (_e *MockIAuthService_Expecter) WhatsappLoginWithNumber(ctx interface{}, req interface{}) *MockIAuthService_WhatsappLoginWithNumber_Call {
+	return &MockIAuthService_WhatsappLoginWithNumber_Call{Call: _e.mock.On("WhatsappLoginWithNumber", ctx, req)}
+}
+
+func (_c *MockIAuthService_WhatsappLoginWithNumber_Call) Run(run func(ctx context.Context, req *dto.WhatsappLoginNumberReq)) *MockIAuthService_WhatsappLoginWithNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dto.WhatsappLoginNumberReq
+		if args[1] != nil {
+			arg1 = args[1].(*dto.WhatsappLoginNumberReq)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIAuthService_WhatsappLoginWithNumber_Call) Return(s string, qRChannelItemCh <-chan whatsmeow.QRChannelItem, err error) *MockIAuthService_WhatsappLoginWithNumber_Call {
+	_c.Call.Return(s, qRChannelItemCh, err)
+	return _c
+}
+
+func (_c *MockIAuthService_WhatsappLoginWithNumber_Call) RunAndReturn(run func(ctx context.Context, req *dto.WhatsappLoginNumberReq) (string, <-chan whatsmeow.QRChannelItem, error)) *MockIAuthService_WhatsappLoginWithNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WhatsappLogout provides a mock function for the type MockIAuthService
 func (_mock *MockIAuthService) WhatsappLogout(ctx context.Context) error {
 	ret := _mock.Called(ctx)
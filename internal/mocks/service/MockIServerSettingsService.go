@@ -235,6 +235,83 @@ func (_c *MockIServerSettingsService_GetExarotonServerPlayerList_Call) RunAndRet
 	return _c
 }
 
+// GetExarotonServersStatus provides a mock function for the type MockIServerSettingsService
+func (_mock *MockIServerSettingsService) GetExarotonServersStatus(ctx context.Context, opts ...service.ServersStatusOption) ([]*dto.ExarotonServerStatus, error) {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, opts)
+	} else {
+		tmpRet = _mock.Called(ctx)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExarotonServersStatus")
+	}
+
+	var r0 []*dto.ExarotonServerStatus
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...service.ServersStatusOption) ([]*dto.ExarotonServerStatus, error)); ok {
+		return returnFunc(ctx, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ...service.ServersStatusOption) []*dto.ExarotonServerStatus); ok {
+		r0 = returnFunc(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.ExarotonServerStatus)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ...service.ServersStatusOption) error); ok {
+		r1 = returnFunc(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIServerSettingsService_GetExarotonServersStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExarotonServersStatus'
+type MockIServerSettingsService_GetExarotonServersStatus_Call struct {
+	*mock.Call
+}
+
+// GetExarotonServersStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...service.ServersStatusOption
+func (_e *MockIServerSettingsService_Expecter) GetExarotonServersStatus(ctx interface{}, opts ...interface{}) *MockIServerSettingsService_GetExarotonServersStatus_Call {
+	return &MockIServerSettingsService_GetExarotonServersStatus_Call{Call: _e.mock.On("GetExarotonServersStatus",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockIServerSettingsService_GetExarotonServersStatus_Call) Run(run func(ctx context.Context, opts ...service.ServersStatusOption)) *MockIServerSettingsService_GetExarotonServersStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []service.ServersStatusOption
+		var variadicArgs []service.ServersStatusOption
+		if len(args) > 1 {
+			variadicArgs = args[1].([]service.ServersStatusOption)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIServerSettingsService_GetExarotonServersStatus_Call) Return(exarotonServerStatuss []*dto.ExarotonServerStatus, err error) *MockIServerSettingsService_GetExarotonServersStatus_Call {
+	_c.Call.Return(exarotonServerStatuss, err)
+	return _c
+}
+
+func (_c *MockIServerSettingsService_GetExarotonServersStatus_Call) RunAndReturn(run func(ctx context.Context, opts ...service.ServersStatusOption) ([]*dto.ExarotonServerStatus, error)) *MockIServerSettingsService_GetExarotonServersStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListExarotonServer provides a mock function for the type MockIServerSettingsService
 func (_mock *MockIServerSettingsService) ListExarotonServer(ctx context.Context) ([]*dto.ExarotonServerInfo, error) {
 	ret := _mock.Called(ctx)
@@ -297,6 +374,63 @@ func (_c *MockIServerSettingsService_ListExarotonServer_Call) RunAndReturn(run f
 	return _c
 }
 
+// RestartExarotonServer provides a mock function for the type MockIServerSettingsService
+func (_mock *MockIServerSettingsService) RestartExarotonServer(ctx context.Context, serverIdx uint) error {
+	ret := _mock.Called(ctx, serverIdx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestartExarotonServer")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = returnFunc(ctx, serverIdx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIServerSettingsService_RestartExarotonServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestartExarotonServer'
+type MockIServerSettingsService_RestartExarotonServer_Call struct {
+	*mock.Call
+}
+
+// RestartExarotonServer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serverIdx uint
+func (_e *MockIServerSettingsService_Expecter) RestartExarotonServer(ctx interface{}, serverIdx interface{}) *MockIServerSettingsService_RestartExarotonServer_Call {
+	return &MockIServerSettingsService_RestartExarotonServer_Call{Call: _e.mock.On("RestartExarotonServer", ctx, serverIdx)}
+}
+
+func (_c *MockIServerSettingsService_RestartExarotonServer_Call) Run(run func(ctx context.Context, serverIdx uint)) *MockIServerSettingsService_RestartExarotonServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint
+		if args[1] != nil {
+			arg1 = args[1].(uint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIServerSettingsService_RestartExarotonServer_Call) Return(err error) *MockIServerSettingsService_RestartExarotonServer_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIServerSettingsService_RestartExarotonServer_Call) RunAndReturn(run func(ctx context.Context, serverIdx uint) error) *MockIServerSettingsService_RestartExarotonServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StartExarotonServer provides a mock function for the type MockIServerSettingsService
 func (_mock *MockIServerSettingsService) StartExarotonServer(ctx context.Context, serverIdx uint, opts ...service.StartExarotonServerOption) *dto.StartExarotonServerRes {
 	var tmpRet mock.Arguments
@@ -7,8 +7,10 @@ package service
 import (
 	"context"
 	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
 
 	mock "github.com/stretchr/testify/mock"
+	"go.mau.fi/whatsmeow/types"
 )
 
 // NewMockIWhatsappService creates a new instance of MockIWhatsappService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -38,6 +40,136 @@ func (_m *MockIWhatsappService) EXPECT() *MockIWhatsappService_Expecter {
 	return &MockIWhatsappService_Expecter{mock: &_m.Mock}
 }
 
+// CleanupGroups provides a mock function for the type MockIWhatsappService
+func (_mock *MockIWhatsappService) CleanupGroups(ctx context.Context) (*dto.WhatsappGroupsCleanupRes, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CleanupGroups")
+	}
+
+	var r0 *dto.WhatsappGroupsCleanupRes
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*dto.WhatsappGroupsCleanupRes, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *dto.WhatsappGroupsCleanupRes); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.WhatsappGroupsCleanupRes)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIWhatsappService_CleanupGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CleanupGroups'
+type MockIWhatsappService_CleanupGroups_Call struct {
+	*mock.Call
+}
+
+// CleanupGroups is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIWhatsappService_Expecter) CleanupGroups(ctx interface{}) *MockIWhatsappService_CleanupGroups_Call {
+	return &MockIWhatsappService_CleanupGroups_Call{Call: _e.mock.On("CleanupGroups", ctx)}
+}
+
+func (_c *MockIWhatsappService_CleanupGroups_Call) Run(run func(ctx context.Context)) *MockIWhatsappService_CleanupGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappService_CleanupGroups_Call) Return(whatsappGroupsCleanupRes *dto.WhatsappGroupsCleanupRes, err error) *MockIWhatsappService_CleanupGroups_Call {
+	_c.Call.Return(whatsappGroupsCleanupRes, err)
+	return _c
+}
+
+func (_c *MockIWhatsappService_CleanupGroups_Call) RunAndReturn(run func(ctx context.Context) (*dto.WhatsappGroupsCleanupRes, error)) *MockIWhatsappService_CleanupGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGroupSettings provides a mock function for the type MockIWhatsappService
+func (_mock *MockIWhatsappService) GetGroupSettings(ctx context.Context, group dto.WhatsappJID) (*dto.WhatsappGroupSettings, error) {
+	ret := _mock.Called(ctx, group)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupSettings")
+	}
+
+	var r0 *dto.WhatsappGroupSettings
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.WhatsappJID) (*dto.WhatsappGroupSettings, error)); ok {
+		return returnFunc(ctx, group)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.WhatsappJID) *dto.WhatsappGroupSettings); ok {
+		r0 = returnFunc(ctx, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.WhatsappGroupSettings)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.WhatsappJID) error); ok {
+		r1 = returnFunc(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIWhatsappService_GetGroupSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGroupSettings'
+type MockIWhatsappService_GetGroupSettings_Call struct {
+	*mock.Call
+}
+
+// GetGroupSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - group dto.WhatsappJID
+func (_e *MockIWhatsappService_Expecter) GetGroupSettings(ctx interface{}, group interface{}) *MockIWhatsappService_GetGroupSettings_Call {
+	return &MockIWhatsappService_GetGroupSettings_Call{Call: _e.mock.On("GetGroupSettings", ctx, group)}
+}
+
+func (_c *MockIWhatsappService_GetGroupSettings_Call) Run(run func(ctx context.Context, group dto.WhatsappJID)) *MockIWhatsappService_GetGroupSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.WhatsappJID
+		if args[1] != nil {
+			arg1 = args[1].(dto.WhatsappJID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappService_GetGroupSettings_Call) Return(whatsappGroupSettings *dto.WhatsappGroupSettings, err error) *MockIWhatsappService_GetGroupSettings_Call {
+	_c.Call.Return(whatsappGroupSettings, err)
+	return _c
+}
+
+func (_c *MockIWhatsappService_GetGroupSettings_Call) RunAndReturn(run func(ctx context.Context, group dto.WhatsappJID) (*dto.WhatsappGroupSettings, error)) *MockIWhatsappService_GetGroupSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetGroups provides a mock function for the type MockIWhatsappService
 func (_mock *MockIWhatsappService) GetGroups(ctx context.Context, req *dto.GetWhatsappGroupReq) ([]*dto.WhatsappGroupInfo, error) {
 	ret := _mock.Called(ctx, req)
@@ -106,6 +238,370 @@ func (_c *MockIWhatsappService_GetGroups_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// JoinGroup provides a mock function for the type MockIWhatsappService
+func (_mock *MockIWhatsappService) JoinGroup(ctx context.Context, req *dto.JoinWhatsappGroupReq) (*dto.WhatsappGroupInfo, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JoinGroup")
+	}
+
+	var r0 *dto.WhatsappGroupInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.JoinWhatsappGroupReq) (*dto.WhatsappGroupInfo, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.JoinWhatsappGroupReq) *dto.WhatsappGroupInfo); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.WhatsappGroupInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dto.JoinWhatsappGroupReq) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIWhatsappService_JoinGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JoinGroup'
+type MockIWhatsappService_JoinGroup_Call struct {
+	*mock.Call
+}
+
+// JoinGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *dto.JoinWhatsappGroupReq
+func (_e *MockIWhatsappService_Expecter) JoinGroup(ctx interface{}, req interface{}) *MockIWhatsappService_JoinGroup_Call {
+	return &MockIWhatsappService_JoinGroup_Call{Call: _e.mock.On("JoinGroup", ctx, req)}
+}
+
+func (_c *MockIWhatsappService_JoinGroup_Call) Run(run func(ctx context.Context, req *dto.JoinWhatsappGroupReq)) *MockIWhatsappService_JoinGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dto.JoinWhatsappGroupReq
+		if args[1] != nil {
+			arg1 = args[1].(*dto.JoinWhatsappGroupReq)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappService_JoinGroup_Call) Return(whatsappGroupInfo *dto.WhatsappGroupInfo, err error) *MockIWhatsappService_JoinGroup_Call {
+	_c.Call.Return(whatsappGroupInfo, err)
+	return _c
+}
+
+func (_c *MockIWhatsappService_JoinGroup_Call) RunAndReturn(run func(ctx context.Context, req *dto.JoinWhatsappGroupReq) (*dto.WhatsappGroupInfo, error)) *MockIWhatsappService_JoinGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkGroupLeft provides a mock function for the type MockIWhatsappService
+func (_mock *MockIWhatsappService) MarkGroupLeft(ctx context.Context, group dto.WhatsappJID) error {
+	ret := _mock.Called(ctx, group)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkGroupLeft")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.WhatsappJID) error); ok {
+		r0 = returnFunc(ctx, group)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIWhatsappService_MarkGroupLeft_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkGroupLeft'
+type MockIWhatsappService_MarkGroupLeft_Call struct {
+	*mock.Call
+}
+
+// MarkGroupLeft is a helper method to define mock.On call
+//   - ctx context.Context
+//   - group dto.WhatsappJID
+func (_e *MockIWhatsappService_Expecter) MarkGroupLeft(ctx interface{}, group interface{}) *MockIWhatsappService_MarkGroupLeft_Call {
+	return &MockIWhatsappService_MarkGroupLeft_Call{Call: _e.mock.On("MarkGroupLeft", ctx, group)}
+}
+
+func (_c *MockIWhatsappService_MarkGroupLeft_Call) Run(run func(ctx context.Context, group dto.WhatsappJID)) *MockIWhatsappService_MarkGroupLeft_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.WhatsappJID
+		if args[1] != nil {
+			arg1 = args[1].(dto.WhatsappJID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappService_MarkGroupLeft_Call) Return(err error) *MockIWhatsappService_MarkGroupLeft_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIWhatsappService_MarkGroupLeft_Call) RunAndReturn(run func(ctx context.Context, group dto.WhatsappJID) error) *MockIWhatsappService_MarkGroupLeft_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewGroupInvite provides a mock function for the type MockIWhatsappService
+func (_mock *MockIWhatsappService) PreviewGroupInvite(ctx context.Context, req *dto.WhatsappGroupInviteReq) (*dto.WhatsappGroupInfo, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewGroupInvite")
+	}
+
+	var r0 *dto.WhatsappGroupInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.WhatsappGroupInviteReq) (*dto.WhatsappGroupInfo, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.WhatsappGroupInviteReq) *dto.WhatsappGroupInfo); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.WhatsappGroupInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *dto.WhatsappGroupInviteReq) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIWhatsappService_PreviewGroupInvite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewGroupInvite'
+type MockIWhatsappService_PreviewGroupInvite_Call struct {
+	*mock.Call
+}
+
+// PreviewGroupInvite is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *dto.WhatsappGroupInviteReq
+func (_e *MockIWhatsappService_Expecter) PreviewGroupInvite(ctx interface{}, req interface{}) *MockIWhatsappService_PreviewGroupInvite_Call {
+	return &MockIWhatsappService_PreviewGroupInvite_Call{Call: _e.mock.On("PreviewGroupInvite", ctx, req)}
+}
+
+func (_c *MockIWhatsappService_PreviewGroupInvite_Call) Run(run func(ctx context.Context, req *dto.WhatsappGroupInviteReq)) *MockIWhatsappService_PreviewGroupInvite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dto.WhatsappGroupInviteReq
+		if args[1] != nil {
+			arg1 = args[1].(*dto.WhatsappGroupInviteReq)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappService_PreviewGroupInvite_Call) Return(whatsappGroupInfo *dto.WhatsappGroupInfo, err error) *MockIWhatsappService_PreviewGroupInvite_Call {
+	_c.Call.Return(whatsappGroupInfo, err)
+	return _c
+}
+
+func (_c *MockIWhatsappService_PreviewGroupInvite_Call) RunAndReturn(run func(ctx context.Context, req *dto.WhatsappGroupInviteReq) (*dto.WhatsappGroupInfo, error)) *MockIWhatsappService_PreviewGroupInvite_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReconcileGroups provides a mock function for the type MockIWhatsappService
+func (_mock *MockIWhatsappService) ReconcileGroups(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileGroups")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIWhatsappService_ReconcileGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconcileGroups'
+type MockIWhatsappService_ReconcileGroups_Call struct {
+	*mock.Call
+}
+
+// ReconcileGroups is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockIWhatsappService_Expecter) ReconcileGroups(ctx interface{}) *MockIWhatsappService_ReconcileGroups_Call {
+	return &MockIWhatsappService_ReconcileGroups_Call{Call: _e.mock.On("ReconcileGroups", ctx)}
+}
+
+func (_c *MockIWhatsappService_ReconcileGroups_Call) Run(run func(ctx context.Context)) *MockIWhatsappService_ReconcileGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappService_ReconcileGroups_Call) Return(err error) *MockIWhatsappService_ReconcileGroups_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIWhatsappService_ReconcileGroups_Call) RunAndReturn(run func(ctx context.Context) error) *MockIWhatsappService_ReconcileGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetGroupLanguage provides a mock function for the type MockIWhatsappService
+func (_mock *MockIWhatsappService) SetGroupLanguage(ctx context.Context, group dto.WhatsappJID, lang i18n.Lang) error {
+	ret := _mock.Called(ctx, group, lang)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetGroupLanguage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.WhatsappJID, i18n.Lang) error); ok {
+		r0 = returnFunc(ctx, group, lang)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIWhatsappService_SetGroupLanguage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetGroupLanguage'
+type MockIWhatsappService_SetGroupLanguage_Call struct {
+	*mock.Call
+}
+
+// SetGroupLanguage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - group dto.WhatsappJID
+//   - lang i18n.Lang
+func (_e *MockIWhatsappService_Expecter) SetGroupLanguage(ctx interface{}, group interface{}, lang interface{}) *MockIWhatsappService_SetGroupLanguage_Call {
+	return &MockIWhatsappService_SetGroupLanguage_Call{Call: _e.mock.On("SetGroupLanguage", ctx, group, lang)}
+}
+
+func (_c *MockIWhatsappService_SetGroupLanguage_Call) Run(run func(ctx context.Context, group dto.WhatsappJID, lang i18n.Lang)) *MockIWhatsappService_SetGroupLanguage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.WhatsappJID
+		if args[1] != nil {
+			arg1 = args[1].(dto.WhatsappJID)
+		}
+		var arg2 i18n.Lang
+		if args[2] != nil {
+			arg2 = args[2].(i18n.Lang)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappService_SetGroupLanguage_Call) Return(err error) *MockIWhatsappService_SetGroupLanguage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIWhatsappService_SetGroupLanguage_Call) RunAndReturn(run func(ctx context.Context, group dto.WhatsappJID, lang i18n.Lang) error) *MockIWhatsappService_SetGroupLanguage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SyncGroup provides a mock function for the type MockIWhatsappService
+func (_mock *MockIWhatsappService) SyncGroup(ctx context.Context, info *types.GroupInfo) error {
+	ret := _mock.Called(ctx, info)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncGroup")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *types.GroupInfo) error); ok {
+		r0 = returnFunc(ctx, info)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIWhatsappService_SyncGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncGroup'
+type MockIWhatsappService_SyncGroup_Call struct {
+	*mock.Call
+}
+
+// SyncGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - info *types.GroupInfo
+func (_e *MockIWhatsappService_Expecter) SyncGroup(ctx interface{}, info interface{}) *MockIWhatsappService_SyncGroup_Call {
+	return &MockIWhatsappService_SyncGroup_Call{Call: _e.mock.On("SyncGroup", ctx, info)}
+}
+
+func (_c *MockIWhatsappService_SyncGroup_Call) Run(run func(ctx context.Context, info *types.GroupInfo)) *MockIWhatsappService_SyncGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *types.GroupInfo
+		if args[1] != nil {
+			arg1 = args[1].(*types.GroupInfo)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappService_SyncGroup_Call) Return(err error) *MockIWhatsappService_SyncGroup_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIWhatsappService_SyncGroup_Call) RunAndReturn(run func(ctx context.Context, info *types.GroupInfo) error) *MockIWhatsappService_SyncGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UnwhitelistGroup provides a mock function for the type MockIWhatsappService
 func (_mock *MockIWhatsappService) UnwhitelistGroup(ctx context.Context, req *dto.UnwhitelistWhatsappGroupReq) error {
 	ret := _mock.Called(ctx, req)
@@ -163,6 +659,177 @@ func (_c *MockIWhatsappService_UnwhitelistGroup_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// UnwhitelistUser provides a mock function for the type MockIWhatsappService
+func (_mock *MockIWhatsappService) UnwhitelistUser(ctx context.Context, req *dto.UnwhitelistWhatsappUserReq) error {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnwhitelistUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.UnwhitelistWhatsappUserReq) error); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIWhatsappService_UnwhitelistUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnwhitelistUser'
+type MockIWhatsappService_UnwhitelistUser_Call struct {
+	*mock.Call
+}
+
+// UnwhitelistUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *dto.UnwhitelistWhatsappUserReq
+func (_e *MockIWhatsappService_Expecter) UnwhitelistUser(ctx interface{}, req interface{}) *MockIWhatsappService_UnwhitelistUser_Call {
+	return &MockIWhatsappService_UnwhitelistUser_Call{Call: _e.mock.On("UnwhitelistUser", ctx, req)}
+}
+
+func (_c *MockIWhatsappService_UnwhitelistUser_Call) Run(run func(ctx context.Context, req *dto.UnwhitelistWhatsappUserReq)) *MockIWhatsappService_UnwhitelistUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dto.UnwhitelistWhatsappUserReq
+		if args[1] != nil {
+			arg1 = args[1].(*dto.UnwhitelistWhatsappUserReq)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappService_UnwhitelistUser_Call) Return(err error) *MockIWhatsappService_UnwhitelistUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIWhatsappService_UnwhitelistUser_Call) RunAndReturn(run func(ctx context.Context, req *dto.UnwhitelistWhatsappUserReq) error) *MockIWhatsappService_UnwhitelistUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateGroupInfo provides a mock function for the type MockIWhatsappService
+func (_mock *MockIWhatsappService) UpdateGroupInfo(ctx context.Context, req *dto.WhatsappGroupInfoUpdate) error {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateGroupInfo")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.WhatsappGroupInfoUpdate) error); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIWhatsappService_UpdateGroupInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateGroupInfo'
+type MockIWhatsappService_UpdateGroupInfo_Call struct {
+	*mock.Call
+}
+
+// UpdateGroupInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *dto.WhatsappGroupInfoUpdate
+func (_e *MockIWhatsappService_Expecter) UpdateGroupInfo(ctx interface{}, req interface{}) *MockIWhatsappService_UpdateGroupInfo_Call {
+	return &MockIWhatsappService_UpdateGroupInfo_Call{Call: _e.mock.On("UpdateGroupInfo", ctx, req)}
+}
+
+func (_c *MockIWhatsappService_UpdateGroupInfo_Call) Run(run func(ctx context.Context, req *dto.WhatsappGroupInfoUpdate)) *MockIWhatsappService_UpdateGroupInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dto.WhatsappGroupInfoUpdate
+		if args[1] != nil {
+			arg1 = args[1].(*dto.WhatsappGroupInfoUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappService_UpdateGroupInfo_Call) Return(err error) *MockIWhatsappService_UpdateGroupInfo_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIWhatsappService_UpdateGroupInfo_Call) RunAndReturn(run func(ctx context.Context, req *dto.WhatsappGroupInfoUpdate) error) *MockIWhatsappService_UpdateGroupInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateGroupSettings provides a mock function for the type MockIWhatsappService
+func (_mock *MockIWhatsappService) UpdateGroupSettings(ctx context.Context, req *dto.UpdateWhatsappGroupSettingsReq) error {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateGroupSettings")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.UpdateWhatsappGroupSettingsReq) error); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIWhatsappService_UpdateGroupSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateGroupSettings'
+type MockIWhatsappService_UpdateGroupSettings_Call struct {
+	*mock.Call
+}
+
+// UpdateGroupSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *dto.UpdateWhatsappGroupSettingsReq
+func (_e *MockIWhatsappService_Expecter) UpdateGroupSettings(ctx interface{}, req interface{}) *MockIWhatsappService_UpdateGroupSettings_Call {
+	return &MockIWhatsappService_UpdateGroupSettings_Call{Call: _e.mock.On("UpdateGroupSettings", ctx, req)}
+}
+
+func (_c *MockIWhatsappService_UpdateGroupSettings_Call) Run(run func(ctx context.Context, req *dto.UpdateWhatsappGroupSettingsReq)) *MockIWhatsappService_UpdateGroupSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dto.UpdateWhatsappGroupSettingsReq
+		if args[1] != nil {
+			arg1 = args[1].(*dto.UpdateWhatsappGroupSettingsReq)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappService_UpdateGroupSettings_Call) Return(err error) *MockIWhatsappService_UpdateGroupSettings_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIWhatsappService_UpdateGroupSettings_Call) RunAndReturn(run func(ctx context.Context, req *dto.UpdateWhatsappGroupSettingsReq) error) *MockIWhatsappService_UpdateGroupSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WhitelistGroup provides a mock function for the type MockIWhatsappService
 func (_mock *MockIWhatsappService) WhitelistGroup(ctx context.Context, req *dto.WhitelistWhatsappGroupReq) error {
 	ret := _mock.Called(ctx, req)
@@ -219,3 +886,60 @@ func (_c *MockIWhatsappService_WhitelistGroup_Call) RunAndReturn(run func(ctx co
 	_c.Call.Return(run)
 	return _c
 }
+
+// WhitelistUser provides a mock function for the type MockIWhatsappService
+func (_mock *MockIWhatsappService) WhitelistUser(ctx context.Context, req *dto.WhitelistWhatsappUserReq) error {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WhitelistUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *dto.WhitelistWhatsappUserReq) error); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIWhatsappService_WhitelistUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WhitelistUser'
+type MockIWhatsappService_WhitelistUser_Call struct {
+	*mock.Call
+}
+
+// WhitelistUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *dto.WhitelistWhatsappUserReq
+func (_e *MockIWhatsappService_Expecter) WhitelistUser(ctx interface{}, req interface{}) *MockIWhatsappService_WhitelistUser_Call {
+	return &MockIWhatsappService_WhitelistUser_Call{Call: _e.mock.On("WhitelistUser", ctx, req)}
+}
+
+func (_c *MockIWhatsappService_WhitelistUser_Call) Run(run func(ctx context.Context, req *dto.WhitelistWhatsappUserReq)) *MockIWhatsappService_WhitelistUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *dto.WhitelistWhatsappUserReq
+		if args[1] != nil {
+			arg1 = args[1].(*dto.WhitelistWhatsappUserReq)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIWhatsappService_WhitelistUser_Call) Return(err error) *MockIWhatsappService_WhitelistUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIWhatsappService_WhitelistUser_Call) RunAndReturn(run func(ctx context.Context, req *dto.WhitelistWhatsappUserReq) error) *MockIWhatsappService_WhitelistUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -6,6 +6,7 @@ package command
 
 import (
 	"context"
+	"exaroton-wa-bot/internal/dto"
 	"exaroton-wa-bot/internal/service/command"
 
 	mock "github.com/stretchr/testify/mock"
@@ -38,8 +39,142 @@ func (_m *MockCommand) EXPECT() *MockCommand_Expecter {
 	return &MockCommand_Expecter{mock: &_m.Mock}
 }
 
+// Aliases provides a mock function for the type MockCommand
+func (_mock *MockCommand) Aliases() []string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Aliases")
+	}
+
+	var r0 []string
+	if returnFunc, ok := ret.Get(0).(func() []string); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	return r0
+}
+
+// MockCommand_Aliases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Aliases'
+type MockCommand_Aliases_Call struct {
+	*mock.Call
+}
+
+// Aliases is a helper method to define mock.On call
+func (_e *MockCommand_Expecter) Aliases() *MockCommand_Aliases_Call {
+	return &MockCommand_Aliases_Call{Call: _e.mock.On("Aliases")}
+}
+
+func (_c *MockCommand_Aliases_Call) Run(run func()) *MockCommand_Aliases_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockCommand_Aliases_Call) Return(ss []string) *MockCommand_Aliases_Call {
+	_c.Call.Return(ss)
+	return _c
+}
+
+func (_c *MockCommand_Aliases_Call) RunAndReturn(run func() []string) *MockCommand_Aliases_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Args provides a mock function for the type MockCommand
+func (_mock *MockCommand) Args() command.ArgSpec {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Args")
+	}
+
+	var r0 command.ArgSpec
+	if returnFunc, ok := ret.Get(0).(func() command.ArgSpec); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(command.ArgSpec)
+	}
+	return r0
+}
+
+// MockCommand_Args_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Args'
+type MockCommand_Args_Call struct {
+	*mock.Call
+}
+
+// Args is a helper method to define mock.On call
+func (_e *MockCommand_Expecter) Args() *MockCommand_Args_Call {
+	return &MockCommand_Args_Call{Call: _e.mock.On("Args")}
+}
+
+func (_c *MockCommand_Args_Call) Run(run func()) *MockCommand_Args_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockCommand_Args_Call) Return(argSpec command.ArgSpec) *MockCommand_Args_Call {
+	_c.Call.Return(argSpec)
+	return _c
+}
+
+func (_c *MockCommand_Args_Call) RunAndReturn(run func() command.ArgSpec) *MockCommand_Args_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Cooldown provides a mock function for the type MockCommand
+func (_mock *MockCommand) Cooldown() command.Cooldown {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Cooldown")
+	}
+
+	var r0 command.Cooldown
+	if returnFunc, ok := ret.Get(0).(func() command.Cooldown); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(command.Cooldown)
+	}
+	return r0
+}
+
+// MockCommand_Cooldown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Cooldown'
+type MockCommand_Cooldown_Call struct {
+	*mock.Call
+}
+
+// Cooldown is a helper method to define mock.On call
+func (_e *MockCommand_Expecter) Cooldown() *MockCommand_Cooldown_Call {
+	return &MockCommand_Cooldown_Call{Call: _e.mock.On("Cooldown")}
+}
+
+func (_c *MockCommand_Cooldown_Call) Run(run func()) *MockCommand_Cooldown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockCommand_Cooldown_Call) Return(cooldown command.Cooldown) *MockCommand_Cooldown_Call {
+	_c.Call.Return(cooldown)
+	return _c
+}
+
+func (_c *MockCommand_Cooldown_Call) RunAndReturn(run func() command.Cooldown) *MockCommand_Cooldown_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Execute provides a mock function for the type MockCommand
-func (_mock *MockCommand) Execute(c context.Context, args []string) command.CommandResult {
+func (_mock *MockCommand) Execute(c context.Context, args *command.Args) command.CommandResult {
 	ret := _mock.Called(c, args)
 
 	if len(ret) == 0 {
@@ -47,7 +182,7 @@ func (_mock *MockCommand) Execute(c context.Context, args []string) command.Comm
 	}
 
 	var r0 command.CommandResult
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) command.CommandResult); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *command.Args) command.CommandResult); ok {
 		r0 = returnFunc(c, args)
 	} else {
 		r0 = ret.Get(0).(command.CommandResult)
@@ -62,20 +197,20 @@ type MockCommand_Execute_Call struct {
 
 // Execute is a helper method to define mock.On call
 //   - c context.Context
-//   - args []string
+//   - args *command.Args
 func (_e *MockCommand_Expecter) Execute(c interface{}, args interface{}) *MockCommand_Execute_Call {
 	return &MockCommand_Execute_Call{Call: _e.mock.On("Execute", c, args)}
 }
 
-func (_c *MockCommand_Execute_Call) Run(run func(c context.Context, args []string)) *MockCommand_Execute_Call {
+func (_c *MockCommand_Execute_Call) Run(run func(c context.Context, args *command.Args)) *MockCommand_Execute_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 *command.Args
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(*command.Args)
 		}
 		run(
 			arg0,
@@ -90,7 +225,7 @@ func (_c *MockCommand_Execute_Call) Return(commandResult command.CommandResult)
 	return _c
 }
 
-func (_c *MockCommand_Execute_Call) RunAndReturn(run func(c context.Context, args []string) command.CommandResult) *MockCommand_Execute_Call {
+func (_c *MockCommand_Execute_Call) RunAndReturn(run func(c context.Context, args *command.Args) command.CommandResult) *MockCommand_Execute_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -183,46 +318,46 @@ func (_c *MockCommand_Name_Call) RunAndReturn(run func() string) *MockCommand_Na
 	return _c
 }
 
-// Usage provides a mock function for the type MockCommand
-func (_mock *MockCommand) Usage() string {
+// Role provides a mock function for the type MockCommand
+func (_mock *MockCommand) Role() dto.WhatsappRole {
 	ret := _mock.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for Usage")
+		panic("no return value specified for Role")
 	}
 
-	var r0 string
-	if returnFunc, ok := ret.Get(0).(func() string); ok {
+	var r0 dto.WhatsappRole
+	if returnFunc, ok := ret.Get(0).(func() dto.WhatsappRole); ok {
 		r0 = returnFunc()
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(dto.WhatsappRole)
 	}
 	return r0
 }
 
-// MockCommand_Usage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Usage'
-type MockCommand_Usage_Call struct {
+// MockCommand_Role_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Role'
+type MockCommand_Role_Call struct {
 	*mock.Call
 }
 
-// Usage is a helper method to define mock.On call
-func (_e *MockCommand_Expecter) Usage() *MockCommand_Usage_Call {
-	return &MockCommand_Usage_Call{Call: _e.mock.On("Usage")}
+// Role is a helper method to define mock.On call
+func (_e *MockCommand_Expecter) Role() *MockCommand_Role_Call {
+	return &MockCommand_Role_Call{Call: _e.mock.On("Role")}
 }
 
-func (_c *MockCommand_Usage_Call) Run(run func()) *MockCommand_Usage_Call {
+func (_c *MockCommand_Role_Call) Run(run func()) *MockCommand_Role_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *MockCommand_Usage_Call) Return(s string) *MockCommand_Usage_Call {
-	_c.Call.Return(s)
+func (_c *MockCommand_Role_Call) Return(whatsappRole dto.WhatsappRole) *MockCommand_Role_Call {
+	_c.Call.Return(whatsappRole)
 	return _c
 }
 
-func (_c *MockCommand_Usage_Call) RunAndReturn(run func() string) *MockCommand_Usage_Call {
+func (_c *MockCommand_Role_Call) RunAndReturn(run func() dto.WhatsappRole) *MockCommand_Role_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,340 @@
+// Package render renders the replies of the bot: the message templates and
+// the image cards.
+//
+// Cards only use the bundled Go fonts and nothing depends on the clock or
+// randomness, the same input always renders the same PNG.
+package render
+
+import (
+	"bytes"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// MimeType is the type of the rendered images.
+const MimeType = "image/png"
+
+const (
+	cardWidth      = 720
+	cardPadding    = 24 // around the sections
+	sectionPadding = 24 // inside a section
+	sectionGap     = 16
+	accentWidth    = 8
+	barHeight      = 12
+	maxPlayerLines = 3
+)
+
+var (
+	colorBackground = color.RGBA{R: 0x1e, G: 0x1f, B: 0x22, A: 0xff}
+	colorSection    = color.RGBA{R: 0x2b, G: 0x2d, B: 0x31, A: 0xff}
+	colorText       = color.RGBA{R: 0xf2, G: 0xf3, B: 0xf5, A: 0xff}
+	colorMuted      = color.RGBA{R: 0xb5, G: 0xba, B: 0xc1, A: 0xff}
+	colorBarEmpty   = color.RGBA{R: 0x40, G: 0x42, B: 0x49, A: 0xff}
+
+	colorOnline  = color.RGBA{R: 0x23, G: 0xa5, B: 0x5a, A: 0xff}
+	colorOffline = color.RGBA{R: 0x80, G: 0x84, B: 0x8e, A: 0xff}
+	colorBusy    = color.RGBA{R: 0xf0, G: 0xb2, B: 0x32, A: 0xff}
+	colorCrashed = color.RGBA{R: 0xf2, G: 0x3f, B: 0x43, A: 0xff}
+)
+
+// statusColor returns the colour of a server status: green when online,
+// grey when offline, red when crashed and yellow in between.
+func statusColor(s dto.ServerStatus) color.RGBA {
+	switch s {
+	case dto.ServerStatusOnline:
+		return colorOnline
+	case dto.ServerStatusOffline:
+		return colorOffline
+	case dto.ServerStatusCrashed:
+		return colorCrashed
+	}
+
+	return colorBusy
+}
+
+// parsed once, faces are created per card as they aren't safe for concurrent use
+var parseFonts = sync.OnceValues(func() ([2]*opentype.Font, error) {
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return [2]*opentype.Font{}, err
+	}
+
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return [2]*opentype.Font{}, err
+	}
+
+	return [2]*opentype.Font{regular, bold}, nil
+})
+
+type faces struct {
+	title font.Face
+	body  font.Face
+	bold  font.Face
+	small font.Face
+}
+
+func newFaces() (*faces, error) {
+	fonts, err := parseFonts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fonts: %w", err)
+	}
+	regular, bold := fonts[0], fonts[1]
+
+	f := new(faces)
+	for _, face := range []struct {
+		dst  *font.Face
+		font *opentype.Font
+		size float64
+	}{
+		{&f.title, bold, 30},
+		{&f.body, regular, 22},
+		{&f.bold, bold, 22},
+		{&f.small, regular, 18},
+	} {
+		*face.dst, err = opentype.NewFace(face.font, &opentype.FaceOptions{Size: face.size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create font face: %w", err)
+		}
+	}
+
+	return f, nil
+}
+
+// ServerCard renders the status card of a server in lang, idx is its index as used by the commands.
+func ServerCard(lang i18n.Lang, server *dto.ExarotonServerInfo, idx uint) ([]byte, error) {
+	return render(lang, func(c *canvas, y int) int {
+		return c.server(y, server, idx, "")
+	})
+}
+
+// StatusCard renders the cards of every server in lang, one below the other.
+func StatusCard(lang i18n.Lang, statuses []*dto.ExarotonServerStatus) ([]byte, error) {
+	return render(lang, func(c *canvas, y int) int {
+		for i, st := range statuses {
+			if i > 0 {
+				y += sectionGap
+			}
+
+			note := ""
+			if st.Err != nil {
+				note = i18n.T(lang, "card.outdated")
+			}
+			y = c.server(y, st.Server, st.Idx, note)
+		}
+
+		return y
+	})
+}
+
+// render runs layout twice: once to measure the height of the card, then to draw it.
+func render(lang i18n.Lang, layout func(c *canvas, y int) int) ([]byte, error) {
+	f, err := newFaces()
+	if err != nil {
+		return nil, err
+	}
+
+	height := layout(&canvas{lang: lang, faces: f}, cardPadding) + cardPadding
+
+	img := image.NewRGBA(image.Rect(0, 0, cardWidth, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(colorBackground), image.Point{}, draw.Src)
+	layout(&canvas{img: img, lang: lang, faces: f}, cardPadding)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode card: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// canvas draws on img, or only measures if img is nil. Its labels are in lang.
+type canvas struct {
+	img  *image.RGBA
+	lang i18n.Lang
+	*faces
+}
+
+// server draws the section of a server starting at y, it returns the y below it.
+func (c *canvas) server(y int, server *dto.ExarotonServerInfo, idx uint, note string) int {
+	top := y
+	left, right := cardPadding+accentWidth+sectionPadding, cardWidth-cardPadding-sectionPadding
+	width := right - left
+	statusCol := statusColor(server.Status)
+
+	y += sectionPadding
+
+	// leave the section background for the end, its height isn't known yet
+	var ops []func()
+	deferDraw := func(op func()) { ops = append(ops, op) }
+
+	// name, status on the right
+	status := strings.ToUpper(StatusName(c.lang, server.Status))
+	statusWidth := measure(c.bold, status)
+	radius := ascent(c.bold) / 3
+	y += lineHeight(c.title)
+	titleY := y
+	deferDraw(func() {
+		c.text(c.title, left, titleY, truncate(c.title, server.Name, width-statusWidth-5*radius), colorText)
+		c.text(c.bold, right-statusWidth, titleY, status, statusCol)
+		c.circle(right-statusWidth-2*radius, titleY-ascent(c.bold)/2+radius/2, radius, statusCol)
+	})
+
+	// id and address
+	sub := fmt.Sprintf("#%d · %s", idx, server.Address)
+	if note != "" {
+		sub += " · " + note
+	}
+	y += lineHeight(c.body)
+	subY := y
+	deferDraw(func() { c.text(c.body, left, subY, truncate(c.body, sub, width), colorMuted) })
+
+	// players count and bar
+	y += sectionGap + lineHeight(c.body)
+	playersY := y
+	deferDraw(func() {
+		c.text(c.body, left, playersY, i18n.T(c.lang, "label.players"), colorText)
+		count := fmt.Sprintf("%d / %d", server.Players.Count, server.Players.Max)
+		c.text(c.bold, right-measure(c.bold, count), playersY, count, colorText)
+	})
+
+	y += sectionGap / 2
+	barY := y
+	deferDraw(func() {
+		c.rect(image.Rect(left, barY, right, barY+barHeight), colorBarEmpty)
+		if server.Players.Max > 0 {
+			filled := width * min(server.Players.Count, server.Players.Max) / server.Players.Max
+			c.rect(image.Rect(left, barY, left+filled, barY+barHeight), statusCol)
+		}
+	})
+	y += barHeight
+
+	// player names
+	for _, line := range wrapList(c.lang, c.small, server.Players.List, width, maxPlayerLines) {
+		y += lineHeight(c.small)
+		lineY := y
+		deferDraw(func() { c.text(c.small, left, lineY, line, colorMuted) })
+	}
+
+	// software
+	if server.Software != nil {
+		y += sectionGap + lineHeight(c.body)
+		softwareY := y
+		software := strings.TrimSpace(server.Software.Name + " " + server.Software.Version)
+		deferDraw(func() { c.text(c.body, left, softwareY, truncate(c.body, software, width), colorText) })
+	}
+
+	y += sectionPadding
+
+	c.rect(image.Rect(cardPadding, top, cardWidth-cardPadding, y), colorSection)
+	c.rect(image.Rect(cardPadding, top, cardPadding+accentWidth, y), statusCol)
+	for _, op := range ops {
+		op()
+	}
+
+	return y
+}
+
+// text draws s with its baseline at y.
+func (c *canvas) text(face font.Face, x, y int, s string, col color.Color) {
+	if c.img == nil {
+		return
+	}
+
+	d := font.Drawer{Dst: c.img, Src: image.NewUniform(col), Face: face, Dot: fixed.P(x, y)}
+	d.DrawString(s)
+}
+
+func (c *canvas) rect(r image.Rectangle, col color.Color) {
+	if c.img == nil {
+		return
+	}
+
+	draw.Draw(c.img, r, image.NewUniform(col), image.Point{}, draw.Src)
+}
+
+func (c *canvas) circle(cx, cy, radius int, col color.Color) {
+	if c.img == nil {
+		return
+	}
+
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				c.img.Set(cx+x, cy+y, col)
+			}
+		}
+	}
+}
+
+func measure(face font.Face, s string) int {
+	return font.MeasureString(face, s).Ceil()
+}
+
+func lineHeight(face font.Face) int {
+	return face.Metrics().Height.Ceil()
+}
+
+func ascent(face font.Face) int {
+	return face.Metrics().Ascent.Ceil()
+}
+
+// truncate shortens s with an ellipsis to fit in maxWidth.
+func truncate(face font.Face, s string, maxWidth int) string {
+	if measure(face, s) <= maxWidth {
+		return s
+	}
+
+	runes := []rune(s)
+	for len(runes) > 0 && measure(face, string(runes)+"…") > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+
+	return strings.TrimRight(string(runes), " ,") + "…"
+}
+
+// wrapList joins items by commas into at most maxLines lines of maxWidth,
+// the items that don't fit are counted at the end of the last line.
+func wrapList(lang i18n.Lang, face font.Face, items []string, maxWidth, maxLines int) []string {
+	var lines []string
+	line := ""
+
+	for i, item := range items {
+		next := item
+		if line != "" {
+			next = line + ", " + item
+		}
+
+		if line == "" || measure(face, next) <= maxWidth {
+			line = next
+			continue
+		}
+
+		if len(lines) == maxLines-1 {
+			more := " " + i18n.T(lang, "card.more", len(items)-i)
+			return append(lines, truncate(face, line+",", maxWidth-measure(face, more))+more)
+		}
+
+		lines = append(lines, truncate(face, line+",", maxWidth))
+		line = item
+	}
+
+	if line != "" {
+		lines = append(lines, truncate(face, line, maxWidth))
+	}
+
+	return lines
+}
@@ -0,0 +1,114 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/helper"
+	"exaroton-wa-bot/internal/i18n"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden images in testdata")
+
+// assertGolden compares a rendered card to testdata/<name>.png, run the tests
+// with -update after an intended change and check the new images.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".png")
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	if !bytes.Equal(want, got) {
+		t.Errorf("%s differs from the golden image, run the tests with -update if intended", path)
+	}
+}
+
+func onlineServer() *dto.ExarotonServerInfo {
+	return &dto.ExarotonServerInfo{
+		ID:      "abc123",
+		Name:    "Survival",
+		Address: "survival.exaroton.me",
+		Status:  dto.ServerStatusOnline,
+		Host:    helper.Ptr("host.exaroton.me"),
+		Port:    helper.Ptr(25565),
+		Players: dto.ExarotonServerPlayers{
+			Max:   20,
+			Count: 14,
+			List: []string{
+				"Steve", "Alex", "Notch", "jeb_", "Dinnerbone", "Grumm", "Herobrine",
+				"xXx_Slayer_xXx", "CaptainSparklez", "DanTDM", "Technoblade", "Dream", "Sapnap", "GeorgeNotFound",
+			},
+		},
+		Software: &dto.ExarotonServerSoftware{ID: "paper", Name: "Paper", Version: "1.21.4"},
+	}
+}
+
+func TestServerCard(t *testing.T) {
+	card, err := ServerCard(i18n.EN, onlineServer(), 1)
+	require.NoError(t, err)
+	assertGolden(t, "server_card", card)
+
+	// deterministic
+	again, err := ServerCard(i18n.EN, onlineServer(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, card, again)
+}
+
+func TestServerCard_Lang(t *testing.T) {
+	// the labels and the status are in the chat's language
+	card, err := ServerCard(i18n.ID, onlineServer(), 1)
+	require.NoError(t, err)
+	assertGolden(t, "server_card_id", card)
+}
+
+func TestStatusCard(t *testing.T) {
+	statuses := []*dto.ExarotonServerStatus{
+		{Idx: 0, Server: onlineServer()},
+		{Idx: 1, Server: &dto.ExarotonServerInfo{
+			Name:     "Creative with a very long name that doesn't fit on the card at all",
+			Address:  "creative.exaroton.me",
+			Status:   dto.ServerStatusOffline,
+			Players:  dto.ExarotonServerPlayers{Max: 10},
+			Software: &dto.ExarotonServerSoftware{Name: "Vanilla", Version: "1.20.1"},
+		}},
+		{Idx: 2, Server: &dto.ExarotonServerInfo{
+			Name:    "Modded",
+			Address: "modded.exaroton.me",
+			Status:  dto.ServerStatusStarting,
+		}, Err: errors.New("timeout")},
+	}
+
+	card, err := StatusCard(i18n.EN, statuses)
+	require.NoError(t, err)
+	assertGolden(t, "status_card", card)
+}
+
+func TestWrapList(t *testing.T) {
+	f, err := newFaces()
+	require.NoError(t, err)
+
+	items := []string{"Steve", "Alex", "Notch", "Dinnerbone", "Herobrine"}
+	width := measure(f.small, "Steve, Alex, Notch,")
+
+	assert.Equal(t, []string{"Steve, Alex, Notch,", "Dinnerbone,", "Herobrine"}, wrapList(i18n.EN, f.small, items, width, 3))
+
+	// the rest is counted on the last line, truncated if needed
+	wider := max(width, measure(f.small, "Dinnerbone, +1 more"))
+	assert.Equal(t, []string{"Steve, Alex, Notch,", "Dinnerbone, +1 more"}, wrapList(i18n.EN, f.small, items, wider, 2))
+	assert.Equal(t, []string{"Steve, Alex, Notch,", "Dinnerb… +1 more"}, wrapList(i18n.EN, f.small, items, width, 2))
+	assert.Equal(t, []string{"Steve, Alex, Notch, +2 more"}, wrapList(i18n.EN, f.small, items, measure(f.small, "Steve, Alex, Notch, +2 more"), 1))
+	assert.Empty(t, wrapList(i18n.EN, f.small, nil, width, 3))
+}
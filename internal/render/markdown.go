@@ -0,0 +1,103 @@
+package render
+
+import (
+	"exaroton-wa-bot/internal/dto"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Bold formats s as WhatsApp bold, "" stays empty.
+func Bold(s string) string {
+	return wrap("*", s)
+}
+
+// Italic formats s as WhatsApp italic.
+func Italic(s string) string {
+	return wrap("_", s)
+}
+
+// Strike formats s as WhatsApp strikethrough.
+func Strike(s string) string {
+	return wrap("~", s)
+}
+
+// Code formats s as WhatsApp inline code.
+func Code(s string) string {
+	return wrap("`", s)
+}
+
+// Mono formats s as a WhatsApp monospace block, e.g. for aligned columns.
+func Mono(s string) string {
+	return wrap("```", s)
+}
+
+// List formats items as a WhatsApp bulleted list, one item per line.
+func List(items []string) string {
+	return prefixLines(items, func(int) string { return "- " })
+}
+
+// NumberedList formats items as a WhatsApp numbered list, from 1.
+func NumberedList(items []string) string {
+	return prefixLines(items, func(i int) string { return strconv.Itoa(i+1) + ". " })
+}
+
+// Quote formats s as a WhatsApp quote, every line is quoted.
+func Quote(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return prefixLines(strings.Split(s, "\n"), func(int) string { return "> " })
+}
+
+// StatusEmoji returns the emoji of a server status: 🟢 online, 🔴 offline,
+// 💥 crashed and 🟡 in between.
+func StatusEmoji(s dto.ServerStatus) string {
+	switch s {
+	case dto.ServerStatusOnline:
+		return "🟢"
+	case dto.ServerStatusOffline:
+		return "🔴"
+	case dto.ServerStatusCrashed:
+		return "💥"
+	}
+
+	return "🟡"
+}
+
+// templateFuncs are the helpers available in message templates.
+var templateFuncs = template.FuncMap{
+	"bold":        Bold,
+	"italic":      Italic,
+	"strike":      Strike,
+	"code":        Code,
+	"mono":        Mono,
+	"list":        List,
+	"numbered":    NumberedList,
+	"quote":       Quote,
+	"statusEmoji": StatusEmoji,
+	"join":        func(items []string, sep string) string { return strings.Join(items, sep) },
+	"upper":       strings.ToUpper,
+	"add":         func(a, b int) int { return a + b },
+}
+
+func wrap(mark, s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return mark + s + mark
+}
+
+func prefixLines(lines []string, prefix func(i int) string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(prefix(i) + line)
+	}
+
+	return b.String()
+}
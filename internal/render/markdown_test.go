@@ -0,0 +1,29 @@
+package render
+
+import (
+	"exaroton-wa-bot/internal/dto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdown(t *testing.T) {
+	assert.Equal(t, "*Survival*", Bold("Survival"))
+	assert.Equal(t, "_note_", Italic("note"))
+	assert.Equal(t, "~old~", Strike("old"))
+	assert.Equal(t, "`/start 1`", Code("/start 1"))
+	assert.Equal(t, "```a  b```", Mono("a  b"))
+	assert.Equal(t, "", Bold(""), "empty stays empty")
+
+	assert.Equal(t, "- Steve\n- Alex", List([]string{"Steve", "Alex"}))
+	assert.Equal(t, "1. Steve\n2. Alex", NumberedList([]string{"Steve", "Alex"}))
+	assert.Equal(t, "", List(nil))
+	assert.Equal(t, "> a\n> b", Quote("a\nb"))
+}
+
+func TestStatusEmoji(t *testing.T) {
+	assert.Equal(t, "🟢", StatusEmoji(dto.ServerStatusOnline))
+	assert.Equal(t, "🔴", StatusEmoji(dto.ServerStatusOffline))
+	assert.Equal(t, "💥", StatusEmoji(dto.ServerStatusCrashed))
+	assert.Equal(t, "🟡", StatusEmoji(dto.ServerStatusStarting))
+}
@@ -0,0 +1,246 @@
+package render
+
+import (
+	"embed"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Message template names, each command renders its own from structured data.
+const (
+	TmplHelpPage         = "help_page"
+	TmplHelpCommand      = "help_command"
+	TmplServers          = "servers"
+	TmplInfo             = "info"
+	TmplPlayers          = "players"
+	TmplStatus           = "status"
+	TmplJobs             = "jobs"
+	TmplJobCancelling    = "job_cancelling"
+	TmplServerStopping   = "server_stopping"
+	TmplServerRestarting = "server_restarting"
+	TmplStartProgress    = "start_progress"
+	TmplStartFinish      = "start_finish"
+	TmplWhoAmI           = "whoami"
+	TmplLang             = "lang"
+	TmplRegister         = "register"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// TemplateDef describes a message template, overrides are checked by
+// rendering its sample data.
+type TemplateDef struct {
+	Name   string
+	Help   string
+	Sample any
+}
+
+var sampleServer = &dto.ExarotonServerInfo{
+	ID:       "Tgkm0cKkrwbT3a9c",
+	Name:     "Survival",
+	Address:  "survival.exaroton.me",
+	Motd:     "Welcome!",
+	Status:   dto.ServerStatusOnline,
+	Players:  dto.ExarotonServerPlayers{Max: 20, Count: 2, List: []string{"Steve", "Alex"}},
+	Software: &dto.ExarotonServerSoftware{Name: "Paper", Version: "1.21.4"},
+}
+
+var templateDefs = []TemplateDef{
+	{TmplHelpPage, "/help, a page of the commands list", dto.HelpPageTmplData{
+		Command: "help", Page: 1, TotalPage: 2,
+		Commands: []dto.HelpEntryTmplData{{Name: "info", Help: "Check a server info by its ID"}},
+	}},
+	{TmplHelpCommand, "/help <command>, the detail of a command", dto.HelpCommandTmplData{
+		Name: "stop", Help: "Stop a server by its ID", Usage: "/stop <id>", Aliases: []string{"x"},
+		Role: "operator", Cooldown: "1 min per id", Detail: "Arguments:\n- id: the server ID, see /servers",
+	}},
+	{TmplServers, "/servers, a page of the servers list", dto.ServersTmplData{
+		Command: "servers", Page: 1, TotalPage: 1,
+		Servers: []dto.ServerTmplData{{Idx: 0, Server: sampleServer}},
+	}},
+	{TmplInfo, "/info, the details of a server", dto.ServerTmplData{Idx: 0, Server: sampleServer}},
+	{TmplPlayers, "/players, the players online on a server", dto.PlayersTmplData{Idx: 0, Players: []string{"Steve", "Alex"}}},
+	{TmplStatus, "/status, an overview of all servers", dto.StatusTmplData{
+		Command: "status",
+		Servers: []dto.ServerStatusTmplData{{Idx: 0, Server: sampleServer, Uptime: "1h 5m"}},
+	}},
+	{TmplJobs, "/jobs, the running jobs of the chat", dto.JobsTmplData{
+		Command: "jobs",
+		Jobs:    []dto.JobTmplData{{ID: 1, Name: "start server 0", Age: "12s"}},
+	}},
+	{TmplJobCancelling, "/cancel, a job is being cancelled", dto.JobIDTmplData{JobID: 1}},
+	{TmplServerStopping, "/stop, the server is stopping", dto.ServerIdxTmplData{Idx: 0}},
+	{TmplServerRestarting, "/restart, the server is restarting", dto.ServerIdxTmplData{Idx: 0}},
+	{TmplStartProgress, "/start, the progress of the start, edited as the status changes", dto.StartTmplData{
+		Idx: 0, Status: dto.ServerStatusStarting, JobID: 1,
+	}},
+	{TmplStartFinish, "/start, the start has finished", dto.StartTmplData{Idx: 0, Status: dto.ServerStatusOnline, JobID: 1}},
+	{TmplWhoAmI, "/whoami, the sender's ids and role", dto.WhoAmITmplData{IDs: []string{"6281234567890@s.whatsapp.net"}, Role: "player"}},
+	{TmplLang, "/lang, the language of the chat or its change", dto.LangTmplData{
+		Code: "en", Name: "English",
+		Langs: []dto.LangOptionTmplData{{Code: "en", Name: "English"}, {Code: "id", Name: "Bahasa Indonesia"}},
+	}},
+	{TmplRegister, "/register, the group is registered", dto.RegisterTmplData{Servers: []string{"#0 Survival", "#2 Creative"}}},
+}
+
+// TemplateDefs returns every message template.
+func TemplateDefs() []TemplateDef {
+	return append([]TemplateDef(nil), templateDefs...)
+}
+
+// Templates renders the message templates, using their override if set.
+type Templates struct {
+	sources  map[string]string // default template sources
+	defaults map[string]*template.Template
+
+	mu        sync.RWMutex
+	overrides map[string]*template.Template
+}
+
+// NewTemplates parses the default templates, it panics if one is invalid.
+func NewTemplates() *Templates {
+	t := &Templates{
+		sources:   make(map[string]string, len(templateDefs)),
+		defaults:  make(map[string]*template.Template, len(templateDefs)),
+		overrides: make(map[string]*template.Template),
+	}
+
+	for _, def := range templateDefs {
+		src, err := defaultTemplates.ReadFile("templates/" + def.Name + ".tmpl")
+		if err != nil {
+			panic(fmt.Sprintf("default template %s not found", def.Name))
+		}
+
+		t.sources[def.Name] = string(src)
+		t.defaults[def.Name] = template.Must(parseTemplate(def.Name, string(src)))
+	}
+
+	return t
+}
+
+// Default returns the source of a default template, "" if there's no such template.
+func (t *Templates) Default(name string) string {
+	return t.sources[name]
+}
+
+// Render renders a template with data, the messages of the i18n catalog are
+// translated in lang. An override that fails to render is logged and the
+// default is rendered instead.
+func (t *Templates) Render(lang i18n.Lang, name string, data any) (string, error) {
+	t.mu.RLock()
+	override := t.overrides[name]
+	t.mu.RUnlock()
+
+	if override != nil {
+		text, err := execute(override, lang, data)
+		if err == nil {
+			return text, nil
+		}
+		slog.Warn("failed to render template override, using the default", "template", name, "error", err.Error())
+	}
+
+	tmpl, ok := t.defaults[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errs.ErrTemplateNotFound, name)
+	}
+
+	return execute(tmpl, lang, data)
+}
+
+// Override replaces a default template, body is checked by CheckTemplate. An
+// empty body resets the template to its default.
+func (t *Templates) Override(name, body string) error {
+	if body == "" {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		delete(t.overrides, name)
+		return nil
+	}
+
+	tmpl, err := checkTemplate(name, body)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.overrides[name] = tmpl
+
+	return nil
+}
+
+// CheckTemplate parses the body of a template and renders the template's
+// sample data with it, e.g. to catch a misspelled field.
+func CheckTemplate(name, body string) error {
+	_, err := checkTemplate(name, body)
+	return err
+}
+
+func checkTemplate(name, body string) (*template.Template, error) {
+	idx := slices.IndexFunc(templateDefs, func(def TemplateDef) bool { return def.Name == name })
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: %s", errs.ErrTemplateNotFound, name)
+	}
+
+	tmpl, err := parseTemplate(name, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tmpl.Execute(io.Discard, templateDefs[idx].Sample); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+func parseTemplate(name, body string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs).Funcs(localeFuncs(i18n.Default)).Option("missingkey=error").Parse(body)
+}
+
+// execute renders tmpl in lang, on a clone as the translating funcs are
+// bound to the template.
+func execute(tmpl *template.Template, lang i18n.Lang, data any) (string, error) {
+	tmpl, err := tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Funcs(localeFuncs(lang)).Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
+
+// localeFuncs are the template helpers translating in lang: t and tn translate
+// a message of the i18n catalog (see i18n.T and i18n.N), status a server status.
+func localeFuncs(lang i18n.Lang) template.FuncMap {
+	return template.FuncMap{
+		"t":      func(id string, args ...any) string { return i18n.T(lang, id, args...) },
+		"tn":     func(id string, n int, args ...any) string { return i18n.N(lang, id, n, args...) },
+		"status": func(s dto.ServerStatus) string { return StatusName(lang, s) },
+	}
+}
+
+// StatusName translates a server status, e.g. "online".
+func StatusName(lang i18n.Lang, s dto.ServerStatus) string {
+	id := "status." + s.String()
+	if !i18n.Has(id) {
+		return s.String()
+	}
+
+	return i18n.T(lang, id)
+}
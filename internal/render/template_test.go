@@ -0,0 +1,108 @@
+package render
+
+import (
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplates_Defaults(t *testing.T) {
+	tmpls := NewTemplates()
+
+	for _, def := range TemplateDefs() {
+		t.Run(def.Name, func(t *testing.T) {
+			assert.NotEmpty(t, tmpls.Default(def.Name))
+
+			text, err := tmpls.Render(i18n.Default, def.Name, def.Sample)
+			require.NoError(t, err)
+			assert.NotEmpty(t, text)
+		})
+	}
+}
+
+func TestTemplates_Render(t *testing.T) {
+	tmpls := NewTemplates()
+	data := dto.WhoAmITmplData{IDs: []string{"6285@s.whatsapp.net", "77@lid"}, Role: "admin"}
+
+	text, err := tmpls.Render(i18n.Default, TmplWhoAmI, data)
+	require.NoError(t, err)
+	assert.Equal(t, "👤 You are 6285@s.whatsapp.net, 77@lid\nRole: *admin*", text)
+
+	_, err = tmpls.Render(i18n.Default, "nope", data)
+	assert.ErrorIs(t, err, errs.ErrTemplateNotFound)
+}
+
+func TestTemplates_RenderLang(t *testing.T) {
+	tmpls := NewTemplates()
+
+	for _, lang := range i18n.Langs() {
+		t.Run(lang.String(), func(t *testing.T) {
+			for _, def := range TemplateDefs() {
+				text, err := tmpls.Render(lang, def.Name, def.Sample)
+				require.NoError(t, err, def.Name)
+				assert.NotEmpty(t, text, def.Name)
+			}
+		})
+	}
+
+	text, err := tmpls.Render(i18n.ID, TmplServerStopping, dto.ServerIdxTmplData{Idx: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "Server 2 sedang dihentikan :)", text)
+
+	text, err = tmpls.Render(i18n.ID, TmplPlayers, dto.PlayersTmplData{Idx: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Tidak ada pemain online di server 1", text)
+
+	t.Run("overrides are translated too", func(t *testing.T) {
+		require.NoError(t, tmpls.Override(TmplInfo, "{{status .Server.Status}}"))
+
+		text, err := tmpls.Render(i18n.EN, TmplInfo, dto.ServerTmplData{Server: sampleServer})
+		require.NoError(t, err)
+		assert.Equal(t, i18n.T(i18n.EN, "status.online"), text)
+
+		text, err = tmpls.Render(i18n.ID, TmplInfo, dto.ServerTmplData{Server: sampleServer})
+		require.NoError(t, err)
+		assert.Equal(t, i18n.T(i18n.ID, "status.online"), text)
+	})
+}
+
+func TestTemplates_Override(t *testing.T) {
+	tmpls := NewTemplates()
+	data := dto.ServerIdxTmplData{Idx: 2}
+
+	require.NoError(t, tmpls.Override(TmplServerStopping, "{{bold \"Stopping\"}} server {{.Idx}} "))
+	text, err := tmpls.Render(i18n.Default, TmplServerStopping, data)
+	require.NoError(t, err)
+	assert.Equal(t, "*Stopping* server 2", text, "trimmed")
+
+	t.Run("invalid overrides are rejected", func(t *testing.T) {
+		assert.Error(t, tmpls.Override(TmplServerStopping, "{{.Idx"), "doesn't parse")
+		assert.Error(t, tmpls.Override(TmplServerStopping, "{{.Name}}"), "no such field")
+		assert.ErrorIs(t, tmpls.Override("nope", "hi"), errs.ErrTemplateNotFound)
+
+		text, err := tmpls.Render(i18n.Default, TmplServerStopping, data)
+		require.NoError(t, err)
+		assert.Equal(t, "*Stopping* server 2", text, "the previous override is kept")
+	})
+
+	t.Run("an override failing to render falls back to the default", func(t *testing.T) {
+		// renders the sample, but not without players
+		require.NoError(t, tmpls.Override(TmplPlayers, "{{index .Players 0}} is online"))
+
+		text, err := tmpls.Render(i18n.Default, TmplPlayers, dto.PlayersTmplData{Idx: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "No players online on server 1", text)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		require.NoError(t, tmpls.Override(TmplServerStopping, ""))
+
+		text, err := tmpls.Render(i18n.Default, TmplServerStopping, data)
+		require.NoError(t, err)
+		assert.Equal(t, "Server 2 is stopping :)", text)
+	})
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"exaroton-wa-bot/internal/dto"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IAlertRepo reaches the admins outside of WhatsApp, e.g. when the bot's
+// session is lost.
+type IAlertRepo interface {
+	// SendEmail sends a plain text email, the server must support STARTTLS
+	// if it requires a login.
+	SendEmail(ctx context.Context, cfg dto.SMTPConfig, subject, body string) error
+	// PostWebhook posts payload as JSON, a non 2xx response is an error.
+	PostWebhook(ctx context.Context, url string, payload any) error
+}
+
+type AlertRepo struct {
+	client *http.Client
+}
+
+func newAlertRepo() IAlertRepo {
+	return &AlertRepo{
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (r *AlertRepo) SendEmail(ctx context.Context, cfg dto.SMTPConfig, subject, body string) error {
+	msg := &bytes.Buffer{}
+	fmt.Fprintf(msg, "From: %s\r\n", cfg.From)
+	fmt.Fprintf(msg, "To: %s\r\n", strings.Join(cfg.To, ", "))
+	fmt.Fprintf(msg, "Subject: %s\r\n", encodeHeader(subject))
+	fmt.Fprintf(msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	if err := r.sendMail(ctx, cfg, msg.Bytes()); err != nil {
+		return fmt.Errorf("alert repo SendEmail error: %w", err)
+	}
+
+	return nil
+}
+
+// encodeHeader makes s safe as a header value: it can hold user input (e.g. a
+// device name), a line break would start another header.
+func encodeHeader(s string) string {
+	s = strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+	return mime.QEncoding.Encode("utf-8", s)
+}
+
+// sendMail is smtp.SendMail bounded by ctx: smtp.SendMail has no deadline, a
+// server that doesn't answer would block it forever.
+func (r *AlertRepo) sendMail(ctx context.Context, cfg dto.SMTPConfig, msg []byte) error {
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	c, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: cfg.Host}); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(cfg.From); err != nil {
+		return err
+	}
+	for _, to := range cfg.To {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+func (r *AlertRepo) PostWebhook(ctx context.Context, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("alert repo PostWebhook error: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("alert repo PostWebhook error: status %d", res.StatusCode)
+	}
+
+	return nil
+}
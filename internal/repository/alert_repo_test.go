@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"exaroton-wa-bot/internal/dto"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertRepo_PostWebhook(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+
+		if got["fail"] == true {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	repo := newAlertRepo()
+
+	require.NoError(t, repo.PostWebhook(context.Background(), srv.URL, map[string]any{"state": "logged_out"}))
+	assert.Equal(t, "logged_out", got["state"])
+
+	err := repo.PostWebhook(context.Background(), srv.URL, map[string]any{"fail": true})
+	assert.ErrorContains(t, err, "status 500")
+}
+
+// fakeSMTPServer answers the commands of one client, without STARTTLS or AUTH,
+// and sends the received message to data.
+func fakeSMTPServer(t *testing.T, data chan<- string) dto.SMTPConfig {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		fmt.Fprint(conn, "220 localhost ESMTP\r\n")
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			switch cmd := strings.ToUpper(strings.Fields(line)[0]); cmd {
+			case "EHLO":
+				fmt.Fprint(conn, "250 localhost\r\n")
+			case "DATA":
+				fmt.Fprint(conn, "354 go ahead\r\n")
+
+				var msg strings.Builder
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					msg.WriteString(line)
+				}
+				data <- msg.String()
+				fmt.Fprint(conn, "250 queued\r\n")
+			case "QUIT":
+				fmt.Fprint(conn, "221 bye\r\n")
+				return
+			default:
+				fmt.Fprint(conn, "250 ok\r\n")
+			}
+		}
+	}()
+
+	addr := l.Addr().(*net.TCPAddr)
+	return dto.SMTPConfig{Host: addr.IP.String(), Port: addr.Port, From: "bot@example.com", To: []string{"admin@example.com"}}
+}
+
+func TestAlertRepo_SendEmail(t *testing.T) {
+	repo := newAlertRepo()
+
+	t.Run("sends the email", func(t *testing.T) {
+		data := make(chan string, 1)
+		cfg := fakeSMTPServer(t, data)
+
+		require.NoError(t, repo.SendEmail(context.Background(), cfg, "Session lost", "logged out\nby the phone"))
+
+		msg := <-data
+		assert.Contains(t, msg, "Subject: Session lost\r\n")
+		assert.Contains(t, msg, "To: admin@example.com\r\n")
+		assert.Contains(t, msg, "logged out\r\nby the phone")
+	})
+
+	t.Run("the subject can't add headers", func(t *testing.T) {
+		data := make(chan string, 1)
+		cfg := fakeSMTPServer(t, data)
+
+		require.NoError(t, repo.SendEmail(context.Background(), cfg, "Session lost: Phone\r\nBcc: evil@example.com", "logged out"))
+
+		msg := <-data
+		assert.Contains(t, msg, "Subject: Session lost: Phone  Bcc: evil@example.com\r\n")
+		assert.NotContains(t, msg, "\r\nBcc:")
+	})
+
+	t.Run("encodes a non ascii subject", func(t *testing.T) {
+		data := make(chan string, 1)
+		cfg := fakeSMTPServer(t, data)
+
+		require.NoError(t, repo.SendEmail(context.Background(), cfg, "Session lost: Ponsel Budi 📱", "logged out"))
+
+		msg := <-data
+		assert.Contains(t, msg, "Subject: =?utf-8?q?Session_lost:_Ponsel_Budi_=F0=9F=93=B1?=\r\n")
+	})
+
+	t.Run("gives up once ctx is done", func(t *testing.T) {
+		// accepts the connection but never greets
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer l.Close()
+
+		addr := l.Addr().(*net.TCPAddr)
+		cfg := dto.SMTPConfig{Host: addr.IP.String(), Port: addr.Port, From: "bot@example.com", To: []string{"admin@example.com"}}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err = repo.SendEmail(ctx, cfg, "Session lost", "logged out")
+		assert.Error(t, err)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
@@ -20,6 +20,7 @@ type IExarotonRepo interface {
 	ListServers(ctx context.Context, apiKey string) ([]*dto.ExarotonServerInfo, error)
 	StartServer(ctx context.Context, apiKey string, serverID string, opt dto.StartExarotonServerReq) (err error)
 	StopServer(ctx context.Context, apiKey string, serverID string) (err error)
+	RestartServer(ctx context.Context, apiKey string, serverID string) (err error)
 	GetServerInfo(ctx context.Context, apiKey string, serverID string) (*dto.ExarotonServerInfo, error)
 	GetServerPlayerList(ctx context.Context, apiKey string, serverID string) (*dto.ExarotonServerPlayers, error)
 }
@@ -126,6 +127,21 @@ func (r *ExarotonRepo) StopServer(ctx context.Context, apiKey string, serverID s
 	return nil
 }
 
+func (r *ExarotonRepo) RestartServer(ctx context.Context, apiKey string, serverID string) (err error) {
+	client, err := exaroton.NewClient(apiKey)
+	if err != nil {
+		return err
+	}
+
+	serverAPI := client.Server(serverID)
+	raw, err := serverAPI.Restart(ctx)
+	if err := handleExarotonError(err, helper.Deref(raw).Error); err != nil {
+		return fmt.Errorf("exaroton repo RestartServer error: %w", err)
+	}
+
+	return nil
+}
+
 func (r *ExarotonRepo) GetServerInfo(ctx context.Context, apiKey string, serverID string) (*dto.ExarotonServerInfo, error) {
 	client, err := exaroton.NewClient(apiKey)
 	if err != nil {
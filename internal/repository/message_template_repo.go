@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/database/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IMessageTemplateRepo interface {
+	GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.MessageTemplate, error)
+	Upsert(ctx context.Context, tx *gorm.DB, tmpl *entity.MessageTemplate) error
+	Delete(ctx context.Context, tx *gorm.DB, name string) error
+}
+
+type MessageTemplateRepo struct{}
+
+func newMessageTemplateRepo() IMessageTemplateRepo {
+	return &MessageTemplateRepo{}
+}
+
+func (r *MessageTemplateRepo) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.MessageTemplate, error) {
+	tmpls := make([]*entity.MessageTemplate, 0)
+	if err := tx.Order("name").Find(&tmpls).Error; err != nil {
+		return nil, err
+	}
+
+	return tmpls, nil
+}
+
+func (r *MessageTemplateRepo) Upsert(ctx context.Context, tx *gorm.DB, tmpl *entity.MessageTemplate) error {
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(tmpl).Error
+}
+
+func (r *MessageTemplateRepo) Delete(ctx context.Context, tx *gorm.DB, name string) error {
+	return tx.Where("name = ?", name).Delete(&entity.MessageTemplate{}).Error
+}
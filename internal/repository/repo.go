@@ -8,17 +8,39 @@ import (
 
 type Repo struct {
 	WhatsappRepo       IWhatsappRepo
+	WhatsappDeviceRepo IWhatsappDeviceRepo
 	UserRepo           IUserRepo
 	ServerSettingsRepo IServerSettingsRepo
 	ExarotonRepo       IExarotonRepo
+
+	WhatsappGroupSettingsRepo IWhatsappGroupSettingsRepo
+	WhatsappStartVoteRepo     IWhatsappStartVoteRepo
+	WhatsappUserRoleRepo      IWhatsappUserRoleRepo
+	WhatsappRegistrationRepo  IWhatsappRegistrationRepo
+
+	MessageTemplateRepo IMessageTemplateRepo
+
+	WhatsappConnectionLogRepo IWhatsappConnectionLogRepo
+	AlertRepo                 IAlertRepo
 }
 
-func New(db *gorm.DB, waClient *waClient) (*Repo, error) {
+func New(db *gorm.DB, waDevices *WADevices) (*Repo, error) {
 	return &Repo{
-		WhatsappRepo:       newWhatsappRepo(waClient),
+		WhatsappRepo:       newWhatsappRepo(waDevices),
+		WhatsappDeviceRepo: newWhatsappDeviceRepo(),
 		UserRepo:           newUserRepo(),
 		ServerSettingsRepo: newServerSettingsRepo(),
 		ExarotonRepo:       newExarotonRepo(),
+
+		WhatsappGroupSettingsRepo: newWhatsappGroupSettingsRepo(),
+		WhatsappStartVoteRepo:     newWhatsappStartVoteRepo(),
+		WhatsappUserRoleRepo:      newWhatsappUserRoleRepo(),
+		WhatsappRegistrationRepo:  newWhatsappRegistrationRepo(),
+
+		MessageTemplateRepo: newMessageTemplateRepo(),
+
+		WhatsappConnectionLogRepo: newWhatsappConnectionLogRepo(),
+		AlertRepo:                 newAlertRepo(),
 	}, nil
 }
 
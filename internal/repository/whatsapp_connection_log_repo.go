@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IWhatsappConnectionLogRepo is the connection history of the device of the
+// context, see dto.WithWhatsappDevice.
+type IWhatsappConnectionLogRepo interface {
+	Create(ctx context.Context, tx *gorm.DB, log *entity.WhatsappConnectionLog) error
+	// GetLatest returns the latest entries, the newest first.
+	GetLatest(ctx context.Context, tx *gorm.DB, limit int) ([]*entity.WhatsappConnectionLog, error)
+	// DeleteBefore deletes the entries of every device older than t.
+	DeleteBefore(ctx context.Context, tx *gorm.DB, t time.Time) error
+}
+
+type WhatsappConnectionLogRepo struct{}
+
+func newWhatsappConnectionLogRepo() IWhatsappConnectionLogRepo {
+	return &WhatsappConnectionLogRepo{}
+}
+
+func (r *WhatsappConnectionLogRepo) Create(ctx context.Context, tx *gorm.DB, log *entity.WhatsappConnectionLog) error {
+	log.DeviceID = dto.WhatsappDeviceFromContext(ctx)
+
+	return tx.Create(log).Error
+}
+
+func (r *WhatsappConnectionLogRepo) GetLatest(ctx context.Context, tx *gorm.DB, limit int) ([]*entity.WhatsappConnectionLog, error) {
+	logs := make([]*entity.WhatsappConnectionLog, 0)
+
+	err := tx.Where("device_id = ?", dto.WhatsappDeviceFromContext(ctx)).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&logs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+func (r *WhatsappConnectionLogRepo) DeleteBefore(ctx context.Context, tx *gorm.DB, t time.Time) error {
+	return tx.Where("created_at < ?", t).Delete(&entity.WhatsappConnectionLog{}).Error
+}
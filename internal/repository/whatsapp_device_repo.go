@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/database/entity"
+
+	"gorm.io/gorm"
+)
+
+type IWhatsappDeviceRepo interface {
+	GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappDevice, error)
+	// Get returns nil if the device doesn't exist.
+	Get(ctx context.Context, tx *gorm.DB, id uint) (*entity.WhatsappDevice, error)
+	Create(ctx context.Context, tx *gorm.DB, device *entity.WhatsappDevice) error
+	UpdateJID(ctx context.Context, tx *gorm.DB, id uint, jid string) error
+
+	// Delete deletes a device with its whitelists, settings, roles, votes,
+	// connection history and registration codes.
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+}
+
+type WhatsappDeviceRepo struct{}
+
+func newWhatsappDeviceRepo() IWhatsappDeviceRepo {
+	return &WhatsappDeviceRepo{}
+}
+
+func (r *WhatsappDeviceRepo) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappDevice, error) {
+	devices := make([]*entity.WhatsappDevice, 0)
+	if err := tx.Order("id").Find(&devices).Error; err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+func (r *WhatsappDeviceRepo) Get(ctx context.Context, tx *gorm.DB, id uint) (*entity.WhatsappDevice, error) {
+	device := &entity.WhatsappDevice{}
+
+	err := tx.First(device, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return device, nil
+}
+
+func (r *WhatsappDeviceRepo) Create(ctx context.Context, tx *gorm.DB, device *entity.WhatsappDevice) error {
+	return tx.Create(device).Error
+}
+
+func (r *WhatsappDeviceRepo) UpdateJID(ctx context.Context, tx *gorm.DB, id uint, jid string) error {
+	return tx.Model(&entity.WhatsappDevice{ID: id}).Update("jid", jid).Error
+}
+
+func (r *WhatsappDeviceRepo) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	// the app db doesn't enforce foreign keys, the voters go first
+	err := tx.Where("vote_id IN (?)", tx.Model(&entity.WhatsappStartVote{}).Select("id").Where("device_id = ?", id)).
+		Delete(&entity.WhatsappStartVoteVoter{}).Error
+	if err != nil {
+		return err
+	}
+
+	scoped := []any{
+		&entity.WhatsappStartVote{},
+		&entity.WhatsappWhitelistedGroup{},
+		&entity.WhatsappWhitelistedUser{},
+		&entity.WhatsappGroupSettings{},
+		&entity.WhatsappUserRole{},
+		&entity.WhatsappConnectionLog{},
+		&entity.WhatsappRegistrationCode{},
+		&entity.WhatsappGroupRegistration{},
+	}
+	for _, model := range scoped {
+		if err := tx.Where("device_id = ?", id).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Delete(&entity.WhatsappDevice{}, id).Error
+}
@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/config/warouter"
+	"exaroton-wa-bot/internal/dto"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// WADevices holds the client of every opened device (bot account), all
+// backed by the same whatsmeow store container.
+//
+// defer DisconnectAll()
+type WADevices struct {
+	db *config.WhatsappDB
+
+	mu      sync.RWMutex
+	clients map[uint]*waClient // key: device id
+
+	hooksMu sync.RWMutex
+	onOpen  []func(deviceID uint, wa warouter.WhatsappService)
+	onClose []func(deviceID uint)
+	onJID   []func(deviceID uint, jid string)
+	onConn  []func(deviceID uint, evt dto.WhatsappConnEvent)
+}
+
+func NewWADevices(db *config.WhatsappDB) *WADevices {
+	return &WADevices{
+		db:      db,
+		clients: make(map[uint]*waClient),
+	}
+}
+
+// OnOpen registers a hook called once a device's client is created, e.g. to
+// start its router.
+func (d *WADevices) OnOpen(f func(deviceID uint, wa warouter.WhatsappService)) {
+	d.hooksMu.Lock()
+	defer d.hooksMu.Unlock()
+
+	d.onOpen = append(d.onOpen, f)
+}
+
+// OnClose registers a hook called before a device's client is disconnected
+// and dropped.
+func (d *WADevices) OnClose(f func(deviceID uint)) {
+	d.hooksMu.Lock()
+	defer d.hooksMu.Unlock()
+
+	d.onClose = append(d.onClose, f)
+}
+
+// OnJID registers a hook called when a device is paired (jid is its store
+// device) or logged out (jid is empty).
+func (d *WADevices) OnJID(f func(deviceID uint, jid string)) {
+	d.hooksMu.Lock()
+	defer d.hooksMu.Unlock()
+
+	d.onJID = append(d.onJID, f)
+}
+
+// OnConnEvent registers a hook called on the connection events of every
+// device, whatsmeow's own reconnects are disabled so the hook reconnects.
+func (d *WADevices) OnConnEvent(f func(deviceID uint, evt dto.WhatsappConnEvent)) {
+	d.hooksMu.Lock()
+	defer d.hooksMu.Unlock()
+
+	d.onConn = append(d.onConn, f)
+}
+
+// Open creates the client of a device from its store device, a new one is
+// created if jid is empty or isn't in the store anymore. It does nothing if
+// the device is already open.
+func (d *WADevices) Open(ctx context.Context, deviceID uint, jid string) error {
+	d.mu.Lock()
+	if _, ok := d.clients[deviceID]; ok {
+		d.mu.Unlock()
+		return nil
+	}
+
+	deviceStore, err := d.getStore(ctx, jid)
+	if err != nil {
+		d.mu.Unlock()
+		return err
+	}
+
+	client := newWAClient(deviceStore, d.db.ClientLogger)
+	client.RegisterEventHandler(d.jidEventHandler(deviceID))
+	client.RegisterEventHandler(d.connEventHandler(deviceID))
+	d.clients[deviceID] = client
+	d.mu.Unlock()
+
+	d.hooksMu.RLock()
+	defer d.hooksMu.RUnlock()
+	for _, f := range d.onOpen {
+		f(deviceID, client)
+	}
+
+	return nil
+}
+
+func (d *WADevices) getStore(ctx context.Context, jid string) (*store.Device, error) {
+	if jid == "" {
+		return d.db.Container.NewDevice(), nil
+	}
+
+	parsed, err := types.ParseJID(jid)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceStore, err := d.db.Container.GetDevice(ctx, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	if deviceStore == nil {
+		// logged out while the app was down
+		slog.WarnContext(ctx, "whatsapp store device not found, creating a new one", "jid", jid)
+		return d.db.Container.NewDevice(), nil
+	}
+
+	return deviceStore, nil
+}
+
+func (d *WADevices) jidEventHandler(deviceID uint) func(evt any) {
+	return func(evt any) {
+		switch v := evt.(type) {
+		case *events.PairSuccess:
+			d.notifyJID(deviceID, v.ID.String())
+		case *events.LoggedOut:
+			d.notifyJID(deviceID, "")
+		}
+	}
+}
+
+func (d *WADevices) connEventHandler(deviceID uint) func(evt any) {
+	return func(evt any) {
+		if connEvt, ok := newConnEvent(evt); ok {
+			d.notifyConn(deviceID, connEvt)
+		}
+	}
+}
+
+func newConnEvent(evt any) (dto.WhatsappConnEvent, bool) {
+	switch v := evt.(type) {
+	case *events.Connected:
+		return dto.WhatsappConnEvent{Type: dto.WAConnEventConnected}, true
+	case *events.Disconnected:
+		return dto.WhatsappConnEvent{Type: dto.WAConnEventDisconnected}, true
+	case *events.LoggedOut:
+		return dto.WhatsappConnEvent{Type: dto.WAConnEventLoggedOut, Reason: v.PermanentDisconnectDescription()}, true
+	case *events.StreamReplaced:
+		return dto.WhatsappConnEvent{Type: dto.WAConnEventStreamReplaced}, true
+	case *events.TemporaryBan:
+		return dto.WhatsappConnEvent{Type: dto.WAConnEventTemporaryBan, Reason: v.Code.String(), Expire: v.Expire}, true
+	case *events.ClientOutdated:
+		return dto.WhatsappConnEvent{Type: dto.WAConnEventClientOutdated}, true
+	case *events.ConnectFailure:
+		return dto.WhatsappConnEvent{Type: dto.WAConnEventConnectFailure, Reason: fmt.Sprintf("%s %s", v.Reason, v.Message)}, true
+	case *events.CATRefreshError:
+		return dto.WhatsappConnEvent{Type: dto.WAConnEventConnectFailure, Reason: v.PermanentDisconnectDescription()}, true
+	case *events.KeepAliveTimeout:
+		return dto.WhatsappConnEvent{Type: dto.WAConnEventKeepAliveTimeout, Reason: fmt.Sprintf("%d failed pings", v.ErrorCount)}, true
+	case *events.KeepAliveRestored:
+		return dto.WhatsappConnEvent{Type: dto.WAConnEventKeepAliveRestored}, true
+	}
+
+	return dto.WhatsappConnEvent{}, false
+}
+
+func (d *WADevices) notifyConn(deviceID uint, evt dto.WhatsappConnEvent) {
+	d.hooksMu.RLock()
+	defer d.hooksMu.RUnlock()
+
+	for _, f := range d.onConn {
+		f(deviceID, evt)
+	}
+}
+
+func (d *WADevices) notifyJID(deviceID uint, jid string) {
+	d.hooksMu.RLock()
+	defer d.hooksMu.RUnlock()
+
+	for _, f := range d.onJID {
+		f(deviceID, jid)
+	}
+}
+
+// Close disconnects a device's client and drops it, the store device is
+// kept. It does nothing if the device isn't open.
+func (d *WADevices) Close(deviceID uint) {
+	d.mu.Lock()
+	client, ok := d.clients[deviceID]
+	delete(d.clients, deviceID)
+	d.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	d.hooksMu.RLock()
+	for _, f := range d.onClose {
+		f(deviceID)
+	}
+	d.hooksMu.RUnlock()
+
+	client.Disconnect()
+}
+
+// DeleteStored deletes the store device of jid, so it isn't adopted by
+// another device. It does nothing if jid isn't in the store.
+func (d *WADevices) DeleteStored(ctx context.Context, jid string) error {
+	parsed, err := types.ParseJID(jid)
+	if err != nil {
+		return err
+	}
+
+	deviceStore, err := d.db.Container.GetDevice(ctx, parsed)
+	if err != nil || deviceStore == nil {
+		return err
+	}
+
+	return d.db.Container.DeleteDevice(ctx, deviceStore)
+}
+
+// Client returns the client of a device, nil if it isn't open.
+func (d *WADevices) Client(deviceID uint) *waClient {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.clients[deviceID]
+}
+
+// IDs returns the ids of the opened devices, in ascending order.
+func (d *WADevices) IDs() []uint {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	ids := make([]uint, 0, len(d.clients))
+	for id := range d.clients {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// StoredJIDs returns the jids of every paired store device.
+func (d *WADevices) StoredJIDs(ctx context.Context) ([]string, error) {
+	devices, err := d.db.Container.GetAllDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jids := make([]string, 0, len(devices))
+	for _, device := range devices {
+		if device.ID != nil {
+			jids = append(jids, device.ID.String())
+		}
+	}
+
+	return jids, nil
+}
+
+// DisconnectAll disconnects the client of every device.
+func (d *WADevices) DisconnectAll() {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, client := range d.clients {
+		client.Disconnect()
+	}
+}
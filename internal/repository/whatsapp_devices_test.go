@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"exaroton-wa-bot/internal/dto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestNewConnEvent(t *testing.T) {
+	tests := []struct {
+		name string
+		evt  any
+		want dto.WhatsappConnEvent
+		ok   bool
+	}{
+		{"connected", &events.Connected{}, dto.WhatsappConnEvent{Type: dto.WAConnEventConnected}, true},
+		{"disconnected", &events.Disconnected{}, dto.WhatsappConnEvent{Type: dto.WAConnEventDisconnected}, true},
+		{
+			"logged out",
+			&events.LoggedOut{OnConnect: true, Reason: events.ConnectFailureLoggedOut},
+			dto.WhatsappConnEvent{Type: dto.WAConnEventLoggedOut, Reason: events.ConnectFailureLoggedOut.String()},
+			true,
+		},
+		{"stream replaced", &events.StreamReplaced{}, dto.WhatsappConnEvent{Type: dto.WAConnEventStreamReplaced}, true},
+		{
+			"temporary ban",
+			&events.TemporaryBan{Code: events.TempBanSentToTooManyPeople, Expire: time.Hour},
+			dto.WhatsappConnEvent{Type: dto.WAConnEventTemporaryBan, Reason: events.TempBanSentToTooManyPeople.String(), Expire: time.Hour},
+			true,
+		},
+		{"client outdated", &events.ClientOutdated{}, dto.WhatsappConnEvent{Type: dto.WAConnEventClientOutdated}, true},
+		{
+			"keepalive timeout",
+			&events.KeepAliveTimeout{ErrorCount: 3},
+			dto.WhatsappConnEvent{Type: dto.WAConnEventKeepAliveTimeout, Reason: "3 failed pings"},
+			true,
+		},
+		{"not a connection event", &events.Message{}, dto.WhatsappConnEvent{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := newConnEvent(tt.evt)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IWhatsappGroupSettingsRepo reads and writes the settings of the context's device.
+type IWhatsappGroupSettingsRepo interface {
+	// GetAll returns every setting of a group, empty if none is set.
+	GetAll(ctx context.Context, tx *gorm.DB, group dto.WhatsappJID) ([]*entity.WhatsappGroupSettings, error)
+	Upsert(ctx context.Context, tx *gorm.DB, settings ...*entity.WhatsappGroupSettings) error
+}
+
+type WhatsappGroupSettingsRepo struct{}
+
+func newWhatsappGroupSettingsRepo() IWhatsappGroupSettingsRepo {
+	return &WhatsappGroupSettingsRepo{}
+}
+
+func (r *WhatsappGroupSettingsRepo) GetAll(ctx context.Context, tx *gorm.DB, group dto.WhatsappJID) ([]*entity.WhatsappGroupSettings, error) {
+	settings := make([]*entity.WhatsappGroupSettings, 0)
+
+	err := tx.Where(&entity.WhatsappGroupSettings{
+		DeviceID:  dto.WhatsappDeviceFromContext(ctx),
+		JID:       group.User,
+		ServerJID: group.Server,
+	}).Find(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (r *WhatsappGroupSettingsRepo) Upsert(ctx context.Context, tx *gorm.DB, settings ...*entity.WhatsappGroupSettings) error {
+	if len(settings) == 0 {
+		return errors.New("upsert: settings cannot be empty")
+	}
+
+	for _, setting := range settings {
+		setting.DeviceID = dto.WhatsappDeviceFromContext(ctx)
+	}
+
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&settings).Error
+}
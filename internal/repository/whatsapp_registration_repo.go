@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IWhatsappRegistrationRepo is the registration codes of the device of the
+// context (see dto.WithWhatsappDevice), and the audit trail of their
+// redemptions.
+type IWhatsappRegistrationRepo interface {
+	CreateCode(ctx context.Context, tx *gorm.DB, code *entity.WhatsappRegistrationCode) error
+	// GetCodes returns the codes, the newest first.
+	GetCodes(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappRegistrationCode, error)
+	// GetCode returns nil if there is no such code.
+	GetCode(ctx context.Context, tx *gorm.DB, code string) (*entity.WhatsappRegistrationCode, error)
+	// RedeemCode marks a code redeemed, it returns false if it already was.
+	RedeemCode(ctx context.Context, tx *gorm.DB, id uint, at time.Time) (bool, error)
+	DeleteCode(ctx context.Context, tx *gorm.DB, id uint) error
+
+	CreateRegistration(ctx context.Context, tx *gorm.DB, registration *entity.WhatsappGroupRegistration) error
+	// GetRegistrations returns the latest redemptions, the newest first.
+	GetRegistrations(ctx context.Context, tx *gorm.DB, limit int) ([]*entity.WhatsappGroupRegistration, error)
+}
+
+type WhatsappRegistrationRepo struct{}
+
+func newWhatsappRegistrationRepo() IWhatsappRegistrationRepo {
+	return &WhatsappRegistrationRepo{}
+}
+
+func (r *WhatsappRegistrationRepo) CreateCode(ctx context.Context, tx *gorm.DB, code *entity.WhatsappRegistrationCode) error {
+	code.DeviceID = dto.WhatsappDeviceFromContext(ctx)
+
+	return tx.Create(code).Error
+}
+
+func (r *WhatsappRegistrationRepo) GetCodes(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappRegistrationCode, error) {
+	codes := make([]*entity.WhatsappRegistrationCode, 0)
+
+	err := tx.Where("device_id = ?", dto.WhatsappDeviceFromContext(ctx)).
+		Order("created_at DESC, id DESC").
+		Find(&codes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (r *WhatsappRegistrationRepo) GetCode(ctx context.Context, tx *gorm.DB, code string) (*entity.WhatsappRegistrationCode, error) {
+	res := &entity.WhatsappRegistrationCode{}
+
+	err := tx.Where("device_id = ? AND code = ?", dto.WhatsappDeviceFromContext(ctx), code).First(res).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (r *WhatsappRegistrationRepo) RedeemCode(ctx context.Context, tx *gorm.DB, id uint, at time.Time) (bool, error) {
+	// the check is in the update, a code can't be redeemed twice at once
+	res := tx.Model(&entity.WhatsappRegistrationCode{}).
+		Where("id = ? AND device_id = ? AND redeemed_at IS NULL", id, dto.WhatsappDeviceFromContext(ctx)).
+		Update("redeemed_at", at)
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+func (r *WhatsappRegistrationRepo) DeleteCode(ctx context.Context, tx *gorm.DB, id uint) error {
+	return tx.Where("id = ? AND device_id = ?", id, dto.WhatsappDeviceFromContext(ctx)).
+		Delete(&entity.WhatsappRegistrationCode{}).Error
+}
+
+func (r *WhatsappRegistrationRepo) CreateRegistration(ctx context.Context, tx *gorm.DB, registration *entity.WhatsappGroupRegistration) error {
+	registration.DeviceID = dto.WhatsappDeviceFromContext(ctx)
+
+	return tx.Create(registration).Error
+}
+
+func (r *WhatsappRegistrationRepo) GetRegistrations(ctx context.Context, tx *gorm.DB, limit int) ([]*entity.WhatsappGroupRegistration, error) {
+	registrations := make([]*entity.WhatsappGroupRegistration, 0)
+
+	err := tx.Where("device_id = ?", dto.WhatsappDeviceFromContext(ctx)).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&registrations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return registrations, nil
+}
@@ -2,102 +2,300 @@ package repository
 
 import (
 	"context"
+	"exaroton-wa-bot/internal/constants/errs"
 	"exaroton-wa-bot/internal/database/entity"
 	"exaroton-wa-bot/internal/dto"
 
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// IWhatsappRepo is scoped to the device of the context (see
+// dto.WithWhatsappDevice), both its client and its rows.
 type IWhatsappRepo interface {
-	RegisterEventHandler(f func(any)) uint32
-	UnregisterEventHandler(handlerID uint32) bool
+	// Disconnect disconnects every device.
 	Disconnect()
 	Login(ctx context.Context) (<-chan whatsmeow.QRChannelItem, error)
-	// LoginWithNumber(ctx context.Context, phone string, showPushNotification bool, clientDisplayName string) (string, error)
+	LoginWithNumber(ctx context.Context, phone string) (string, <-chan whatsmeow.QRChannelItem, error)
+	// LoginWithExistingSession connects if the device is paired, it returns
+	// false if it isn't or is already logged in.
+	LoginWithExistingSession(ctx context.Context) (bool, error)
+	// Reconnect connects a paired device again once its connection dropped.
+	Reconnect(ctx context.Context) error
 	Logout(ctx context.Context) error
-	IsLoggedIn() bool
-	GetPhoneNumber() string // self
-	GetSelfLID() *dto.WhatsappJID
+	IsLoggedIn(ctx context.Context) bool
+	GetPhoneNumber(ctx context.Context) string // self
+	GetSelfLID(ctx context.Context) *dto.WhatsappJID
 	GetGroups(ctx context.Context) ([]*types.GroupInfo, error)
+	GetGroupInfo(ctx context.Context, group dto.WhatsappJID) (*types.GroupInfo, error)
+	// GetGroupInfoFromLink previews the group of an invite link, the link can
+	// be the whole chat.whatsapp.com url or only its code.
+	GetGroupInfoFromLink(ctx context.Context, link string) (*types.GroupInfo, error)
+	JoinGroupWithLink(ctx context.Context, link string) (dto.WhatsappJID, error)
 	GetWhitelistedGroupJIDs(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappWhitelistedGroup, error)
-	WhitelistGroup(ctx context.Context, tx *gorm.DB, req *dto.WhitelistWhatsappGroupReq) error
+	WhitelistGroup(ctx context.Context, tx *gorm.DB, group *entity.WhatsappWhitelistedGroup) error
 	UnwhitelistGroup(ctx context.Context, tx *gorm.DB, req *dto.UnwhitelistWhatsappGroupReq) error
+	// UpdateWhitelistedGroup saves the info of a whitelisted group, its name,
+	// topic, last seen and left time.
+	UpdateWhitelistedGroup(ctx context.Context, tx *gorm.DB, group *entity.WhatsappWhitelistedGroup) error
+	GetWhitelistedUsers(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappWhitelistedUser, error)
+	WhitelistUser(ctx context.Context, tx *gorm.DB, req *dto.WhitelistWhatsappUserReq) error
+	UnwhitelistUser(ctx context.Context, tx *gorm.DB, req *dto.UnwhitelistWhatsappUserReq) error
 
 	// IsSyncComplete returns true if the sync is complete and false otherwise.
 	IsSyncComplete(ctx context.Context) bool
+
+	// OpenDevice creates the client of a device from its paired store device,
+	// jid is empty if it isn't paired yet.
+	OpenDevice(ctx context.Context, deviceID uint, jid string) error
+	// CloseDevice disconnects a device and drops its client.
+	CloseDevice(deviceID uint)
+	// DeleteStoredDevice deletes the store device of jid, e.g. of a device
+	// removed while logged out. It does nothing if jid isn't in the store.
+	DeleteStoredDevice(ctx context.Context, jid string) error
+	// GetDeviceStatus is empty if the device isn't open.
+	GetDeviceStatus(ctx context.Context, deviceID uint) dto.WhatsappDeviceStatus
+	// GetStoredDeviceJIDs returns the jids of every paired store device.
+	GetStoredDeviceJIDs(ctx context.Context) ([]string, error)
+	// OnDeviceJID registers a hook called when a device is paired (jid is
+	// its store device) or logged out (jid is empty).
+	OnDeviceJID(f func(deviceID uint, jid string))
+	// OnConnectionEvent registers a hook called on the connection events of
+	// every device, e.g. disconnected or logged out from the phone.
+	OnConnectionEvent(f func(deviceID uint, evt dto.WhatsappConnEvent))
 }
 
 type whatsappRepo struct {
-	waClient *waClient // represents a single whatsapp device/account.
+	devices *WADevices
 }
 
-func newWhatsappRepo(waClient *waClient) IWhatsappRepo {
+func newWhatsappRepo(devices *WADevices) IWhatsappRepo {
 	return &whatsappRepo{
-		waClient: waClient,
+		devices: devices,
 	}
 }
 
-func (r *whatsappRepo) RegisterEventHandler(f func(any)) uint32 {
-	return r.waClient.RegisterEventHandler(f)
-}
-
-func (r *whatsappRepo) UnregisterEventHandler(handlerID uint32) bool {
-	return r.waClient.UnregisterEventHandler(handlerID)
+// client returns the client of the context's device, nil if it isn't open.
+func (r *whatsappRepo) client(ctx context.Context) *waClient {
+	return r.devices.Client(dto.WhatsappDeviceFromContext(ctx))
 }
 
 func (r *whatsappRepo) Disconnect() {
-	r.waClient.Disconnect()
+	r.devices.DisconnectAll()
 }
 
 func (r *whatsappRepo) Login(ctx context.Context) (<-chan whatsmeow.QRChannelItem, error) {
-	return r.waClient.Login(ctx)
+	client := r.client(ctx)
+	if client == nil {
+		return nil, errs.ErrWADeviceNotFound
+	}
+
+	return client.Login(ctx)
+}
+
+func (r *whatsappRepo) LoginWithNumber(ctx context.Context, phone string) (string, <-chan whatsmeow.QRChannelItem, error) {
+	client := r.client(ctx)
+	if client == nil {
+		return "", nil, errs.ErrWADeviceNotFound
+	}
+
+	return client.LoginWithNumber(ctx, phone)
+}
+
+func (r *whatsappRepo) LoginWithExistingSession(ctx context.Context) (bool, error) {
+	client := r.client(ctx)
+	if client == nil {
+		return false, errs.ErrWADeviceNotFound
+	}
+
+	return client.LoginWithExistingSession(ctx)
+}
+
+func (r *whatsappRepo) Reconnect(ctx context.Context) error {
+	client := r.client(ctx)
+	if client == nil {
+		return errs.ErrWADeviceNotFound
+	}
+
+	return client.Reconnect(ctx)
 }
 
 func (r *whatsappRepo) Logout(ctx context.Context) error {
-	return r.waClient.Logout(ctx)
+	client := r.client(ctx)
+	if client == nil {
+		return errs.ErrWADeviceNotFound
+	}
+
+	if err := client.Logout(ctx); err != nil {
+		return err
+	}
+
+	// whatsmeow doesn't dispatch events.LoggedOut for its own logout
+	deviceID := dto.WhatsappDeviceFromContext(ctx)
+	r.devices.notifyJID(deviceID, "")
+	r.devices.notifyConn(deviceID, dto.WhatsappConnEvent{Type: dto.WAConnEventLogout})
+
+	return nil
 }
 
-func (r *whatsappRepo) IsLoggedIn() bool {
-	return r.waClient.IsLoggedIn()
+func (r *whatsappRepo) IsLoggedIn(ctx context.Context) bool {
+	client := r.client(ctx)
+	return client != nil && client.IsLoggedIn()
 }
 
-func (r *whatsappRepo) GetPhoneNumber() string {
-	return r.waClient.GetPhoneNumber()
+func (r *whatsappRepo) GetPhoneNumber(ctx context.Context) string {
+	client := r.client(ctx)
+	if client == nil {
+		return ""
+	}
+
+	return client.GetPhoneNumber()
 }
 
-func (r *whatsappRepo) GetSelfLID() *dto.WhatsappJID {
-	return r.waClient.GetSelfLID()
+func (r *whatsappRepo) GetSelfLID(ctx context.Context) *dto.WhatsappJID {
+	client := r.client(ctx)
+	if client == nil {
+		return nil
+	}
+
+	return client.GetSelfLID()
 }
 
 func (r *whatsappRepo) GetGroups(ctx context.Context) ([]*types.GroupInfo, error) {
-	return r.waClient.GetGroups(ctx)
+	client := r.client(ctx)
+	if client == nil {
+		return nil, errs.ErrWADeviceNotFound
+	}
+
+	return client.GetGroups(ctx)
+}
+
+func (r *whatsappRepo) GetGroupInfo(ctx context.Context, group dto.WhatsappJID) (*types.GroupInfo, error) {
+	client := r.client(ctx)
+	if client == nil {
+		return nil, errs.ErrWADeviceNotFound
+	}
+
+	return client.GetGroupInfo(ctx, group)
+}
+
+func (r *whatsappRepo) GetGroupInfoFromLink(ctx context.Context, link string) (*types.GroupInfo, error) {
+	client := r.client(ctx)
+	if client == nil {
+		return nil, errs.ErrWADeviceNotFound
+	}
+
+	return client.GetGroupInfoFromLink(ctx, link)
+}
+
+func (r *whatsappRepo) JoinGroupWithLink(ctx context.Context, link string) (dto.WhatsappJID, error) {
+	client := r.client(ctx)
+	if client == nil {
+		return dto.WhatsappJID{}, errs.ErrWADeviceNotFound
+	}
+
+	return client.JoinGroupWithLink(ctx, link)
 }
 
 func (r *whatsappRepo) GetWhitelistedGroupJIDs(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappWhitelistedGroup, error) {
 	whitelistedGroups := make([]*entity.WhatsappWhitelistedGroup, 0)
-	if err := tx.Find(&whitelistedGroups).Error; err != nil {
+	err := tx.Where("device_id = ?", dto.WhatsappDeviceFromContext(ctx)).Find(&whitelistedGroups).Error
+	if err != nil {
 		return nil, err
 	}
 
 	return whitelistedGroups, nil
 }
 
-func (r *whatsappRepo) WhitelistGroup(ctx context.Context, tx *gorm.DB, req *dto.WhitelistWhatsappGroupReq) error {
-	return tx.Create(entity.WhatsappWhitelistedGroup{
-		JID:       req.User,
-		ServerJID: req.Server,
-	}).Error
+func (r *whatsappRepo) WhitelistGroup(ctx context.Context, tx *gorm.DB, group *entity.WhatsappWhitelistedGroup) error {
+	group.DeviceID = dto.WhatsappDeviceFromContext(ctx)
+
+	// the info of a whitelisted group is updated
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(group).Error
 }
 
 func (r *whatsappRepo) UnwhitelistGroup(ctx context.Context, tx *gorm.DB, req *dto.UnwhitelistWhatsappGroupReq) error {
 	return tx.Where(entity.WhatsappWhitelistedGroup{
+		DeviceID:  dto.WhatsappDeviceFromContext(ctx),
 		JID:       req.User,
 		ServerJID: req.Server,
 	}).Delete(&entity.WhatsappWhitelistedGroup{}).Error
 }
 
+func (r *whatsappRepo) UpdateWhitelistedGroup(ctx context.Context, tx *gorm.DB, group *entity.WhatsappWhitelistedGroup) error {
+	return tx.Model(&entity.WhatsappWhitelistedGroup{}).
+		Where("device_id = ? AND jid = ? AND server_jid = ?", dto.WhatsappDeviceFromContext(ctx), group.JID, group.ServerJID).
+		Select("name", "topic", "last_seen_at", "left_at").
+		Updates(group).Error
+}
+
+func (r *whatsappRepo) GetWhitelistedUsers(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappWhitelistedUser, error) {
+	users := make([]*entity.WhatsappWhitelistedUser, 0)
+	err := tx.Where("device_id = ?", dto.WhatsappDeviceFromContext(ctx)).Order("name, jid").Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *whatsappRepo) WhitelistUser(ctx context.Context, tx *gorm.DB, req *dto.WhitelistWhatsappUserReq) error {
+	// the name of a whitelisted user is updated
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&entity.WhatsappWhitelistedUser{
+		DeviceID: dto.WhatsappDeviceFromContext(ctx),
+		JID:      req.Phone,
+		Name:     req.Name,
+	}).Error
+}
+
+func (r *whatsappRepo) UnwhitelistUser(ctx context.Context, tx *gorm.DB, req *dto.UnwhitelistWhatsappUserReq) error {
+	return tx.Where(entity.WhatsappWhitelistedUser{
+		DeviceID: dto.WhatsappDeviceFromContext(ctx),
+		JID:      req.Phone,
+	}).Delete(&entity.WhatsappWhitelistedUser{}).Error
+}
+
 func (r *whatsappRepo) IsSyncComplete(ctx context.Context) bool {
-	return r.waClient.IsSyncComplete(ctx)
+	client := r.client(ctx)
+	return client != nil && client.IsSyncComplete(ctx)
+}
+
+func (r *whatsappRepo) OpenDevice(ctx context.Context, deviceID uint, jid string) error {
+	return r.devices.Open(ctx, deviceID, jid)
+}
+
+func (r *whatsappRepo) CloseDevice(deviceID uint) {
+	r.devices.Close(deviceID)
+}
+
+func (r *whatsappRepo) DeleteStoredDevice(ctx context.Context, jid string) error {
+	return r.devices.DeleteStored(ctx, jid)
+}
+
+func (r *whatsappRepo) GetDeviceStatus(ctx context.Context, deviceID uint) dto.WhatsappDeviceStatus {
+	client := r.devices.Client(deviceID)
+	if client == nil {
+		return dto.WhatsappDeviceStatus{}
+	}
+
+	return dto.WhatsappDeviceStatus{
+		LoggedIn:  client.IsLoggedIn(),
+		Connected: client.IsConnected(),
+		Synced:    client.IsSyncComplete(ctx),
+		Phone:     client.GetPhoneNumber(),
+	}
+}
+
+func (r *whatsappRepo) GetStoredDeviceJIDs(ctx context.Context) ([]string, error) {
+	return r.devices.StoredJIDs(ctx)
+}
+
+func (r *whatsappRepo) OnDeviceJID(f func(deviceID uint, jid string)) {
+	r.devices.OnJID(f)
+}
+
+func (r *whatsappRepo) OnConnectionEvent(f func(deviceID uint, evt dto.WhatsappConnEvent)) {
+	r.devices.OnConnEvent(f)
 }
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IWhatsappStartVoteRepo only sees the votes of the context's device.
+type IWhatsappStartVoteRepo interface {
+	// GetOpen returns the open vote of a chat for a server, nil if there is none.
+	GetOpen(ctx context.Context, tx *gorm.DB, chat dto.WhatsappJID, serverID string) (*entity.WhatsappStartVote, error)
+
+	// GetOpenByMessageID returns the open vote announced by a bot message, nil if there is none.
+	GetOpenByMessageID(ctx context.Context, tx *gorm.DB, chat dto.WhatsappJID, messageID string) (*entity.WhatsappStartVote, error)
+
+	ListOpenBefore(ctx context.Context, tx *gorm.DB, deadline time.Time) ([]*entity.WhatsappStartVote, error)
+	Create(ctx context.Context, tx *gorm.DB, vote *entity.WhatsappStartVote) error
+
+	// AddVoter returns false if the voter had already voted.
+	AddVoter(ctx context.Context, tx *gorm.DB, voteID uint, voter string) (bool, error)
+	UpdateStatus(ctx context.Context, tx *gorm.DB, voteID uint, status string) error
+	UpdateMessageID(ctx context.Context, tx *gorm.DB, voteID uint, messageID string) error
+}
+
+type WhatsappStartVoteRepo struct{}
+
+func newWhatsappStartVoteRepo() IWhatsappStartVoteRepo {
+	return &WhatsappStartVoteRepo{}
+}
+
+func (r *WhatsappStartVoteRepo) GetOpen(ctx context.Context, tx *gorm.DB, chat dto.WhatsappJID, serverID string) (*entity.WhatsappStartVote, error) {
+	return r.first(ctx, tx.Where("server_id = ?", serverID), chat)
+}
+
+func (r *WhatsappStartVoteRepo) GetOpenByMessageID(ctx context.Context, tx *gorm.DB, chat dto.WhatsappJID, messageID string) (*entity.WhatsappStartVote, error) {
+	if messageID == "" {
+		return nil, nil
+	}
+
+	return r.first(ctx, tx.Where("message_id = ?", messageID), chat)
+}
+
+func (r *WhatsappStartVoteRepo) first(ctx context.Context, tx *gorm.DB, chat dto.WhatsappJID) (*entity.WhatsappStartVote, error) {
+	vote := &entity.WhatsappStartVote{}
+
+	err := tx.Preload("Voters").
+		Where(&entity.WhatsappStartVote{
+			DeviceID:  dto.WhatsappDeviceFromContext(ctx),
+			JID:       chat.User,
+			ServerJID: chat.Server,
+			Status:    entity.StartVoteStatusOpen,
+		}).
+		Order("id DESC").
+		First(vote).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return vote, nil
+}
+
+func (r *WhatsappStartVoteRepo) ListOpenBefore(ctx context.Context, tx *gorm.DB, deadline time.Time) ([]*entity.WhatsappStartVote, error) {
+	votes := make([]*entity.WhatsappStartVote, 0)
+
+	err := tx.Preload("Voters").
+		Where(&entity.WhatsappStartVote{DeviceID: dto.WhatsappDeviceFromContext(ctx), Status: entity.StartVoteStatusOpen}).
+		Where("deadline < ?", deadline).
+		Find(&votes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+func (r *WhatsappStartVoteRepo) Create(ctx context.Context, tx *gorm.DB, vote *entity.WhatsappStartVote) error {
+	vote.DeviceID = dto.WhatsappDeviceFromContext(ctx)
+
+	return tx.Omit(clause.Associations).Create(vote).Error
+}
+
+func (r *WhatsappStartVoteRepo) AddVoter(ctx context.Context, tx *gorm.DB, voteID uint, voter string) (bool, error) {
+	res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.WhatsappStartVoteVoter{
+		VoteID: voteID,
+		Voter:  voter,
+	})
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+func (r *WhatsappStartVoteRepo) UpdateStatus(ctx context.Context, tx *gorm.DB, voteID uint, status string) error {
+	return tx.Model(&entity.WhatsappStartVote{ID: voteID}).Update("status", status).Error
+}
+
+func (r *WhatsappStartVoteRepo) UpdateMessageID(ctx context.Context, tx *gorm.DB, voteID uint, messageID string) error {
+	return tx.Model(&entity.WhatsappStartVote{ID: voteID}).Update("message_id", messageID).Error
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IWhatsappUserRoleRepo only sees the roles of the context's device.
+type IWhatsappUserRoleRepo interface {
+	GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappUserRole, error)
+
+	// GetByUsers returns the roles of the users in a group, including the
+	// ones assigned for every chat.
+	GetByUsers(ctx context.Context, tx *gorm.DB, group dto.WhatsappJID, users []dto.WhatsappJID) ([]*entity.WhatsappUserRole, error)
+	Upsert(ctx context.Context, tx *gorm.DB, role *entity.WhatsappUserRole) error
+	Delete(ctx context.Context, tx *gorm.DB, role *entity.WhatsappUserRole) error
+}
+
+type WhatsappUserRoleRepo struct{}
+
+func newWhatsappUserRoleRepo() IWhatsappUserRoleRepo {
+	return &WhatsappUserRoleRepo{}
+}
+
+func (r *WhatsappUserRoleRepo) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.WhatsappUserRole, error) {
+	roles := make([]*entity.WhatsappUserRole, 0)
+	err := tx.Where("device_id = ?", dto.WhatsappDeviceFromContext(ctx)).Order("group_jid, jid").Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *WhatsappUserRoleRepo) GetByUsers(ctx context.Context, tx *gorm.DB, group dto.WhatsappJID, users []dto.WhatsappJID) ([]*entity.WhatsappUserRole, error) {
+	roles := make([]*entity.WhatsappUserRole, 0)
+	if len(users) == 0 {
+		return roles, nil
+	}
+
+	userCond := tx.Session(&gorm.Session{NewDB: true})
+	for _, u := range users {
+		userCond = userCond.Or("jid = ? AND server_jid = ?", u.User, u.Server)
+	}
+
+	err := tx.
+		Where("device_id = ?", dto.WhatsappDeviceFromContext(ctx)).
+		Where("(group_jid = ? AND group_server_jid = ?) OR (group_jid = '' AND group_server_jid = '')", group.User, group.Server).
+		Where(userCond).
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *WhatsappUserRoleRepo) Upsert(ctx context.Context, tx *gorm.DB, role *entity.WhatsappUserRole) error {
+	role.DeviceID = dto.WhatsappDeviceFromContext(ctx)
+
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(role).Error
+}
+
+func (r *WhatsappUserRoleRepo) Delete(ctx context.Context, tx *gorm.DB, role *entity.WhatsappUserRole) error {
+	return tx.Where(map[string]any{
+		"device_id":        dto.WhatsappDeviceFromContext(ctx),
+		"group_jid":        role.GroupJID,
+		"group_server_jid": role.GroupServerJID,
+		"jid":              role.JID,
+		"server_jid":       role.ServerJID,
+	}).Delete(&entity.WhatsappUserRole{}).Error
+}
@@ -2,16 +2,19 @@ package repository
 
 import (
 	"context"
-	"exaroton-wa-bot/internal/config"
+	"errors"
 	"exaroton-wa-bot/internal/constants/errs"
 	"exaroton-wa-bot/internal/dto"
+	"fmt"
 	"sync"
 	"sync/atomic"
 
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/store"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
+	waLog "go.mau.fi/whatsmeow/util/log"
 )
 
 // represents a single whatsapp device/account.
@@ -21,28 +24,30 @@ type waClient struct {
 	qrSub     chan whatsmeow.QRChannelItem
 	qrSubLock sync.RWMutex
 
+	isPairing atomic.Bool // a pairing code session is running
+
 	isSyncComplete atomic.Bool
 }
 
-// NewWAClient creates a new WhatsApp client (without connecting to an account).
+// newWAClient creates a WhatsApp client of a store device (without
+// connecting to an account), see waDevices.
 //
 // represents a single whatsapp device/account.
 //
 // defer Disconnect()
-func NewWAClient(db *config.WhatsappDB) (*waClient, error) {
-	deviceStore, err := db.Container.GetFirstDevice(context.TODO())
-	if err != nil {
-		return nil, err
-	}
+func newWAClient(deviceStore *store.Device, logger waLog.Logger) *waClient {
+	client := whatsmeow.NewClient(deviceStore, logger)
+	// reconnected with a backoff by the connection supervisor, see
+	// WADevices.OnConnEvent
+	client.EnableAutoReconnect = false
 
-	client := whatsmeow.NewClient(deviceStore, db.ClientLogger)
 	waClient := &waClient{
 		client: &whatsmeowClientWrapper{client: client},
 	}
 
 	waClient.client.RegisterEventHandler(getStateEventHandler(waClient))
 
-	return waClient, nil
+	return waClient
 }
 
 func getStateEventHandler(w *waClient) func(evt interface{}) {
@@ -66,6 +71,12 @@ func (w *waClient) IsLoggedIn() bool {
 	return w.client.IsLoggedIn()
 }
 
+// IsConnected reports whether the websocket is up, it can be connected while
+// not logged in (e.g. waiting for a QR scan).
+func (w *waClient) IsConnected() bool {
+	return w.client.IsConnected()
+}
+
 // if isn't logged in, will return a channel (to send qr codes) that is closed/nil automatically.
 //
 // if already logged in, will return nil and nil error.
@@ -79,6 +90,10 @@ func (w *waClient) Login(ctx context.Context) (<-chan whatsmeow.QRChannelItem, e
 		return nil, err
 	}
 
+	if w.isPairing.Load() {
+		return nil, errs.ErrWAQRIsPairing
+	}
+
 	// check for existing qr session
 	qrChan := w.getQRSub()
 	if qrChan != nil {
@@ -99,6 +114,88 @@ func (w *waClient) Login(ctx context.Context) (<-chan whatsmeow.QRChannelItem, e
 	return *w.getQRSub(), nil
 }
 
+// pairClientDisplayName is shown in the phone's linked devices, it must be
+// formatted as "Browser (OS)".
+const pairClientDisplayName = "Chrome (Linux)"
+
+// LoginWithNumber links a new session with a pairing code instead of a QR
+// code, the code is entered in the phone's WhatsApp (Linked devices > Link
+// with phone number instead). phone is digits only, with the country code.
+//
+// The returned channel gets the outcome of the pairing (success, timeout or
+// an error) and is closed, the QR codes whatsmeow keeps sending are dropped.
+//
+// if already logged in, will return an empty code and a nil channel.
+func (w *waClient) LoginWithNumber(ctx context.Context, phone string) (string, <-chan whatsmeow.QRChannelItem, error) {
+	if w.IsLoggedIn() {
+		return "", nil, errs.ErrWAAlreadyLoggedIn
+	}
+
+	// login with existing session
+	if ok, err := w.LoginWithExistingSession(ctx); ok {
+		return "", nil, err
+	}
+
+	// one session at a time, qr or pairing code
+	if w.getQRSub() != nil || !w.isPairing.CompareAndSwap(false, true) {
+		return "", nil, errs.ErrWAQRIsPairing
+	}
+
+	code, pub, err := w.pairPhone(ctx, phone)
+	if err != nil {
+		w.isPairing.Store(false)
+		return "", nil, err
+	}
+
+	sub := make(chan whatsmeow.QRChannelItem, 1)
+	go func() {
+		defer w.isPairing.Store(false)
+		defer close(sub)
+
+		for item := range pub {
+			if item.Event != whatsmeow.QRChannelEventCode {
+				sub <- item
+				return
+			}
+		}
+	}()
+
+	return code, sub, nil
+}
+
+// pairPhone connects and requests a pairing code, which can only be done once
+// the server sent the first QR code.
+func (w *waClient) pairPhone(ctx context.Context, phone string) (string, <-chan whatsmeow.QRChannelItem, error) {
+	pub, err := w.client.GetQRChannel(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := w.client.Connect(); err != nil {
+		return "", nil, err
+	}
+
+	select {
+	case first, ok := <-pub:
+		if !ok || first.Event != whatsmeow.QRChannelEventCode {
+			w.client.Disconnect()
+			return "", nil, errs.ErrWAQRError
+		}
+	case <-ctx.Done():
+		w.client.Disconnect()
+		return "", nil, ctx.Err()
+	}
+
+	code, err := w.client.PairPhone(ctx, phone, true, pairClientDisplayName)
+	if err != nil {
+		// the next attempt connects again
+		w.client.Disconnect()
+		return "", nil, err
+	}
+
+	return code, pub, nil
+}
+
 func (w *waClient) LoginWithExistingSession(ctx context.Context) (bool, error) {
 	if !w.IsLoggedIn() && w.client.GetLoggedInDeviceJID() != nil {
 		err := w.client.Connect()
@@ -111,6 +208,25 @@ func (w *waClient) LoginWithExistingSession(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
+// Reconnect connects a paired client again after the connection dropped, it
+// does nothing if the client is connected.
+func (w *waClient) Reconnect(ctx context.Context) error {
+	if w.client.GetLoggedInDeviceJID() == nil {
+		return errs.ErrWANotLoggedIn
+	}
+
+	if w.IsConnected() {
+		return nil
+	}
+
+	err := w.client.Connect()
+	if errors.Is(err, whatsmeow.ErrAlreadyConnected) {
+		return nil
+	}
+
+	return err
+}
+
 func (w *waClient) Logout(ctx context.Context) error {
 	return w.client.Logout(ctx)
 }
@@ -130,6 +246,35 @@ func (w *waClient) GetPhoneNumber() string {
 	return jid.User
 }
 
+// IsSelf checks if a jid is the logged in account, either by its phone number
+// or its LID. The other identity is resolved thru the store's LID<->PN map.
+func (w *waClient) IsSelf(ctx context.Context, jid dto.WhatsappJID) bool {
+	pn, lid := w.client.GetLoggedInDeviceJID(), w.client.GetLoggedInDeviceLID()
+	if pn == nil {
+		return false
+	}
+
+	isSelf := func(j types.JID) bool {
+		return isSameUser(j, *pn) || (lid != nil && isSameUser(j, *lid))
+	}
+
+	target := jid.To()
+	if isSelf(target) {
+		return true
+	}
+
+	alt, err := w.client.GetAltJID(ctx, target.ToNonAD())
+	if err != nil || alt.IsEmpty() {
+		return false
+	}
+
+	return isSelf(alt)
+}
+
+func isSameUser(a, b types.JID) bool {
+	return a.User == b.User && a.Server == b.Server
+}
+
 func (w *waClient) GetSelfLID() *dto.WhatsappJID {
 	lid := w.client.GetLoggedInDeviceLID()
 	if lid == nil {
@@ -149,6 +294,34 @@ func (w *waClient) GetGroups(ctx context.Context) ([]*types.GroupInfo, error) {
 	return w.client.GetJoinedGroups(ctx)
 }
 
+func (w *waClient) GetGroupInfo(ctx context.Context, group dto.WhatsappJID) (*types.GroupInfo, error) {
+	return w.client.GetGroupInfo(ctx, group.To())
+}
+
+// GetGroupInfoFromLink previews the group of an invite link, the link can be
+// the whole chat.whatsapp.com url or only its code.
+func (w *waClient) GetGroupInfoFromLink(ctx context.Context, link string) (*types.GroupInfo, error) {
+	info, err := w.client.GetGroupInfoFromLink(ctx, link)
+	return info, inviteLinkErr(err)
+}
+
+func (w *waClient) JoinGroupWithLink(ctx context.Context, link string) (dto.WhatsappJID, error) {
+	jid, err := w.client.JoinGroupWithLink(ctx, link)
+	if err != nil {
+		return dto.WhatsappJID{}, inviteLinkErr(err)
+	}
+
+	return dto.NewWhatsappJID(jid), nil
+}
+
+func inviteLinkErr(err error) error {
+	if errors.Is(err, whatsmeow.ErrInviteLinkInvalid) || errors.Is(err, whatsmeow.ErrInviteLinkRevoked) {
+		return fmt.Errorf("%w: %w", errs.ErrWAInviteLinkInvalid, err)
+	}
+
+	return err
+}
+
 // starts a goroutine that publishes QR codes to the subscriber.
 func (w *waClient) publishQR(pub <-chan whatsmeow.QRChannelItem) {
 	if w.qrSub == nil {
@@ -201,8 +374,23 @@ func (w *waClient) UnregisterEventHandler(code uint32) bool {
 	return w.client.UnregisterEventHandler(code)
 }
 
+// SendMessage sends a message, uploading its document or image first. The
+// upload isn't kept in message, sending it again uploads the media again.
 func (w *waClient) SendMessage(ctx context.Context, to dto.WhatsappJID, message *dto.WhatsappMessage) (*dto.WhatsappSendResponse, error) {
-	resp, err := w.client.SendMessage(ctx, to.To(), message.To())
+	if media, mediaType := message.Media(); media != nil && media.Uploaded == nil {
+		uploaded, err := w.client.Upload(ctx, media.Data, mediaType)
+		if err != nil {
+			return nil, err
+		}
+		message = message.WithUpload(newWhatsappUpload(uploaded))
+	}
+
+	msg, err := message.To()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := w.client.SendMessage(ctx, to.To(), msg)
 	if err != nil {
 		return nil, err
 	}
@@ -212,6 +400,68 @@ func (w *waClient) SendMessage(ctx context.Context, to dto.WhatsappJID, message
 	return &dtoRes, nil
 }
 
+func newWhatsappUpload(up whatsmeow.UploadResponse) *dto.WhatsappUpload {
+	return &dto.WhatsappUpload{
+		URL:           up.URL,
+		DirectPath:    up.DirectPath,
+		MediaKey:      up.MediaKey,
+		FileEncSHA256: up.FileEncSHA256,
+		FileSHA256:    up.FileSHA256,
+		FileLength:    up.FileLength,
+	}
+}
+
+// EditMessage edits a message previously sent by the bot.
+func (w *waClient) EditMessage(ctx context.Context, to dto.WhatsappJID, messageID string, message *dto.WhatsappMessage) (*dto.WhatsappSendResponse, error) {
+	chat := to.To()
+
+	msg, err := message.To()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := w.client.SendMessage(ctx, chat, w.client.BuildEdit(chat, messageID, msg))
+	if err != nil {
+		return nil, err
+	}
+
+	dtoRes := dto.NewWhatsappSendResponse(resp)
+
+	return &dtoRes, nil
+}
+
+// SendReaction reacts to a message of sender in a chat, an empty emoji removes the reaction.
+func (w *waClient) SendReaction(ctx context.Context, chat, sender dto.WhatsappJID, messageID, emoji string) error {
+	to := chat.To()
+
+	_, err := w.client.SendMessage(ctx, to, w.client.BuildReaction(to, sender.To(), messageID, emoji))
+	return err
+}
+
+// SendPoll sends a single-choice poll, the votes come as poll update messages
+// (see DecryptPollVote).
+func (w *waClient) SendPoll(ctx context.Context, to dto.WhatsappJID, question string, options []string) (*dto.WhatsappSendResponse, error) {
+	resp, err := w.client.SendMessage(ctx, to.To(), w.client.BuildPollCreation(question, options, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	dtoRes := dto.NewWhatsappSendResponse(resp)
+
+	return &dtoRes, nil
+}
+
+// DecryptPollVote decrypts a vote on one of the bot's polls, it returns the
+// SHA-256 hashes of the selected options, none if the vote was removed.
+func (w *waClient) DecryptPollVote(ctx context.Context, vote *events.Message) ([][]byte, error) {
+	decrypted, err := w.client.DecryptPollVote(ctx, vote)
+	if err != nil {
+		return nil, err
+	}
+
+	return decrypted.GetSelectedOptions(), nil
+}
+
 // ================================
 //
 //	whatsmeow wrapper
@@ -219,6 +469,7 @@ func (w *waClient) SendMessage(ctx context.Context, to dto.WhatsappJID, message
 // ================================
 type iWhatsmeowClientWrapper interface {
 	IsLoggedIn() bool
+	IsConnected() bool
 	Connect() error
 	PairPhone(ctx context.Context, phone string, showPushNotification bool, clientDisplayName string) (string, error)
 	Disconnect()
@@ -230,9 +481,20 @@ type iWhatsmeowClientWrapper interface {
 	GetLoggedInDeviceLID() *types.JID
 	GetUserInfo(context.Context, []types.JID) (map[types.JID]types.UserInfo, error)
 	GetJoinedGroups(ctx context.Context) ([]*types.GroupInfo, error)
+	GetGroupInfo(ctx context.Context, jid types.JID) (*types.GroupInfo, error)
+	GetGroupInfoFromLink(ctx context.Context, code string) (*types.GroupInfo, error)
+	JoinGroupWithLink(ctx context.Context, code string) (types.JID, error)
 	RegisterEventHandler(f func(any)) uint32
 	UnregisterEventHandler(handlerID uint32) bool
 	SendMessage(ctx context.Context, to types.JID, message *waE2E.Message, extra ...whatsmeow.SendRequestExtra) (resp whatsmeow.SendResponse, err error)
+	BuildEdit(chat types.JID, id types.MessageID, newContent *waE2E.Message) *waE2E.Message
+	BuildReaction(chat, sender types.JID, id types.MessageID, reaction string) *waE2E.Message
+	Upload(ctx context.Context, plaintext []byte, mediaType whatsmeow.MediaType) (whatsmeow.UploadResponse, error)
+	BuildPollCreation(name string, optionNames []string, selectableOptionCount int) *waE2E.Message
+	DecryptPollVote(ctx context.Context, vote *events.Message) (*waE2E.PollVoteMessage, error)
+
+	// GetAltJID maps a phone number jid to its LID and vice versa, empty if unknown.
+	GetAltJID(ctx context.Context, jid types.JID) (types.JID, error)
 }
 
 var _ iWhatsmeowClientWrapper = &whatsmeowClientWrapper{}
@@ -245,6 +507,10 @@ func (w *whatsmeowClientWrapper) IsLoggedIn() bool {
 	return w.client.IsLoggedIn() && w.GetLoggedInDeviceJID() != nil
 }
 
+func (w *whatsmeowClientWrapper) IsConnected() bool {
+	return w.client.IsConnected()
+}
+
 func (w *whatsmeowClientWrapper) Connect() error {
 	return w.client.Connect()
 }
@@ -285,6 +551,18 @@ func (w *whatsmeowClientWrapper) GetJoinedGroups(ctx context.Context) ([]*types.
 	return w.client.GetJoinedGroups(ctx)
 }
 
+func (w *whatsmeowClientWrapper) GetGroupInfo(ctx context.Context, jid types.JID) (*types.GroupInfo, error) {
+	return w.client.GetGroupInfo(ctx, jid)
+}
+
+func (w *whatsmeowClientWrapper) GetGroupInfoFromLink(ctx context.Context, code string) (*types.GroupInfo, error) {
+	return w.client.GetGroupInfoFromLink(ctx, code)
+}
+
+func (w *whatsmeowClientWrapper) JoinGroupWithLink(ctx context.Context, code string) (types.JID, error) {
+	return w.client.JoinGroupWithLink(ctx, code)
+}
+
 func (w *whatsmeowClientWrapper) RegisterEventHandler(f func(any)) uint32 {
 	return w.client.AddEventHandler(f)
 }
@@ -296,3 +574,27 @@ func (w *whatsmeowClientWrapper) UnregisterEventHandler(handlerID uint32) bool {
 func (w *whatsmeowClientWrapper) SendMessage(ctx context.Context, to types.JID, message *waE2E.Message, extra ...whatsmeow.SendRequestExtra) (resp whatsmeow.SendResponse, err error) {
 	return w.client.SendMessage(ctx, to, message, extra...)
 }
+
+func (w *whatsmeowClientWrapper) BuildEdit(chat types.JID, id types.MessageID, newContent *waE2E.Message) *waE2E.Message {
+	return w.client.BuildEdit(chat, id, newContent)
+}
+
+func (w *whatsmeowClientWrapper) BuildReaction(chat, sender types.JID, id types.MessageID, reaction string) *waE2E.Message {
+	return w.client.BuildReaction(chat, sender, id, reaction)
+}
+
+func (w *whatsmeowClientWrapper) Upload(ctx context.Context, plaintext []byte, mediaType whatsmeow.MediaType) (whatsmeow.UploadResponse, error) {
+	return w.client.Upload(ctx, plaintext, mediaType)
+}
+
+func (w *whatsmeowClientWrapper) BuildPollCreation(name string, optionNames []string, selectableOptionCount int) *waE2E.Message {
+	return w.client.BuildPollCreation(name, optionNames, selectableOptionCount)
+}
+
+func (w *whatsmeowClientWrapper) DecryptPollVote(ctx context.Context, vote *events.Message) (*waE2E.PollVoteMessage, error) {
+	return w.client.DecryptPollVote(ctx, vote)
+}
+
+func (w *whatsmeowClientWrapper) GetAltJID(ctx context.Context, jid types.JID) (types.JID, error) {
+	return w.client.Store.GetAltJID(ctx, jid)
+}
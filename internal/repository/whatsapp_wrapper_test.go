@@ -1,11 +1,17 @@
 package repository
 
 import (
+	"context"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/dto"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
 )
 
 func TestWAClient_GetSetQRSub(t *testing.T) {
@@ -46,3 +52,187 @@ func TestWAClient_GetSetQRSub(t *testing.T) {
 	assert.Nil(t, wa.qrSub)
 	assert.Nil(t, wa.getQRSub())
 }
+
+// fakePairingClient links with a pairing code, it's not logged in.
+type fakePairingClient struct {
+	iWhatsmeowClientWrapper
+
+	qr           chan whatsmeow.QRChannelItem
+	connected    bool
+	disconnected bool
+	pairErr      error
+}
+
+func (f *fakePairingClient) IsLoggedIn() bool                 { return false }
+func (f *fakePairingClient) GetLoggedInDeviceJID() *types.JID { return nil }
+func (f *fakePairingClient) Connect() error                   { f.connected = true; return nil }
+func (f *fakePairingClient) Disconnect()                      { f.disconnected = true }
+
+func (f *fakePairingClient) GetQRChannel(ctx context.Context) (<-chan whatsmeow.QRChannelItem, error) {
+	return f.qr, nil
+}
+
+func (f *fakePairingClient) PairPhone(ctx context.Context, phone string, showPushNotification bool, clientDisplayName string) (string, error) {
+	if f.pairErr != nil {
+		return "", f.pairErr
+	}
+	return "ABCD-" + phone[len(phone)-4:], nil
+}
+
+func TestWAClient_LoginWithNumber(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client := &fakePairingClient{qr: make(chan whatsmeow.QRChannelItem, 3)}
+		wa := &waClient{client: client}
+
+		client.qr <- whatsmeow.QRChannelItem{Event: whatsmeow.QRChannelEventCode, Code: "qr1"}
+		code, sub, err := wa.LoginWithNumber(context.Background(), "628123456789")
+		require.NoError(t, err)
+		assert.Equal(t, "ABCD-6789", code)
+		assert.True(t, client.connected)
+
+		// one session at a time
+		_, _, err = wa.LoginWithNumber(context.Background(), "628123456789")
+		assert.ErrorIs(t, err, errs.ErrWAQRIsPairing)
+		_, err = wa.Login(context.Background())
+		assert.ErrorIs(t, err, errs.ErrWAQRIsPairing)
+
+		// new qr codes are dropped, the outcome is forwarded
+		client.qr <- whatsmeow.QRChannelItem{Event: whatsmeow.QRChannelEventCode, Code: "qr2"}
+		client.qr <- whatsmeow.QRChannelSuccess
+		close(client.qr)
+
+		select {
+		case item := <-sub:
+			assert.Equal(t, whatsmeow.QRChannelSuccess, item)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for the outcome")
+		}
+
+		_, ok := <-sub
+		assert.False(t, ok, "closed after the outcome")
+		assert.Eventually(t, func() bool { return !wa.isPairing.Load() }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("pairing code failed", func(t *testing.T) {
+		client := &fakePairingClient{qr: make(chan whatsmeow.QRChannelItem, 1), pairErr: whatsmeow.ErrPhoneNumberTooShort}
+		wa := &waClient{client: client}
+
+		client.qr <- whatsmeow.QRChannelItem{Event: whatsmeow.QRChannelEventCode, Code: "qr1"}
+		_, sub, err := wa.LoginWithNumber(context.Background(), "628123456789")
+		assert.ErrorIs(t, err, whatsmeow.ErrPhoneNumberTooShort)
+		assert.Nil(t, sub)
+		assert.True(t, client.disconnected, "disconnected to try again")
+		assert.False(t, wa.isPairing.Load())
+	})
+
+	t.Run("no qr code", func(t *testing.T) {
+		client := &fakePairingClient{qr: make(chan whatsmeow.QRChannelItem, 1)}
+		wa := &waClient{client: client}
+
+		client.qr <- whatsmeow.QRChannelClientOutdated
+		_, _, err := wa.LoginWithNumber(context.Background(), "628123456789")
+		assert.ErrorIs(t, err, errs.ErrWAQRError)
+		assert.False(t, wa.isPairing.Load())
+	})
+}
+
+// fakeSelfClient is logged in as pn (LID lid), alts maps the other identity
+// of the known users.
+type fakeSelfClient struct {
+	iWhatsmeowClientWrapper
+
+	pn, lid *types.JID
+	alts    map[types.JID]types.JID
+}
+
+func (f *fakeSelfClient) GetLoggedInDeviceJID() *types.JID { return f.pn }
+func (f *fakeSelfClient) GetLoggedInDeviceLID() *types.JID { return f.lid }
+
+func (f *fakeSelfClient) GetAltJID(ctx context.Context, jid types.JID) (types.JID, error) {
+	return f.alts[jid], nil
+}
+
+func TestWAClient_IsSelf(t *testing.T) {
+	// the logged in device has a device number, the mentions don't
+	pn := types.NewADJID("628123", 0, 12)
+	lid := types.NewADJID("999", 0, 12)
+	lid.Server = types.HiddenUserServer
+
+	selfPN := dto.WhatsappJID{User: "628123", Server: types.DefaultUserServer}
+	selfLID := dto.WhatsappJID{User: "999", Server: types.HiddenUserServer}
+	other := dto.WhatsappJID{User: "6285", Server: types.DefaultUserServer}
+
+	tests := []struct {
+		name   string
+		client *fakeSelfClient
+		jid    dto.WhatsappJID
+		want   bool
+	}{
+		{name: "phone number", client: &fakeSelfClient{pn: &pn, lid: &lid}, jid: selfPN, want: true},
+		{name: "LID", client: &fakeSelfClient{pn: &pn, lid: &lid}, jid: selfLID, want: true},
+		{name: "other user", client: &fakeSelfClient{pn: &pn, lid: &lid}, jid: other},
+		{name: "same number, other server", client: &fakeSelfClient{pn: &pn, lid: &lid}, jid: dto.WhatsappJID{User: "628123", Server: types.HiddenUserServer}},
+		{
+			name:   "LID thru the store, own LID unknown",
+			client: &fakeSelfClient{pn: &pn, alts: map[types.JID]types.JID{selfLID.To(): selfPN.To()}},
+			jid:    selfLID,
+			want:   true,
+		},
+		{name: "LID unknown to the store", client: &fakeSelfClient{pn: &pn}, jid: selfLID},
+		{name: "not logged in", client: &fakeSelfClient{}, jid: selfPN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wa := &waClient{client: tt.client}
+			assert.Equal(t, tt.want, wa.IsSelf(context.Background(), tt.jid))
+		})
+	}
+}
+
+// fakeSendClient uploads to url and records the sent messages.
+type fakeSendClient struct {
+	iWhatsmeowClientWrapper
+
+	url     string
+	uploads []whatsmeow.MediaType
+	sent    []*waE2E.Message
+}
+
+func (f *fakeSendClient) Upload(ctx context.Context, plaintext []byte, mediaType whatsmeow.MediaType) (whatsmeow.UploadResponse, error) {
+	f.uploads = append(f.uploads, mediaType)
+	return whatsmeow.UploadResponse{URL: f.url, FileLength: uint64(len(plaintext))}, nil
+}
+
+func (f *fakeSendClient) SendMessage(ctx context.Context, to types.JID, message *waE2E.Message, extra ...whatsmeow.SendRequestExtra) (whatsmeow.SendResponse, error) {
+	f.sent = append(f.sent, message)
+	return whatsmeow.SendResponse{ID: "abc"}, nil
+}
+
+func TestWAClient_SendMessage_Upload(t *testing.T) {
+	client := &fakeSendClient{url: "https://mmg.whatsapp.net/x"}
+	wa := &waClient{client: client}
+	to := dto.WhatsappJID{User: "6285", Server: types.DefaultUserServer}
+
+	msg := &dto.WhatsappMessage{Document: &dto.WhatsappDocument{
+		WhatsappMedia: dto.WhatsappMedia{Data: []byte("a,b"), MimeType: "text/csv"},
+		FileName:      "players.csv",
+	}}
+
+	res, err := wa.SendMessage(context.Background(), to, msg)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", res.ID)
+
+	assert.Equal(t, []whatsmeow.MediaType{whatsmeow.MediaDocument}, client.uploads)
+	require.Len(t, client.sent, 1)
+	assert.Equal(t, "https://mmg.whatsapp.net/x", client.sent[0].GetDocumentMessage().GetURL())
+	assert.Equal(t, uint64(3), client.sent[0].GetDocumentMessage().GetFileLength())
+	assert.Equal(t, "players.csv", client.sent[0].GetDocumentMessage().GetFileName())
+
+	// the caller's message is left as is, sending it again uploads it again
+	assert.Nil(t, msg.Document.Uploaded)
+
+	_, err = wa.SendMessage(context.Background(), to, msg)
+	require.NoError(t, err)
+	assert.Len(t, client.uploads, 2)
+}
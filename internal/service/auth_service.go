@@ -17,9 +17,13 @@ import (
 type IAuthService interface {
 	Login(ctx context.Context, req *dto.UserLoginReq) (*dto.UserClaims, time.Duration, error)
 	WhatsappLogin(ctx context.Context) (<-chan whatsmeow.QRChannelItem, error)
+
+	// WhatsappLoginWithNumber requests a pairing code to link the phone number's
+	// WhatsApp, the channel gets the outcome of the pairing.
+	WhatsappLoginWithNumber(ctx context.Context, req *dto.WhatsappLoginNumberReq) (string, <-chan whatsmeow.QRChannelItem, error)
 	WhatsappLogout(ctx context.Context) error
 	WhatsappIsLoggedIn(ctx context.Context) bool
-	GetWhatsappPhoneNumber() string
+	GetWhatsappPhoneNumber(ctx context.Context) string
 	GetWhatsappGroups(ctx context.Context) ([]*dto.WhatsappGroupInfo, error)
 	IsWhatsappSynced(ctx context.Context) bool
 
@@ -27,6 +31,9 @@ type IAuthService interface {
 	FilterWhatsappWhitelistedGroups(ctx context.Context, allGroups []*dto.WhatsappGroupInfo) ([]*dto.WhatsappGroupInfo, error)
 
 	GetWhatsappWhitelistedGroupJIDs(ctx context.Context) ([]*dto.WhatsappWhitelistedGroup, error)
+
+	// users allowed to use the bot in direct messages
+	GetWhatsappWhitelistedUsers(ctx context.Context) ([]*dto.WhatsappWhitelistedUser, error)
 }
 
 type AuthService struct {
@@ -77,12 +84,16 @@ func (s *AuthService) WhatsappLogin(ctx context.Context) (<-chan whatsmeow.QRCha
 	return s.waRepo.Login(ctx)
 }
 
+func (s *AuthService) WhatsappLoginWithNumber(ctx context.Context, req *dto.WhatsappLoginNumberReq) (string, <-chan whatsmeow.QRChannelItem, error) {
+	return s.waRepo.LoginWithNumber(ctx, req.Phone)
+}
+
 func (s *AuthService) WhatsappIsLoggedIn(ctx context.Context) bool {
-	return s.waRepo.IsLoggedIn()
+	return s.waRepo.IsLoggedIn(ctx)
 }
 
-func (s *AuthService) GetWhatsappPhoneNumber() string {
-	return s.waRepo.GetPhoneNumber()
+func (s *AuthService) GetWhatsappPhoneNumber(ctx context.Context) string {
+	return s.waRepo.GetPhoneNumber(ctx)
 }
 
 func (s *AuthService) GetWhatsappGroups(ctx context.Context) ([]*dto.WhatsappGroupInfo, error) {
@@ -130,7 +141,8 @@ func (s *AuthService) FilterWhatsappWhitelistedGroups(ctx context.Context, allGr
 		}
 	}
 
-	// TODO: sync the not existing jids (delete or update? not decided yet)
+	// the whitelisted groups the bot isn't in are left out, they're flagged or
+	// unwhitelisted by WhatsappService.ReconcileGroups
 
 	return filteredGroups, nil
 }
@@ -159,3 +171,24 @@ func (s *AuthService) GetWhatsappWhitelistedGroupJIDs(ctx context.Context) ([]*d
 
 	return res, nil
 }
+
+func (s *AuthService) GetWhatsappWhitelistedUsers(ctx context.Context) ([]*dto.WhatsappWhitelistedUser, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	entities, err := s.waRepo.GetWhitelistedUsers(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*dto.WhatsappWhitelistedUser, len(entities))
+	for i, entity := range entities {
+		res[i] = dto.NewWhatsappWhitelistedUser(entity)
+	}
+
+	return res, nil
+}
@@ -259,6 +259,20 @@ func TestAuthService_WhatsappLogin(t *testing.T) {
 	}
 }
 
+func TestAuthService_WhatsappLoginWithNumber(t *testing.T) {
+	authSvc, _, _, mockWaRepo, _ := setupTestAuthService(t)
+
+	qrChan := make(chan whatsmeow.QRChannelItem)
+	mockWaRepo.EXPECT().
+		LoginWithNumber(mock.Anything, "628123456789").
+		Return("ABCD-EFGH", (<-chan whatsmeow.QRChannelItem)(qrChan), nil)
+
+	code, ch, err := authSvc.WhatsappLoginWithNumber(context.Background(), &dto.WhatsappLoginNumberReq{Phone: "628123456789"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ABCD-EFGH", code)
+	assert.NotNil(t, ch)
+}
+
 func TestAuthService_WhatsappIsLoggedIn(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -269,7 +283,7 @@ func TestAuthService_WhatsappIsLoggedIn(t *testing.T) {
 			name: "is_logged_in",
 			mockSetup: func(mockWaRepo *mockRepo.MockIWhatsappRepo) {
 				mockWaRepo.EXPECT().
-					IsLoggedIn().
+					IsLoggedIn(mock.Anything).
 					Return(true)
 			},
 			expectedValue: true,
@@ -278,7 +292,7 @@ func TestAuthService_WhatsappIsLoggedIn(t *testing.T) {
 			name: "is_not_logged_in",
 			mockSetup: func(mockWaRepo *mockRepo.MockIWhatsappRepo) {
 				mockWaRepo.EXPECT().
-					IsLoggedIn().
+					IsLoggedIn(mock.Anything).
 					Return(false)
 			},
 			expectedValue: false,
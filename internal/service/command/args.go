@@ -0,0 +1,292 @@
+package command
+
+import (
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/i18n"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type ArgType int
+
+const (
+	ArgString ArgType = iota // a single word
+	ArgInt                   // a non-negative integer, e.g. a server ID, with an optional '#' as the job IDs are shown
+	ArgEnum                  // one of Arg.Enum
+	ArgText                  // the rest of the args joined by spaces, must be the last arg
+	ArgBool                  // flags only, set by its presence
+)
+
+// Arg is a positional argument of a command.
+type Arg struct {
+	Name     string
+	Type     ArgType
+	Help     string   // message ID, see messages.ArgHelpServerID
+	Optional bool     // optional args must come after the required ones
+	Enum     []string // allowed values of an ArgEnum
+}
+
+// Flag is a "--name" or "--name=value" argument of a command, flags are always optional.
+type Flag struct {
+	Name string
+	Type ArgType  // ArgBool if the flag takes no value
+	Help string   // message ID, see messages.ArgHelpServerID
+	Enum []string // allowed values of an ArgEnum
+}
+
+// ArgSpec declares the arguments a command accepts, the registry parses and
+// validates them before the command is executed.
+type ArgSpec struct {
+	Args  []Arg
+	Flags []Flag
+}
+
+// Args are the parsed and validated arguments of a command.
+type Args struct {
+	Raw []string
+
+	values map[string]any // key: arg or flag name
+}
+
+// NewArgs returns args holding the given values, e.g. to execute a command directly.
+func NewArgs(values map[string]any) *Args {
+	if values == nil {
+		values = make(map[string]any)
+	}
+
+	return &Args{values: values}
+}
+
+// Has reports whether an arg or flag was given.
+func (a *Args) Has(name string) bool {
+	_, ok := a.values[name]
+	return ok
+}
+
+// Get returns the value of an arg/flag, nil if it wasn't given.
+func (a *Args) Get(name string) any {
+	return a.values[name]
+}
+
+// String returns a string, enum or text arg/flag, "" if it wasn't given.
+func (a *Args) String(name string) string {
+	v, _ := a.values[name].(string)
+	return v
+}
+
+// Int returns an int arg/flag, 0 if it wasn't given.
+func (a *Args) Int(name string) int {
+	v, _ := a.values[name].(int)
+	return v
+}
+
+// Bool returns a bool flag.
+func (a *Args) Bool(name string) bool {
+	v, _ := a.values[name].(bool)
+	return v
+}
+
+// ArgError is a missing or invalid argument, it wraps either
+// errs.ErrCommandMissingArg or errs.ErrCommandInvalidArg.
+type ArgError struct {
+	err   error
+	msg   i18n.Message
+	Usage string
+}
+
+func (e *ArgError) Error() string {
+	return e.msg.In(i18n.Default)
+}
+
+// In translates what's wrong with the argument, without the usage.
+func (e *ArgError) In(lang i18n.Lang) string {
+	return e.msg.In(lang)
+}
+
+func (e *ArgError) Unwrap() error {
+	return e.err
+}
+
+// ParseArgs parses raw args of a command according to its spec.
+func ParseArgs(cmd Command, raw []string) (*Args, error) {
+	spec := cmd.Args()
+	args := &Args{Raw: raw, values: make(map[string]any)}
+
+	newErr := func(err error, id string, a ...any) error {
+		return &ArgError{err: err, msg: i18n.M(id, a...), Usage: Usage(cmd)}
+	}
+
+	// split flags and positional args
+	var positional []string
+	for i := 0; i < len(raw); i++ {
+		word := raw[i]
+		if !strings.HasPrefix(word, "--") || len(word) == 2 {
+			positional = append(positional, word)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(word, "--"), "=")
+		idx := slices.IndexFunc(spec.Flags, func(f Flag) bool { return f.Name == name })
+		if idx < 0 {
+			return nil, newErr(errs.ErrCommandInvalidArg, messages.ArgUnknownFlag, name)
+		}
+		flag := spec.Flags[idx]
+
+		if flag.Type == ArgBool {
+			if hasValue {
+				return nil, newErr(errs.ErrCommandInvalidArg, messages.ArgFlagTakesNoValue, name)
+			}
+			args.values[name] = true
+			continue
+		}
+
+		// "--name value"
+		if !hasValue {
+			if i+1 >= len(raw) {
+				return nil, newErr(errs.ErrCommandMissingArg, messages.ArgFlagNeedsValue, name)
+			}
+			i++
+			value = raw[i]
+		}
+
+		v, msg := parseArgValue(flag.Type, flag.Enum, value)
+		if msg != nil {
+			return nil, newErr(errs.ErrCommandInvalidArg, messages.ArgInvalidFlag, name, *msg)
+		}
+		args.values[name] = v
+	}
+
+	for i, arg := range spec.Args {
+		if arg.Type == ArgText {
+			if i < len(positional) {
+				args.values[arg.Name] = strings.Join(positional[i:], " ")
+				positional = nil
+				break
+			}
+		}
+
+		if i >= len(positional) {
+			if !arg.Optional {
+				return nil, newErr(errs.ErrCommandMissingArg, messages.ArgMissing, arg.Name)
+			}
+			continue
+		}
+
+		v, msg := parseArgValue(arg.Type, arg.Enum, positional[i])
+		if msg != nil {
+			return nil, newErr(errs.ErrCommandInvalidArg, messages.ArgInvalid, arg.Name, *msg)
+		}
+		args.values[arg.Name] = v
+	}
+
+	if len(positional) > len(spec.Args) {
+		return nil, newErr(errs.ErrCommandInvalidArg, messages.ArgTooMany)
+	}
+
+	return args, nil
+}
+
+// parseArgValue parses a single value, the message of an invalid value
+// completes "argument 'x' ...", it's nil if the value is valid.
+func parseArgValue(t ArgType, enum []string, value string) (any, *i18n.Message) {
+	switch t {
+	case ArgInt:
+		v, err := strconv.Atoi(strings.TrimPrefix(value, "#"))
+		if err != nil || v < 0 {
+			msg := i18n.M(messages.ArgMustBeNumber)
+			return nil, &msg
+		}
+		return v, nil
+
+	case ArgEnum:
+		for _, e := range enum {
+			if strings.EqualFold(e, value) {
+				return e, nil
+			}
+		}
+		msg := i18n.M(messages.ArgMustBeOneOf, strings.Join(enum, ", "))
+		return nil, &msg
+	}
+
+	return value, nil
+}
+
+// Usage generates the usage line of a command from its spec, e.g. "/start <id> [--own-credit]".
+func Usage(cmd Command) string {
+	spec := cmd.Args()
+	parts := []string{"/" + cmd.Name()}
+
+	for _, arg := range spec.Args {
+		parts = append(parts, wrapArg(argPlaceholder(arg.Name, arg.Type, arg.Enum), arg.Optional))
+	}
+
+	for _, flag := range spec.Flags {
+		s := "--" + flag.Name
+		if flag.Type != ArgBool {
+			s += "=" + argPlaceholder(flag.Name, flag.Type, flag.Enum)
+		}
+		parts = append(parts, wrapArg(s, true))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// UsageDetail describes every arg and flag of a command in lang, "" if it has none.
+func UsageDetail(cmd Command, lang i18n.Lang) string {
+	spec := cmd.Args()
+	var b strings.Builder
+
+	if len(spec.Args) > 0 {
+		b.WriteString(i18n.T(lang, messages.UsageArguments) + "\n")
+		for _, arg := range spec.Args {
+			fmt.Fprintf(&b, "- %s: %s%s\n", arg.Name, i18n.T(lang, arg.Help), argNote(lang, arg.Optional, arg.Enum))
+		}
+	}
+
+	if len(spec.Flags) > 0 {
+		b.WriteString(i18n.T(lang, messages.UsageFlags) + "\n")
+		for _, flag := range spec.Flags {
+			fmt.Fprintf(&b, "- --%s: %s%s\n", flag.Name, i18n.T(lang, flag.Help), argNote(lang, false, flag.Enum))
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func argPlaceholder(name string, t ArgType, enum []string) string {
+	switch t {
+	case ArgEnum:
+		return strings.Join(enum, "|")
+	case ArgText:
+		return name + "..."
+	}
+
+	return name
+}
+
+func wrapArg(s string, optional bool) string {
+	if optional {
+		return "[" + s + "]"
+	}
+
+	return "<" + s + ">"
+}
+
+func argNote(lang i18n.Lang, optional bool, enum []string) string {
+	var notes []string
+	if optional {
+		notes = append(notes, i18n.T(lang, messages.UsageOptional))
+	}
+	if len(enum) > 0 {
+		notes = append(notes, i18n.T(lang, messages.UsageOneOf, strings.Join(enum, ", ")))
+	}
+
+	if len(notes) == 0 {
+		return ""
+	}
+
+	return " (" + strings.Join(notes, ", ") + ")"
+}
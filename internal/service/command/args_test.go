@@ -0,0 +1,114 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// specCommand is a command with a given spec, for testing the parser.
+type specCommand struct {
+	spec ArgSpec
+}
+
+func (c *specCommand) Name() string           { return "test" }
+func (c *specCommand) Help() string           { return "" }
+func (c *specCommand) Aliases() []string      { return nil }
+func (c *specCommand) Role() dto.WhatsappRole { return dto.RoleGuest }
+func (c *specCommand) Cooldown() Cooldown     { return Cooldown{} }
+func (c *specCommand) Args() ArgSpec          { return c.spec }
+func (c *specCommand) Execute(ctx context.Context, args *Args) CommandResult {
+	return CommandResult{}
+}
+
+var testSpecCommand = &specCommand{spec: ArgSpec{
+	Args: []Arg{
+		{Name: "id", Type: ArgInt},
+		{Name: "mode", Type: ArgEnum, Enum: []string{"fast", "slow"}, Optional: true},
+		{Name: "note", Type: ArgText, Optional: true},
+	},
+	Flags: []Flag{
+		{Name: "force", Type: ArgBool},
+		{Name: "wait", Type: ArgInt},
+	},
+}}
+
+func TestParseArgs(t *testing.T) {
+	args, err := ParseArgs(testSpecCommand, []string{"3", "--force", "SLOW", "hello", "there", "--wait=10"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, args.Int("id"))
+	assert.Equal(t, "slow", args.String("mode"))
+	assert.Equal(t, "hello there", args.String("note"))
+	assert.True(t, args.Bool("force"))
+	assert.Equal(t, 10, args.Int("wait"))
+
+	args, err = ParseArgs(testSpecCommand, []string{"3", "--wait", "5"})
+	require.NoError(t, err)
+	assert.False(t, args.Has("mode"))
+	assert.Equal(t, 5, args.Int("wait"))
+
+	// the IDs are shown as "#3"
+	args, err = ParseArgs(testSpecCommand, []string{"#3"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, args.Int("id"))
+}
+
+func TestParseArgs_Errors(t *testing.T) {
+	tests := []struct {
+		raw  []string
+		want error
+		msg  string
+	}{
+		{raw: nil, want: errs.ErrCommandMissingArg, msg: "missing argument 'id'"},
+		{raw: []string{"abc"}, want: errs.ErrCommandInvalidArg, msg: "argument 'id' must be a number"},
+		{raw: []string{"-1"}, want: errs.ErrCommandInvalidArg, msg: "argument 'id' must be a number"},
+		{raw: []string{"#"}, want: errs.ErrCommandInvalidArg, msg: "argument 'id' must be a number"},
+		{raw: []string{"1", "medium"}, want: errs.ErrCommandInvalidArg, msg: "argument 'mode' must be one of: fast, slow"},
+		{raw: []string{"1", "--nope"}, want: errs.ErrCommandInvalidArg, msg: "unknown flag '--nope'"},
+		{raw: []string{"1", "--wait"}, want: errs.ErrCommandMissingArg, msg: "flag '--wait' needs a value"},
+		{raw: []string{"1", "--force=yes"}, want: errs.ErrCommandInvalidArg, msg: "flag '--force' doesn't take a value"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseArgs(testSpecCommand, tt.raw)
+		require.Error(t, err, tt.raw)
+
+		var argErr *ArgError
+		require.True(t, errors.As(err, &argErr))
+		assert.ErrorIs(t, err, tt.want)
+		assert.Equal(t, tt.msg, err.Error())
+		assert.Equal(t, Usage(testSpecCommand), argErr.Usage)
+	}
+
+	_, err := ParseArgs(&specCommand{spec: ArgSpec{Args: []Arg{{Name: "id", Type: ArgInt}}}}, []string{"1", "2"})
+	assert.EqualError(t, err, "too many arguments")
+}
+
+func TestArgError_In(t *testing.T) {
+	_, err := ParseArgs(testSpecCommand, []string{"abc"})
+
+	var argErr *ArgError
+	require.ErrorAs(t, err, &argErr)
+	assert.Equal(t, "argument 'id' must be a number", argErr.In(i18n.EN))
+	assert.Equal(t, "argumen 'id' harus berupa angka", argErr.In(i18n.ID))
+}
+
+func TestUsageDetail(t *testing.T) {
+	cmd := &specCommand{spec: ArgSpec{Args: []Arg{{Name: "id", Type: ArgInt, Help: "arg_help.server_id"}}}}
+
+	assert.Equal(t, "Arguments:\n- id: "+i18n.T(i18n.EN, "arg_help.server_id"), UsageDetail(cmd, i18n.EN))
+	assert.Equal(t, "Argumen:\n- id: "+i18n.T(i18n.ID, "arg_help.server_id"), UsageDetail(cmd, i18n.ID))
+}
+
+func TestUsage(t *testing.T) {
+	assert.Equal(t, "/test <id> [fast|slow] [note...] [--force] [--wait=wait]", Usage(testSpecCommand))
+	assert.Equal(t, "/start <id> [--own-credit]", Usage(NewStartServerCommand(nil, nil, nil)))
+	assert.Equal(t, "/status", Usage(NewStatusCommand(nil, nil, nil, nil)))
+}
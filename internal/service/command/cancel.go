@@ -0,0 +1,65 @@
+package command
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/render"
+	"exaroton-wa-bot/internal/service"
+)
+
+var (
+	CancelJobCmdName = "cancel"
+)
+
+var _ Command = new(CancelJobCommand)
+
+type CancelJobCommand struct {
+	jobSvc  service.IJobService
+	tmplSvc service.IMessageTemplateService
+}
+
+func NewCancelJobCommand(jobSvc service.IJobService, tmplSvc service.IMessageTemplateService) *CancelJobCommand {
+	return &CancelJobCommand{
+		jobSvc:  jobSvc,
+		tmplSvc: tmplSvc,
+	}
+}
+
+func (c *CancelJobCommand) Name() string {
+	return CancelJobCmdName
+}
+
+func (c *CancelJobCommand) Help() string {
+	return messages.HelpCancel
+}
+
+func (c *CancelJobCommand) Aliases() []string {
+	return nil
+}
+
+func (c *CancelJobCommand) Role() dto.WhatsappRole {
+	return dto.RolePlayer
+}
+
+func (c *CancelJobCommand) Cooldown() Cooldown {
+	return Cooldown{}
+}
+
+func (c *CancelJobCommand) Args() ArgSpec {
+	return ArgSpec{Args: []Arg{
+		{Name: "job-id", Type: ArgInt, Help: messages.ArgHelpJobID},
+	}}
+}
+
+func (c *CancelJobCommand) Execute(ctx context.Context, args *Args) CommandResult {
+	jobID := args.Int("job-id")
+
+	origin := service.JobOriginFromContext(ctx)
+	if err := c.jobSvc.Cancel(ctx, origin.Chat, uint(jobID)); err != nil {
+		return CommandResult{Error: err}
+	}
+
+	text, err := c.tmplSvc.Render(ctx, render.TmplJobCancelling, dto.JobIDTmplData{JobID: uint(jobID)})
+	return CommandResult{Text: text, Error: err}
+}
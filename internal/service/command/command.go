@@ -2,51 +2,111 @@ package command
 
 import (
 	"context"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
 	"exaroton-wa-bot/internal/service"
+	"time"
 )
 
+// ServerIDArg is the index of a server, as listed by /servers.
+var ServerIDArg = Arg{Name: "id", Type: ArgInt, Help: messages.ArgHelpServerID}
+
 type (
 	Command interface {
 		Name() string
+
+		// Help is the message ID of the command's description, e.g. messages.HelpStart.
 		Help() string
-		Usage() string
-		Execute(c context.Context, args []string) CommandResult
+
+		// Aliases are other names for the command, e.g. "s" for "start".
+		Aliases() []string
+
+		// Role is the minimum role needed to use the command.
+		Role() dto.WhatsappRole
+
+		// Cooldown is how often a user can use the command in a chat.
+		Cooldown() Cooldown
+
+		// Args declares the accepted arguments, used to parse and validate
+		// them before Execute and to generate the usage.
+		Args() ArgSpec
+		Execute(c context.Context, args *Args) CommandResult
+	}
+
+	// Cooldown limits how often a user can use a command, the zero value
+	// means no cooldown.
+	Cooldown struct {
+		Every time.Duration
+		Arg   string // if set, the cooldown is per value of this arg, e.g. per server id
 	}
 
 	Registry struct {
 		commands map[string]Command
+		aliases  map[string]string // alias -> command name
 	}
 
 	CommandResult struct {
-		Text  string
+		Text  string // rendered from a message template, see render.TemplateDefs
 		Error error
+
+		// Job is set if the command started a background job, the job
+		// reports its progress to the JobOrigin of the command's context.
+		Job *dto.Job
+
+		// Card renders the result as a PNG image, nil if the command has none.
+		// It's only rendered in groups with the card mode enabled.
+		Card func() ([]byte, error)
 	}
 )
 
-func NewRegistry(WhatsappService service.IWhatsappService, serverSettingsSvc service.IServerSettingsService) *Registry {
+func NewRegistry(
+	cfg *config.Cfg,
+	WhatsappService service.IWhatsappService,
+	serverSettingsSvc service.IServerSettingsService,
+	jobSvc service.IJobService,
+	tmplSvc service.IMessageTemplateService,
+	registrationSvc service.IWhatsappRegistrationService,
+	roleSvc service.IRoleService,
+) *Registry {
 	r := &Registry{
 		commands: make(map[string]Command),
+		aliases:  make(map[string]string),
 	}
 
 	// register commands here...
-	r.Register(NewHelpCommand(r))
-	r.Register(NewListServerCommand(serverSettingsSvc))
-	r.Register(NewStartServerCommand(serverSettingsSvc))
-	r.Register(NewInfoCommand(serverSettingsSvc))
-	r.Register(NewStopServerCommand(serverSettingsSvc))
-	r.Register(NewListPlayersCommand(serverSettingsSvc))
+	r.Register(NewHelpCommand(r, tmplSvc))
+	r.Register(NewListServerCommand(WhatsappService, serverSettingsSvc, tmplSvc))
+	r.Register(NewStartServerCommand(serverSettingsSvc, jobSvc, tmplSvc))
+	r.Register(NewInfoCommand(serverSettingsSvc, tmplSvc))
+	r.Register(NewStopServerCommand(serverSettingsSvc, tmplSvc))
+	r.Register(NewRestartServerCommand(serverSettingsSvc, tmplSvc))
+	r.Register(NewListPlayersCommand(serverSettingsSvc, tmplSvc))
+	r.Register(NewStatusCommand(cfg, WhatsappService, serverSettingsSvc, tmplSvc))
+	r.Register(NewJobsCommand(jobSvc, tmplSvc))
+	r.Register(NewCancelJobCommand(jobSvc, tmplSvc))
+	r.Register(NewWhoAmICommand(roleSvc, tmplSvc))
+	r.Register(NewLangCommand(WhatsappService, tmplSvc))
+	r.Register(NewRegisterCommand(registrationSvc, serverSettingsSvc, tmplSvc))
 
 	return r
 }
 
 // Register a new command to the registry.
-// The command is identified by its Name method.
+// The command is identified by its Name method, or one of its Aliases.
 func (r *Registry) Register(cmd Command) {
-	_, ok := r.commands[cmd.Name()]
-	if ok {
+	if _, ok := r.Get(cmd.Name()); ok {
 		panic("command name must be unique")
 	}
 
+	for _, alias := range cmd.Aliases() {
+		if _, ok := r.Get(alias); ok || alias == cmd.Name() {
+			panic("command alias must be unique")
+		}
+		r.aliases[alias] = cmd.Name()
+	}
+
 	r.commands[cmd.Name()] = cmd
 }
 
@@ -59,9 +119,39 @@ func (r *Registry) List() []Command {
 	return out
 }
 
-// Get a command by its name.
+// Get a command by its name or alias.
 // Returns the command and whether it was found.
 func (r *Registry) Get(name string) (Command, bool) {
+	if alias, ok := r.aliases[name]; ok {
+		name = alias
+	}
+
 	cmd, ok := r.commands[name]
 	return cmd, ok
 }
+
+// Parse parses and validates raw args for a command by its name.
+func (r *Registry) Parse(name string, raw []string) (*Args, error) {
+	cmd, ok := r.Get(name)
+	if !ok {
+		return nil, errs.ErrCommandNotFound
+	}
+
+	return ParseArgs(cmd, raw)
+}
+
+// Execute parses and validates raw args, then executes the command by its name.
+// Bad args are returned as an *ArgError.
+func (r *Registry) Execute(ctx context.Context, name string, raw []string) CommandResult {
+	cmd, ok := r.Get(name)
+	if !ok {
+		return CommandResult{Error: errs.ErrCommandNotFound}
+	}
+
+	args, err := ParseArgs(cmd, raw)
+	if err != nil {
+		return CommandResult{Error: err}
+	}
+
+	return cmd.Execute(ctx, args)
+}
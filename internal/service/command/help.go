@@ -5,9 +5,14 @@ import (
 	"exaroton-wa-bot/internal/constants/errs"
 	"exaroton-wa-bot/internal/constants/messages"
 	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	"exaroton-wa-bot/internal/render"
+	"exaroton-wa-bot/internal/service"
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
 var (
@@ -18,10 +23,11 @@ var _ Command = new(HelpCommand)
 
 type HelpCommand struct {
 	registry *Registry
+	tmplSvc  service.IMessageTemplateService
 }
 
-func NewHelpCommand(r *Registry) *HelpCommand {
-	return &HelpCommand{registry: r}
+func NewHelpCommand(r *Registry, tmplSvc service.IMessageTemplateService) *HelpCommand {
+	return &HelpCommand{registry: r, tmplSvc: tmplSvc}
 }
 
 func (c *HelpCommand) Name() string {
@@ -29,27 +35,42 @@ func (c *HelpCommand) Name() string {
 }
 
 func (c *HelpCommand) Help() string {
-	return "Show available commands"
+	return messages.HelpHelp
 }
 
-func (c *HelpCommand) Usage() string {
-	return "/help [page|command]"
+func (c *HelpCommand) Aliases() []string {
+	return []string{"h"}
 }
 
-func (c *HelpCommand) Execute(ctx context.Context, args []string) CommandResult {
+func (c *HelpCommand) Role() dto.WhatsappRole {
+	return dto.RoleGuest
+}
+
+func (c *HelpCommand) Cooldown() Cooldown {
+	return Cooldown{}
+}
+
+func (c *HelpCommand) Args() ArgSpec {
+	return ArgSpec{Args: []Arg{
+		{Name: "topic", Type: ArgString, Help: messages.ArgHelpTopic, Optional: true},
+	}}
+}
+
+func (c *HelpCommand) Execute(ctx context.Context, args *Args) CommandResult {
 	cmds := c.registry.List()
 
-	if len(args) == 0 {
+	topic := args.String("topic")
+	if topic == "" {
 		return c.showPage(ctx, cmds, 1)
 	}
 
 	// pagination
-	if page, err := strconv.Atoi(args[0]); err == nil {
+	if page, err := strconv.Atoi(topic); err == nil {
 		return c.showPage(ctx, cmds, page)
 	}
 
 	// command detail
-	return c.showCommandDetail(ctx, args[0])
+	return c.showCommandDetail(ctx, strings.TrimPrefix(topic, "/"))
 }
 
 func (c *HelpCommand) showPage(ctx context.Context, cmds []Command, page int) CommandResult {
@@ -57,19 +78,16 @@ func (c *HelpCommand) showPage(ctx context.Context, cmds []Command, page int) Co
 		return cmds[i].Name() < cmds[j].Name()
 	})
 
+	lang := i18n.FromContext(ctx)
 	pag := dto.NewPagination(page, 7, len(cmds))
-
-	msg := fmt.Sprintf(messages.CmdShowingPage, c.Name(), pag.CurrentPage, pag.TotalPage) + "\n\n"
+	data := dto.HelpPageTmplData{Command: c.Name(), Page: pag.CurrentPage, TotalPage: pag.TotalPage}
 
 	for _, cmd := range cmds[pag.Start():pag.End()] {
-		msg += fmt.Sprintf(
-			"/%-10s %s\n",
-			cmd.Name(),
-			cmd.Help(),
-		)
+		data.Commands = append(data.Commands, dto.HelpEntryTmplData{Name: cmd.Name(), Help: i18n.T(lang, cmd.Help())})
 	}
 
-	return CommandResult{Text: msg}
+	text, err := c.tmplSvc.Render(ctx, render.TmplHelpPage, data)
+	return CommandResult{Text: text, Error: err}
 }
 
 func (c *HelpCommand) showCommandDetail(ctx context.Context, name string) CommandResult {
@@ -78,12 +96,35 @@ func (c *HelpCommand) showCommandDetail(ctx context.Context, name string) Comman
 		return CommandResult{Error: errs.ErrCommandNotFound}
 	}
 
-	msg := fmt.Sprintf(
-		"%s\n\n%s\n\nUsage:\n%s",
-		cmd.Name(),
-		cmd.Help(),
-		cmd.Usage(),
-	)
+	lang := i18n.FromContext(ctx)
+	data := dto.HelpCommandTmplData{
+		Name:    cmd.Name(),
+		Help:    i18n.T(lang, cmd.Help()),
+		Usage:   Usage(cmd),
+		Aliases: cmd.Aliases(),
+		Detail:  UsageDetail(cmd, lang),
+	}
+
+	if role := cmd.Role(); role > dto.RoleGuest {
+		data.Role = role.String()
+	}
+
+	if cd := cmd.Cooldown(); cd.Every > 0 {
+		data.Cooldown = formatEvery(cd.Every)
+		if cd.Arg != "" {
+			data.Cooldown += " per " + cd.Arg
+		}
+	}
+
+	text, err := c.tmplSvc.Render(ctx, render.TmplHelpCommand, data)
+	return CommandResult{Text: text, Error: err}
+}
+
+// formatEvery formats a cooldown period, e.g. "2 min" instead of "2m0s".
+func formatEvery(d time.Duration) string {
+	if d >= time.Minute && d%time.Minute == 0 {
+		return fmt.Sprintf("%d min", int(d.Minutes()))
+	}
 
-	return CommandResult{Text: msg}
+	return d.String()
 }
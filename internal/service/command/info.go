@@ -2,12 +2,11 @@ package command
 
 import (
 	"context"
-	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/constants/messages"
 	"exaroton-wa-bot/internal/dto"
-	"exaroton-wa-bot/internal/helper"
+	"exaroton-wa-bot/internal/i18n"
+	"exaroton-wa-bot/internal/render"
 	"exaroton-wa-bot/internal/service"
-	"fmt"
-	"strconv"
 )
 
 var (
@@ -18,11 +17,13 @@ var _ Command = new(InfoCommand)
 
 type InfoCommand struct {
 	serverSettingsSvc service.IServerSettingsService
+	tmplSvc           service.IMessageTemplateService
 }
 
-func NewInfoCommand(serverSettingsSvc service.IServerSettingsService) *InfoCommand {
+func NewInfoCommand(serverSettingsSvc service.IServerSettingsService, tmplSvc service.IMessageTemplateService) *InfoCommand {
 	return &InfoCommand{
 		serverSettingsSvc: serverSettingsSvc,
+		tmplSvc:           tmplSvc,
 	}
 }
 
@@ -31,27 +32,27 @@ func (c *InfoCommand) Name() string {
 }
 
 func (c *InfoCommand) Help() string {
-	return "Check a server info by its ID"
+	return messages.HelpInfo
 }
 
-func (c *InfoCommand) Usage() string {
-	return "/info [id]"
+func (c *InfoCommand) Aliases() []string {
+	return []string{"i"}
 }
 
-func (c *InfoCommand) Execute(ctx context.Context, args []string) CommandResult {
-	if len(args) == 0 {
-		return CommandResult{Error: errs.ErrCommandMissingArg}
-	}
+func (c *InfoCommand) Role() dto.WhatsappRole {
+	return dto.RoleGuest
+}
 
-	var (
-		serverIdx int
-		err       error
-	)
-	if serverIdx, err = strconv.Atoi(args[0]); err != nil {
-		return CommandResult{
-			Error: errs.ErrCommandInvalidArg,
-		}
-	}
+func (c *InfoCommand) Cooldown() Cooldown {
+	return Cooldown{}
+}
+
+func (c *InfoCommand) Args() ArgSpec {
+	return ArgSpec{Args: []Arg{ServerIDArg}}
+}
+
+func (c *InfoCommand) Execute(ctx context.Context, args *Args) CommandResult {
+	serverIdx := args.Int(ServerIDArg.Name)
 
 	server, err := c.serverSettingsSvc.GetExarotonServerInfo(ctx, uint(serverIdx))
 	if err != nil {
@@ -60,24 +61,15 @@ func (c *InfoCommand) Execute(ctx context.Context, args []string) CommandResult
 		}
 	}
 
-	return CommandResult{
-		Text: turnServerInfoIntoText(server, uint(serverIdx)),
+	text, err := c.tmplSvc.Render(ctx, render.TmplInfo, dto.ServerTmplData{Idx: uint(serverIdx), Server: server})
+	if err != nil {
+		return CommandResult{Error: err}
 	}
-}
 
-func turnServerInfoIntoText(server *dto.ExarotonServerInfo, serverIdx uint) string {
-	return fmt.Sprintf("ID: %d [%s] \nName: %s\nAddress: %s\nMotd: %s\nStatus: %s\nHost: %s\nPort: %s\nPlayers:%d/%d\nSoftware: %s %s\nShared: %t",
-		serverIdx,
-		server.ID,
-		server.Name,
-		server.Address,
-		server.Motd,
-		server.Status,
-		helper.Deref(server.Host),
-		helper.If(helper.IsZero(server.Port), "", strconv.Itoa(helper.Deref(server.Port))),
-		server.Players.Count,
-		server.Players.Max,
-		server.Software.Name,
-		server.Software.Version,
-		server.Shared)
+	return CommandResult{
+		Text: text,
+		Card: func() ([]byte, error) {
+			return render.ServerCard(i18n.FromContext(ctx), server, uint(serverIdx))
+		},
+	}
 }
@@ -0,0 +1,68 @@
+package command
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/render"
+	"exaroton-wa-bot/internal/service"
+	"time"
+)
+
+var (
+	JobsCmdName = "jobs"
+)
+
+var _ Command = new(JobsCommand)
+
+type JobsCommand struct {
+	jobSvc  service.IJobService
+	tmplSvc service.IMessageTemplateService
+}
+
+func NewJobsCommand(jobSvc service.IJobService, tmplSvc service.IMessageTemplateService) *JobsCommand {
+	return &JobsCommand{
+		jobSvc:  jobSvc,
+		tmplSvc: tmplSvc,
+	}
+}
+
+func (c *JobsCommand) Name() string {
+	return JobsCmdName
+}
+
+func (c *JobsCommand) Help() string {
+	return messages.HelpJobs
+}
+
+func (c *JobsCommand) Aliases() []string {
+	return nil
+}
+
+func (c *JobsCommand) Role() dto.WhatsappRole {
+	return dto.RoleGuest
+}
+
+func (c *JobsCommand) Cooldown() Cooldown {
+	return Cooldown{}
+}
+
+func (c *JobsCommand) Args() ArgSpec {
+	return ArgSpec{}
+}
+
+func (c *JobsCommand) Execute(ctx context.Context, args *Args) CommandResult {
+	origin := service.JobOriginFromContext(ctx)
+
+	data := dto.JobsTmplData{Command: c.Name()}
+	for _, job := range c.jobSvc.List(ctx, origin.Chat) {
+		data.Jobs = append(data.Jobs, dto.JobTmplData{
+			ID:   job.ID,
+			Name: job.Name,
+			Age:  time.Since(job.StartedAt).Round(time.Second).String(),
+		})
+	}
+
+	text, err := c.tmplSvc.Render(ctx, render.TmplJobs, data)
+	return CommandResult{Text: text, Error: err}
+}
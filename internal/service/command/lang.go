@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	"exaroton-wa-bot/internal/render"
+	"exaroton-wa-bot/internal/service"
+)
+
+var (
+	LangCmdName = "lang"
+)
+
+var _ Command = new(LangCommand)
+
+// LangCommand shows the language of the chat, or changes it.
+type LangCommand struct {
+	waSvc   service.IWhatsappService
+	tmplSvc service.IMessageTemplateService
+}
+
+func NewLangCommand(waSvc service.IWhatsappService, tmplSvc service.IMessageTemplateService) *LangCommand {
+	return &LangCommand{
+		waSvc:   waSvc,
+		tmplSvc: tmplSvc,
+	}
+}
+
+func (c *LangCommand) Name() string {
+	return LangCmdName
+}
+
+func (c *LangCommand) Help() string {
+	return messages.HelpLang
+}
+
+func (c *LangCommand) Aliases() []string {
+	return nil
+}
+
+func (c *LangCommand) Role() dto.WhatsappRole {
+	return dto.RoleOperator
+}
+
+func (c *LangCommand) Cooldown() Cooldown {
+	return Cooldown{}
+}
+
+func (c *LangCommand) Args() ArgSpec {
+	langs := i18n.Langs()
+	codes := make([]string, len(langs))
+	for i, lang := range langs {
+		codes[i] = lang.String()
+	}
+
+	return ArgSpec{
+		Args: []Arg{
+			{Name: "language", Type: ArgEnum, Enum: codes, Optional: true, Help: messages.ArgHelpLanguage},
+		},
+	}
+}
+
+func (c *LangCommand) Execute(ctx context.Context, args *Args) CommandResult {
+	lang := i18n.FromContext(ctx)
+	changed := args.Has("language")
+
+	if changed {
+		lang, _ = i18n.ParseLang(args.String("language"))
+
+		caller := service.CallerFromContext(ctx)
+		if err := c.waSvc.SetGroupLanguage(ctx, caller.Chat, lang); err != nil {
+			return CommandResult{Error: err}
+		}
+
+		// the reply is already in the new language
+		ctx = i18n.WithLang(ctx, lang)
+	}
+
+	data := dto.LangTmplData{Code: lang.String(), Name: lang.Name(), Changed: changed}
+	for _, l := range i18n.Langs() {
+		data.Langs = append(data.Langs, dto.LangOptionTmplData{Code: l.String(), Name: l.Name()})
+	}
+
+	text, err := c.tmplSvc.Render(ctx, render.TmplLang, data)
+	return CommandResult{Text: text, Error: err}
+}
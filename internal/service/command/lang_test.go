@@ -0,0 +1,65 @@
+package command
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	mockService "exaroton-wa-bot/internal/mocks/service"
+	"exaroton-wa-bot/internal/render"
+	"exaroton-wa-bot/internal/service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLangCommand_Execute(t *testing.T) {
+	chat := dto.WhatsappJID{User: "1203630", Server: "g.us"}
+	ctx := service.WithCaller(i18n.WithLang(context.Background(), i18n.EN), &service.Caller{Chat: chat})
+
+	t.Run("shows the language", func(t *testing.T) {
+		tmplSvc := mockService.NewMockIMessageTemplateService(t)
+		tmplSvc.EXPECT().Render(mock.Anything, render.TmplLang, mock.Anything).
+			RunAndReturn(func(ctx context.Context, name string, data any) (string, error) {
+				lang := data.(dto.LangTmplData)
+				assert.Equal(t, "en", lang.Code)
+				assert.False(t, lang.Changed)
+				assert.Len(t, lang.Langs, len(i18n.Langs()))
+				return "English", nil
+			})
+
+		cmd := NewLangCommand(mockService.NewMockIWhatsappService(t), tmplSvc)
+		args, err := ParseArgs(cmd, nil)
+		require.NoError(t, err)
+
+		res := cmd.Execute(ctx, args)
+		require.NoError(t, res.Error)
+		assert.Equal(t, "English", res.Text)
+	})
+
+	t.Run("changes the language", func(t *testing.T) {
+		waSvc := mockService.NewMockIWhatsappService(t)
+		waSvc.EXPECT().SetGroupLanguage(mock.Anything, chat, i18n.ID).Return(nil)
+
+		tmplSvc := mockService.NewMockIMessageTemplateService(t)
+		tmplSvc.EXPECT().Render(mock.Anything, render.TmplLang, mock.Anything).
+			RunAndReturn(func(ctx context.Context, name string, data any) (string, error) {
+				assert.Equal(t, i18n.ID, i18n.FromContext(ctx), "replies in the new language")
+				assert.True(t, data.(dto.LangTmplData).Changed)
+				return "Bahasa Indonesia", nil
+			})
+
+		cmd := NewLangCommand(waSvc, tmplSvc)
+		args, err := ParseArgs(cmd, []string{"id"})
+		require.NoError(t, err)
+
+		res := cmd.Execute(ctx, args)
+		require.NoError(t, res.Error)
+	})
+
+	t.Run("unknown language", func(t *testing.T) {
+		_, err := ParseArgs(NewLangCommand(nil, nil), []string{"fr"})
+		assert.Error(t, err)
+	})
+}
@@ -2,11 +2,10 @@ package command
 
 import (
 	"context"
-	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/constants/messages"
 	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/render"
 	"exaroton-wa-bot/internal/service"
-	"fmt"
-	"strconv"
 )
 
 var (
@@ -17,11 +16,13 @@ var _ Command = new(ListPlayersCommand)
 
 type ListPlayersCommand struct {
 	serverSettingsSvc service.IServerSettingsService
+	tmplSvc           service.IMessageTemplateService
 }
 
-func NewListPlayersCommand(serverSettingsSvc service.IServerSettingsService) *ListPlayersCommand {
+func NewListPlayersCommand(serverSettingsSvc service.IServerSettingsService, tmplSvc service.IMessageTemplateService) *ListPlayersCommand {
 	return &ListPlayersCommand{
 		serverSettingsSvc: serverSettingsSvc,
+		tmplSvc:           tmplSvc,
 	}
 }
 
@@ -30,27 +31,27 @@ func (c *ListPlayersCommand) Name() string {
 }
 
 func (c *ListPlayersCommand) Help() string {
-	return "List players on a server by its ID"
+	return messages.HelpPlayers
 }
 
-func (c *ListPlayersCommand) Usage() string {
-	return "/players [id]"
+func (c *ListPlayersCommand) Aliases() []string {
+	return []string{"p"}
 }
 
-func (c *ListPlayersCommand) Execute(ctx context.Context, args []string) CommandResult {
-	if len(args) == 0 {
-		return CommandResult{Error: errs.ErrCommandMissingArg}
-	}
+func (c *ListPlayersCommand) Role() dto.WhatsappRole {
+	return dto.RoleGuest
+}
 
-	var (
-		serverIdx int
-		err       error
-	)
-	if serverIdx, err = strconv.Atoi(args[0]); err != nil {
-		return CommandResult{
-			Error: errs.ErrCommandInvalidArg,
-		}
-	}
+func (c *ListPlayersCommand) Cooldown() Cooldown {
+	return Cooldown{}
+}
+
+func (c *ListPlayersCommand) Args() ArgSpec {
+	return ArgSpec{Args: []Arg{ServerIDArg}}
+}
+
+func (c *ListPlayersCommand) Execute(ctx context.Context, args *Args) CommandResult {
+	serverIdx := args.Int(ServerIDArg.Name)
 
 	playerList, err := c.serverSettingsSvc.GetExarotonServerPlayerList(ctx, uint(serverIdx))
 	if err != nil {
@@ -59,20 +60,6 @@ func (c *ListPlayersCommand) Execute(ctx context.Context, args []string) Command
 		}
 	}
 
-	return CommandResult{
-		Text: c.formatPlayerListToText(uint(serverIdx), playerList),
-	}
-}
-
-func (c *ListPlayersCommand) formatPlayerListToText(serverId uint, playerList *dto.ExarotonServerPlayers) string {
-	if len(playerList.List) == 0 {
-		return "No players online."
-	}
-
-	result := fmt.Sprintf("[ServerID: %d] Players online(%d):\n", serverId, len(playerList.List))
-	for i, player := range playerList.List {
-		result += fmt.Sprintf("%d. %s\n", i+1, player)
-	}
-
-	return result
+	text, err := c.tmplSvc.Render(ctx, render.TmplPlayers, dto.PlayersTmplData{Idx: uint(serverIdx), Players: playerList.List})
+	return CommandResult{Text: text, Error: err}
 }
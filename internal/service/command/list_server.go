@@ -4,9 +4,8 @@ import (
 	"context"
 	"exaroton-wa-bot/internal/constants/messages"
 	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/render"
 	"exaroton-wa-bot/internal/service"
-	"fmt"
-	"strconv"
 )
 
 var (
@@ -16,12 +15,16 @@ var (
 var _ Command = new(ListServerCommand)
 
 type ListServerCommand struct {
+	waSvc             service.IWhatsappService
 	serverSettingsSvc service.IServerSettingsService
+	tmplSvc           service.IMessageTemplateService
 }
 
-func NewListServerCommand(serverSettingsSvc service.IServerSettingsService) *ListServerCommand {
+func NewListServerCommand(waSvc service.IWhatsappService, serverSettingsSvc service.IServerSettingsService, tmplSvc service.IMessageTemplateService) *ListServerCommand {
 	return &ListServerCommand{
+		waSvc:             waSvc,
 		serverSettingsSvc: serverSettingsSvc,
+		tmplSvc:           tmplSvc,
 	}
 }
 
@@ -30,60 +33,62 @@ func (c *ListServerCommand) Name() string {
 }
 
 func (c *ListServerCommand) Help() string {
-	return "Show available servers"
+	return messages.HelpServers
 }
 
-func (c *ListServerCommand) Usage() string {
-	return "/servers [page]"
+func (c *ListServerCommand) Aliases() []string {
+	return []string{"ls"}
 }
 
-func (c *ListServerCommand) Execute(ctx context.Context, args []string) CommandResult {
+func (c *ListServerCommand) Role() dto.WhatsappRole {
+	return dto.RoleGuest
+}
+
+func (c *ListServerCommand) Cooldown() Cooldown {
+	return Cooldown{}
+}
+
+func (c *ListServerCommand) Args() ArgSpec {
+	return ArgSpec{Args: []Arg{
+		{Name: "page", Type: ArgInt, Help: messages.ArgHelpPage, Optional: true},
+	}}
+}
+
+func (c *ListServerCommand) Execute(ctx context.Context, args *Args) CommandResult {
 	servers, err := c.serverSettingsSvc.ListExarotonServer(ctx)
 	if err != nil {
 		return CommandResult{Error: err}
 	}
 
+	settings, err := c.waSvc.GetGroupSettings(ctx, service.CallerFromContext(ctx).Chat)
+	if err != nil {
+		return CommandResult{Error: err}
+	}
+
+	// only the servers the chat can use are listed, with their index in the
+	// whole list as the other commands take it
+	var listed []dto.ServerTmplData
+	for i, srv := range servers {
+		if settings.AllowsServer(srv.ID) {
+			listed = append(listed, dto.ServerTmplData{Idx: uint(i), Server: srv})
+		}
+	}
+
 	// pagination
 	var (
 		page       = 1
 		limit      = 4
-		totalItems = len(servers)
+		totalItems = len(listed)
 	)
-	if len(args) > 0 {
-		page, err = strconv.Atoi(args[0])
-		if err != nil {
-			return CommandResult{Error: err}
-		}
+	if args.Has("page") {
+		page = args.Int("page")
 	}
 
 	pag := dto.NewPagination(page, limit, totalItems)
 
-	text := fmt.Sprintf(messages.CmdShowingPage, c.Name(), pag.CurrentPage, pag.TotalPage) + "\n\n"
-	text += c.formatServersIntoText(servers[pag.Start():pag.End()])
-
-	return CommandResult{Text: text}
-}
-
-func (c *ListServerCommand) formatServersIntoText(servers []*dto.ExarotonServerInfo) string {
-	var text string
-	for i, srv := range servers {
-		text += formatServerIntoText(i, srv) + "\n"
-	}
-
-	if text == "" {
-		text = "No servers found"
-	}
-
-	return text
-}
+	data := dto.ServersTmplData{Command: c.Name(), Page: pag.CurrentPage, TotalPage: pag.TotalPage}
+	data.Servers = listed[pag.Start():pag.End()]
 
-func formatServerIntoText(i int, srv *dto.ExarotonServerInfo) string {
-	return fmt.Sprintf("ID: %d [%s]\nName: %s\nAddress: %s\nStatus: %s\nSoftware: %s %s\n",
-		i,
-		srv.ID,
-		srv.Name,
-		srv.Address,
-		srv.Status,
-		srv.Software.Name,
-		srv.Software.Version)
+	text, err := c.tmplSvc.Render(ctx, render.TmplServers, data)
+	return CommandResult{Text: text, Error: err}
 }
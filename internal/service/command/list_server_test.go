@@ -0,0 +1,42 @@
+package command
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/dto"
+	mockService "exaroton-wa-bot/internal/mocks/service"
+	"exaroton-wa-bot/internal/render"
+	"exaroton-wa-bot/internal/service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListServerCommand_Execute(t *testing.T) {
+	chat := dto.WhatsappJID{User: "1203630", Server: "g.us"}
+	ctx := service.WithCaller(context.Background(), &service.Caller{Chat: chat})
+
+	servers := []*dto.ExarotonServerInfo{{ID: "a", Name: "A"}, {ID: "b", Name: "B"}, {ID: "c", Name: "C"}}
+
+	serverSettingsSvc := mockService.NewMockIServerSettingsService(t)
+	serverSettingsSvc.EXPECT().ListExarotonServer(mock.Anything).Return(servers, nil)
+
+	waSvc := mockService.NewMockIWhatsappService(t)
+	waSvc.EXPECT().GetGroupSettings(mock.Anything, chat).Return(&dto.WhatsappGroupSettings{Servers: []string{"c", "a"}}, nil)
+
+	// the servers out of the scope are left out, the others keep their index
+	tmplSvc := mockService.NewMockIMessageTemplateService(t)
+	tmplSvc.EXPECT().Render(mock.Anything, render.TmplServers, dto.ServersTmplData{
+		Command: ListServerCmdName, Page: 1, TotalPage: 1,
+		Servers: []dto.ServerTmplData{{Idx: 0, Server: servers[0]}, {Idx: 2, Server: servers[2]}},
+	}).Return("servers", nil)
+
+	cmd := NewListServerCommand(waSvc, serverSettingsSvc, tmplSvc)
+	args, err := ParseArgs(cmd, nil)
+	require.NoError(t, err)
+
+	res := cmd.Execute(ctx, args)
+	require.NoError(t, res.Error)
+	assert.Equal(t, "servers", res.Text)
+}
@@ -0,0 +1,107 @@
+package command
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/render"
+	"exaroton-wa-bot/internal/service"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+var (
+	RegisterCmdName = "register"
+)
+
+var _ Command = new(RegisterCommand)
+
+// RegisterCommand whitelists the group with a registration code from the web
+// UI, it's the only command a group that isn't whitelisted can use.
+type RegisterCommand struct {
+	registrationSvc   service.IWhatsappRegistrationService
+	serverSettingsSvc service.IServerSettingsService
+	tmplSvc           service.IMessageTemplateService
+}
+
+func NewRegisterCommand(registrationSvc service.IWhatsappRegistrationService, serverSettingsSvc service.IServerSettingsService,
+	tmplSvc service.IMessageTemplateService) *RegisterCommand {
+	return &RegisterCommand{
+		registrationSvc:   registrationSvc,
+		serverSettingsSvc: serverSettingsSvc,
+		tmplSvc:           tmplSvc,
+	}
+}
+
+func (c *RegisterCommand) Name() string {
+	return RegisterCmdName
+}
+
+func (c *RegisterCommand) Help() string {
+	return messages.HelpRegister
+}
+
+func (c *RegisterCommand) Aliases() []string {
+	return nil
+}
+
+func (c *RegisterCommand) Role() dto.WhatsappRole {
+	return dto.RoleGuest
+}
+
+func (c *RegisterCommand) Cooldown() Cooldown {
+	return Cooldown{}
+}
+
+func (c *RegisterCommand) Args() ArgSpec {
+	return ArgSpec{
+		Args: []Arg{
+			{Name: "code", Type: ArgString, Help: messages.ArgHelpRegistrationCode},
+		},
+	}
+}
+
+func (c *RegisterCommand) Execute(ctx context.Context, args *Args) CommandResult {
+	caller := service.CallerFromContext(ctx)
+
+	code, err := c.registrationSvc.Redeem(ctx, &dto.RedeemRegistrationCodeReq{
+		Code:       args.String("code"),
+		Group:      caller.Chat,
+		RedeemedBy: caller.User,
+	})
+	if err != nil {
+		return CommandResult{Error: err}
+	}
+
+	data := dto.RegisterTmplData{Servers: c.serverNames(ctx, code.Servers)}
+
+	text, err := c.tmplSvc.Render(ctx, render.TmplRegister, data)
+	return CommandResult{Text: text, Error: err}
+}
+
+// serverNames names the servers of the exaroton IDs as the poll of the server
+// commands does, e.g. "#0 Survival". A server that isn't listed (anymore)
+// keeps its ID.
+func (c *RegisterCommand) serverNames(ctx context.Context, ids []string) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	servers, err := c.serverSettingsSvc.ListExarotonServer(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to list the servers", "error", err.Error())
+	}
+
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = id
+
+		idx := slices.IndexFunc(servers, func(srv *dto.ExarotonServerInfo) bool { return srv.ID == id })
+		if idx >= 0 {
+			names[i] = fmt.Sprintf("#%d %s", idx, servers[idx].Name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,41 @@
+package command
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/dto"
+	mockService "exaroton-wa-bot/internal/mocks/service"
+	"exaroton-wa-bot/internal/render"
+	"exaroton-wa-bot/internal/service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterCommand_Execute(t *testing.T) {
+	chat := dto.WhatsappJID{User: "1203630", Server: "g.us"}
+	sender := dto.WhatsappJID{User: "6285", Server: "s.whatsapp.net"}
+	ctx := service.WithCaller(context.Background(), &service.Caller{Chat: chat, User: sender})
+
+	registrationSvc := mockService.NewMockIWhatsappRegistrationService(t)
+	registrationSvc.EXPECT().Redeem(mock.Anything, &dto.RedeemRegistrationCodeReq{Code: "K7QM2XHP", Group: chat, RedeemedBy: sender}).
+		Return(&dto.WhatsappRegistrationCode{Servers: []string{"c", "gone"}}, nil)
+
+	serverSettingsSvc := mockService.NewMockIServerSettingsService(t)
+	serverSettingsSvc.EXPECT().ListExarotonServer(mock.Anything).Return([]*dto.ExarotonServerInfo{
+		{ID: "a", Name: "Survival"}, {ID: "b", Name: "Skyblock"}, {ID: "c", Name: "Creative"},
+	}, nil)
+
+	// a server that was removed since the code was created keeps its ID
+	tmplSvc := mockService.NewMockIMessageTemplateService(t)
+	tmplSvc.EXPECT().Render(mock.Anything, render.TmplRegister, dto.RegisterTmplData{Servers: []string{"#2 Creative", "gone"}}).Return("registered", nil)
+
+	cmd := NewRegisterCommand(registrationSvc, serverSettingsSvc, tmplSvc)
+	args, err := ParseArgs(cmd, []string{"K7QM2XHP"})
+	require.NoError(t, err)
+
+	res := cmd.Execute(ctx, args)
+	require.NoError(t, res.Error)
+	assert.Equal(t, "registered", res.Text)
+}
@@ -0,0 +1,65 @@
+package command
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/render"
+	"exaroton-wa-bot/internal/service"
+	"time"
+)
+
+var (
+	RestartServerCmdName = "restart"
+)
+
+var _ Command = new(RestartServerCommand)
+
+type RestartServerCommand struct {
+	serverSettingsSvc service.IServerSettingsService
+	tmplSvc           service.IMessageTemplateService
+}
+
+func NewRestartServerCommand(serverSettingsSvc service.IServerSettingsService, tmplSvc service.IMessageTemplateService) *RestartServerCommand {
+	return &RestartServerCommand{
+		serverSettingsSvc: serverSettingsSvc,
+		tmplSvc:           tmplSvc,
+	}
+}
+
+func (c *RestartServerCommand) Name() string {
+	return RestartServerCmdName
+}
+
+func (c *RestartServerCommand) Help() string {
+	return messages.HelpRestart
+}
+
+func (c *RestartServerCommand) Aliases() []string {
+	return nil
+}
+
+func (c *RestartServerCommand) Role() dto.WhatsappRole {
+	return dto.RoleOperator
+}
+
+func (c *RestartServerCommand) Cooldown() Cooldown {
+	return Cooldown{Every: 2 * time.Minute, Arg: ServerIDArg.Name}
+}
+
+func (c *RestartServerCommand) Args() ArgSpec {
+	return ArgSpec{Args: []Arg{ServerIDArg}}
+}
+
+func (c *RestartServerCommand) Execute(ctx context.Context, args *Args) CommandResult {
+	serverIdx := args.Int(ServerIDArg.Name)
+
+	if err := c.serverSettingsSvc.RestartExarotonServer(ctx, uint(serverIdx)); err != nil {
+		return CommandResult{
+			Error: err,
+		}
+	}
+
+	text, err := c.tmplSvc.Render(ctx, render.TmplServerRestarting, dto.ServerIdxTmplData{Idx: serverIdx})
+	return CommandResult{Text: text, Error: err}
+}
@@ -2,12 +2,12 @@ package command
 
 import (
 	"context"
-	"exaroton-wa-bot/internal/constants/errs"
 	"exaroton-wa-bot/internal/constants/messages"
 	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/render"
 	"exaroton-wa-bot/internal/service"
 	"fmt"
-	"strconv"
+	"log/slog"
 	"time"
 )
 
@@ -19,11 +19,19 @@ var _ Command = new(StartServerCommand)
 
 type StartServerCommand struct {
 	serverSettingsSvc service.IServerSettingsService
+	jobSvc            service.IJobService
+	tmplSvc           service.IMessageTemplateService
 }
 
-func NewStartServerCommand(serverSettingsSvc service.IServerSettingsService) *StartServerCommand {
+func NewStartServerCommand(
+	serverSettingsSvc service.IServerSettingsService,
+	jobSvc service.IJobService,
+	tmplSvc service.IMessageTemplateService,
+) *StartServerCommand {
 	return &StartServerCommand{
 		serverSettingsSvc: serverSettingsSvc,
+		jobSvc:            jobSvc,
+		tmplSvc:           tmplSvc,
 	}
 }
 
@@ -32,44 +40,72 @@ func (c *StartServerCommand) Name() string {
 }
 
 func (c *StartServerCommand) Help() string {
-	return "Start a server by its ID"
+	return messages.HelpStart
 }
 
-func (c *StartServerCommand) Usage() string {
-	return "/start [id]"
+func (c *StartServerCommand) Aliases() []string {
+	return []string{"s"}
 }
 
-func (c *StartServerCommand) Execute(ctx context.Context, args []string) CommandResult {
-	if len(args) == 0 {
-		return CommandResult{Error: errs.ErrCommandMissingArg}
+func (c *StartServerCommand) Role() dto.WhatsappRole {
+	return dto.RolePlayer
+}
+
+func (c *StartServerCommand) Cooldown() Cooldown {
+	return Cooldown{Every: 2 * time.Minute, Arg: ServerIDArg.Name}
+}
+
+func (c *StartServerCommand) Args() ArgSpec {
+	return ArgSpec{
+		Args: []Arg{ServerIDArg},
+		Flags: []Flag{
+			{Name: "own-credit", Type: ArgBool, Help: messages.ArgHelpOwnCredit},
+		},
 	}
+}
 
-	var (
-		serverIdx int
-		err       error
-	)
-	if serverIdx, err = strconv.Atoi(args[0]); err != nil {
-		return CommandResult{
-			Error: errs.ErrCommandInvalidArg,
-		}
+// Execute starts the server in a background job and returns right away,
+// the job reports every polled status until the server is online.
+func (c *StartServerCommand) Execute(ctx context.Context, args *Args) CommandResult {
+	serverIdx := args.Int(ServerIDArg.Name)
+
+	opts := []service.StartExarotonServerOption{service.WithPolling(50*time.Second, 10*time.Second)}
+	if args.Bool("own-credit") {
+		opts = append(opts, service.WithOwnCredit())
 	}
 
-	startStatus := c.serverSettingsSvc.StartExarotonServer(ctx, uint(serverIdx), service.WithPolling(
-		50*time.Second,
-		10*time.Second,
-	))
-	if startStatus.Err != nil {
-		return CommandResult{
-			Error: err,
+	job := c.jobSvc.Run(ctx, fmt.Sprintf("start server %d", serverIdx), func(ctx context.Context, job *dto.Job, report service.JobReporter) (string, error) {
+		// a progress that fails to render is skipped, the start goes on
+		progress := func(status dto.ServerStatus) {
+			text, err := c.tmplSvc.Render(ctx, render.TmplStartProgress, dto.StartTmplData{Idx: serverIdx, Status: status, JobID: job.ID})
+			if err != nil {
+				slog.WarnContext(ctx, "failed to render start progress", "error", err.Error())
+				return
+			}
+
+			report(text)
 		}
-	}
 
-	lastStatus := dto.ServerStatusStarting
-	for v := range startStatus.Status {
-		lastStatus = v
-	}
+		progress(dto.ServerStatusStarting)
 
-	return CommandResult{
-		Text: fmt.Sprintf(messages.ServerStartFinish, serverIdx, lastStatus.String()),
-	}
+		startStatus := c.serverSettingsSvc.StartExarotonServer(ctx, uint(serverIdx), opts...)
+		if startStatus.Err != nil {
+			return "", startStatus.Err
+		}
+
+		lastStatus := dto.ServerStatusStarting
+		for v := range startStatus.Status {
+			lastStatus = v
+			progress(v)
+		}
+
+		// cancelled thru /cancel
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		return c.tmplSvc.Render(ctx, render.TmplStartFinish, dto.StartTmplData{Idx: serverIdx, Status: lastStatus, JobID: job.ID})
+	})
+
+	return CommandResult{Job: job}
 }
@@ -0,0 +1,138 @@
+package command
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	"exaroton-wa-bot/internal/render"
+	"exaroton-wa-bot/internal/service"
+	"fmt"
+	"time"
+)
+
+var (
+	StatusCmdName = "status"
+)
+
+var _ Command = new(StatusCommand)
+
+type StatusCommand struct {
+	cfg               *config.Cfg
+	waSvc             service.IWhatsappService
+	serverSettingsSvc service.IServerSettingsService
+	tmplSvc           service.IMessageTemplateService
+}
+
+func NewStatusCommand(cfg *config.Cfg, waSvc service.IWhatsappService, serverSettingsSvc service.IServerSettingsService, tmplSvc service.IMessageTemplateService) *StatusCommand {
+	return &StatusCommand{
+		cfg:               cfg,
+		waSvc:             waSvc,
+		serverSettingsSvc: serverSettingsSvc,
+		tmplSvc:           tmplSvc,
+	}
+}
+
+func (c *StatusCommand) Name() string {
+	return StatusCmdName
+}
+
+func (c *StatusCommand) Help() string {
+	return messages.HelpStatus
+}
+
+func (c *StatusCommand) Aliases() []string {
+	return []string{"st"}
+}
+
+func (c *StatusCommand) Role() dto.WhatsappRole {
+	return dto.RoleGuest
+}
+
+func (c *StatusCommand) Cooldown() Cooldown {
+	return Cooldown{}
+}
+
+func (c *StatusCommand) Args() ArgSpec {
+	return ArgSpec{}
+}
+
+func (c *StatusCommand) Execute(ctx context.Context, args *Args) CommandResult {
+	settings, err := c.waSvc.GetGroupSettings(ctx, service.CallerFromContext(ctx).Chat)
+	if err != nil {
+		return CommandResult{Error: err}
+	}
+
+	statuses, err := c.serverSettingsSvc.GetExarotonServersStatus(ctx, c.statusOptions(settings)...)
+	if err != nil {
+		return CommandResult{Error: err}
+	}
+
+	lang := i18n.FromContext(ctx)
+	data := dto.StatusTmplData{Command: c.Name()}
+	for _, st := range statuses {
+		data.Servers = append(data.Servers, dto.ServerStatusTmplData{
+			Idx:      st.Idx,
+			Server:   st.Server,
+			Outdated: st.Err != nil,
+			Uptime:   serverUptime(lang, st),
+		})
+	}
+
+	text, err := c.tmplSvc.Render(ctx, render.TmplStatus, data)
+	if err != nil {
+		return CommandResult{Error: err}
+	}
+
+	res := CommandResult{Text: text}
+	if len(statuses) > 0 {
+		res.Card = func() ([]byte, error) {
+			return render.StatusCard(lang, statuses)
+		}
+	}
+
+	return res
+}
+
+// statusOptions only fetches the servers the chat can use, with the configured
+// concurrency and call timeout, see config.KeyBotStatusWorkers.
+func (c *StatusCommand) statusOptions(settings *dto.WhatsappGroupSettings) []service.ServersStatusOption {
+	opts := []service.ServersStatusOption{service.WithServerFilter(settings.AllowsServer)}
+	if c.cfg == nil || c.cfg.Koanf == nil {
+		return opts
+	}
+
+	if n := c.cfg.Int(config.KeyBotStatusWorkers); n > 0 {
+		opts = append(opts, service.WithWorkers(n))
+	}
+	if d := c.cfg.Duration(config.KeyBotStatusCallTimeout); d > 0 {
+		opts = append(opts, service.WithCallTimeout(d))
+	}
+
+	return opts
+}
+
+// serverUptime returns the uptime of an online server, "" if it isn't online.
+func serverUptime(lang i18n.Lang, st *dto.ExarotonServerStatus) string {
+	if st.Server.Status != dto.ServerStatusOnline {
+		return ""
+	}
+
+	if st.OnlineSince == nil {
+		return i18n.T(lang, messages.UptimeUnknown)
+	}
+
+	return formatUptime(time.Since(*st.OnlineSince))
+}
+
+// formatUptime formats a duration as e.g. "1h 5m", rounded down to minutes.
+func formatUptime(d time.Duration) string {
+	h, m := int(d.Hours()), int(d.Minutes())%60
+
+	if h == 0 {
+		return fmt.Sprintf("%dm", m)
+	}
+
+	return fmt.Sprintf("%dh %dm", h, m)
+}
@@ -0,0 +1,24 @@
+package command
+
+import (
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerUptime(t *testing.T) {
+	online := &dto.ExarotonServerInfo{Status: dto.ServerStatusOnline}
+
+	assert.Empty(t, serverUptime(i18n.ID, &dto.ExarotonServerStatus{Server: &dto.ExarotonServerInfo{Status: dto.ServerStatusOffline}}))
+
+	// online before the bot first saw it
+	assert.Equal(t, i18n.T(i18n.ID, messages.UptimeUnknown), serverUptime(i18n.ID, &dto.ExarotonServerStatus{Server: online}))
+	assert.NotEqual(t, serverUptime(i18n.EN, &dto.ExarotonServerStatus{Server: online}), serverUptime(i18n.ID, &dto.ExarotonServerStatus{Server: online}))
+
+	since := time.Now().Add(-65 * time.Minute)
+	assert.Equal(t, "1h 5m", serverUptime(i18n.ID, &dto.ExarotonServerStatus{Server: online, OnlineSince: &since}))
+}
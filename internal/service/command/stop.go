@@ -2,10 +2,11 @@ package command
 
 import (
 	"context"
-	"exaroton-wa-bot/internal/constants/errs"
 	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/render"
 	"exaroton-wa-bot/internal/service"
-	"strconv"
+	"time"
 )
 
 var (
@@ -16,11 +17,13 @@ var _ Command = new(StopServerCommand)
 
 type StopServerCommand struct {
 	serverSettingsSvc service.IServerSettingsService
+	tmplSvc           service.IMessageTemplateService
 }
 
-func NewStopServerCommand(serverSettingsSvc service.IServerSettingsService) *StopServerCommand {
+func NewStopServerCommand(serverSettingsSvc service.IServerSettingsService, tmplSvc service.IMessageTemplateService) *StopServerCommand {
 	return &StopServerCommand{
 		serverSettingsSvc: serverSettingsSvc,
+		tmplSvc:           tmplSvc,
 	}
 }
 
@@ -29,35 +32,34 @@ func (c *StopServerCommand) Name() string {
 }
 
 func (c *StopServerCommand) Help() string {
-	return "Stop a server by its ID"
+	return messages.HelpStop
 }
 
-func (c *StopServerCommand) Usage() string {
-	return "/stop [id]"
+func (c *StopServerCommand) Aliases() []string {
+	return nil
 }
 
-func (c *StopServerCommand) Execute(ctx context.Context, args []string) CommandResult {
-	if len(args) == 0 {
-		return CommandResult{Error: errs.ErrCommandMissingArg}
-	}
+func (c *StopServerCommand) Role() dto.WhatsappRole {
+	return dto.RoleOperator
+}
 
-	var (
-		serverIdx int
-		err       error
-	)
-	if serverIdx, err = strconv.Atoi(args[0]); err != nil {
-		return CommandResult{
-			Error: errs.ErrCommandInvalidArg,
-		}
-	}
+func (c *StopServerCommand) Cooldown() Cooldown {
+	return Cooldown{Every: time.Minute, Arg: ServerIDArg.Name}
+}
+
+func (c *StopServerCommand) Args() ArgSpec {
+	return ArgSpec{Args: []Arg{ServerIDArg}}
+}
 
-	if err = c.serverSettingsSvc.StopExarotonServer(ctx, uint(serverIdx)); err != nil {
+func (c *StopServerCommand) Execute(ctx context.Context, args *Args) CommandResult {
+	serverIdx := args.Int(ServerIDArg.Name)
+
+	if err := c.serverSettingsSvc.StopExarotonServer(ctx, uint(serverIdx)); err != nil {
 		return CommandResult{
 			Error: err,
 		}
 	}
 
-	return CommandResult{
-		Text: messages.ServerIsStopping,
-	}
+	text, err := c.tmplSvc.Render(ctx, render.TmplServerStopping, dto.ServerIdxTmplData{Idx: serverIdx})
+	return CommandResult{Text: text, Error: err}
 }
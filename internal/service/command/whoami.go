@@ -0,0 +1,70 @@
+package command
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/render"
+	"exaroton-wa-bot/internal/service"
+)
+
+var (
+	WhoAmICmdName = "whoami"
+)
+
+var _ Command = new(WhoAmICommand)
+
+type WhoAmICommand struct {
+	roleSvc service.IRoleService
+	tmplSvc service.IMessageTemplateService
+}
+
+func NewWhoAmICommand(roleSvc service.IRoleService, tmplSvc service.IMessageTemplateService) *WhoAmICommand {
+	return &WhoAmICommand{
+		roleSvc: roleSvc,
+		tmplSvc: tmplSvc,
+	}
+}
+
+func (c *WhoAmICommand) Name() string {
+	return WhoAmICmdName
+}
+
+func (c *WhoAmICommand) Help() string {
+	return messages.HelpWhoAmI
+}
+
+func (c *WhoAmICommand) Aliases() []string {
+	return nil
+}
+
+func (c *WhoAmICommand) Role() dto.WhatsappRole {
+	return dto.RoleGuest
+}
+
+func (c *WhoAmICommand) Cooldown() Cooldown {
+	return Cooldown{}
+}
+
+func (c *WhoAmICommand) Args() ArgSpec {
+	return ArgSpec{}
+}
+
+func (c *WhoAmICommand) Execute(ctx context.Context, args *Args) CommandResult {
+	caller := service.CallerFromContext(ctx)
+
+	ids := []string{caller.User.String()}
+	if caller.UserAlt.User != "" {
+		ids = append(ids, caller.UserAlt.String())
+	}
+
+	// the caller's role is only resolved up to the command's, e.g. group
+	// admins have theirs looked up here
+	role, err := c.roleSvc.GetRole(ctx, caller.Chat, dto.RoleAdmin, caller.User, caller.UserAlt)
+	if err != nil {
+		return CommandResult{Error: err}
+	}
+
+	text, err := c.tmplSvc.Render(ctx, render.TmplWhoAmI, dto.WhoAmITmplData{IDs: ids, Role: role.String()})
+	return CommandResult{Text: text, Error: err}
+}
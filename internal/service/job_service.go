@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/constants/messages"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// JobFunc is the work of a background job. It should return once ctx is done,
+// the returned text is the job's final progress.
+type JobFunc func(ctx context.Context, job *dto.Job, report JobReporter) (string, error)
+
+// JobReporter shows a job's progress to the chat it was started from.
+type JobReporter func(text string)
+
+// JobOrigin is where a job is started from, passed to commands thru the context.
+type JobOrigin struct {
+	Chat   dto.WhatsappJID
+	Report JobReporter     // nil discards the progress
+	Done   func(err error) // nil if not set, called once the job returned, err is nil on success
+}
+
+type jobOriginKey struct{}
+
+// WithJobOrigin returns a copy of ctx carrying the origin of the jobs started with it.
+func WithJobOrigin(ctx context.Context, origin *JobOrigin) context.Context {
+	return context.WithValue(ctx, jobOriginKey{}, origin)
+}
+
+// JobOriginFromContext returns the origin carried by ctx, never nil.
+func JobOriginFromContext(ctx context.Context) *JobOrigin {
+	if origin, ok := ctx.Value(jobOriginKey{}).(*JobOrigin); ok && origin != nil {
+		return origin
+	}
+
+	return &JobOrigin{}
+}
+
+type IJobService interface {
+	// Run starts fn in the background with its own context and returns right away,
+	// the chat and progress reporter are taken from the JobOrigin of ctx.
+	Run(ctx context.Context, name string, fn JobFunc) *dto.Job
+
+	// List returns the running jobs of a chat of the context's device, oldest first.
+	List(ctx context.Context, chat dto.WhatsappJID) []*dto.Job
+
+	// Cancel cancels a running job of a chat.
+	Cancel(ctx context.Context, chat dto.WhatsappJID, jobID uint) error
+
+	// Shutdown cancels the running jobs of the context's device and waits for
+	// them to return or ctx to be done.
+	Shutdown(ctx context.Context) error
+}
+
+type JobService struct {
+	*svcTmpl
+
+	mu     sync.Mutex
+	nextID uint
+	jobs   map[uint]*runningJob
+}
+
+type runningJob struct {
+	job    *dto.Job
+	cancel context.CancelFunc
+	done   chan struct{} // closed once the job returned
+}
+
+func NewJobService(svcTmpl *svcTmpl) IJobService {
+	return &JobService{
+		svcTmpl: svcTmpl,
+		jobs:    make(map[uint]*runningJob),
+	}
+}
+
+func (s *JobService) Run(ctx context.Context, name string, fn JobFunc) *dto.Job {
+	origin := JobOriginFromContext(ctx)
+	report := origin.Report
+	if report == nil {
+		report = func(string) {}
+	}
+
+	// without ctx's deadline, the job outlives the command, but keeps its
+	// values (e.g. the request ID)
+	jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	s.mu.Lock()
+	s.nextID++
+	job := &dto.Job{
+		ID:        s.nextID,
+		Name:      name,
+		Chat:      origin.Chat,
+		Device:    dto.WhatsappDeviceFromContext(ctx),
+		StartedAt: time.Now(),
+	}
+	running := &runningJob{job: job, cancel: cancel, done: make(chan struct{})}
+	s.jobs[job.ID] = running
+	s.mu.Unlock()
+
+	go func() {
+		defer close(running.done)
+		defer func() {
+			s.mu.Lock()
+			delete(s.jobs, job.ID)
+			s.mu.Unlock()
+			cancel()
+		}()
+
+		text, err := s.run(jobCtx, job, fn, report)
+
+		switch {
+		case err != nil && errors.Is(jobCtx.Err(), context.Canceled):
+			report(i18n.T(i18n.FromContext(ctx), messages.JobCancelled, job.ID, job.Name))
+		case err != nil:
+			slog.ErrorContext(jobCtx, "job failed", "job", job.Name, "error", err.Error())
+			lang := i18n.FromContext(ctx)
+			report(i18n.T(lang, messages.JobFailed, job.ID, job.Name, jobErrorText(jobCtx, lang, err)))
+		default:
+			report(text)
+		}
+
+		if origin.Done != nil {
+			origin.Done(err)
+		}
+	}()
+
+	return job
+}
+
+// jobErrorText is what the chat is told about a job's error, in lang: the
+// message of a known error, else a reference to the logs of the job.
+func jobErrorText(ctx context.Context, lang i18n.Lang, err error) string {
+	var msgErr *i18n.Error
+
+	switch {
+	case errors.As(err, &msgErr):
+		return msgErr.In(lang)
+	case errors.Is(err, context.DeadlineExceeded):
+		return i18n.T(lang, messages.CmdTimeout)
+	}
+
+	if id, ok := messages.ErrorID(err); ok {
+		return i18n.T(lang, id)
+	}
+
+	return i18n.T(lang, messages.CmdFailed, config.RequestIDFromContext(ctx))
+}
+
+// run runs fn, a panic fails the job instead of crashing the app.
+func (s *JobService) run(ctx context.Context, job *dto.Job, fn JobFunc, report JobReporter) (text string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return fn(ctx, job, report)
+}
+
+func (s *JobService) List(ctx context.Context, chat dto.WhatsappJID) []*dto.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]*dto.Job, 0)
+	for _, j := range s.jobs {
+		if isJobOf(ctx, j.job, chat) {
+			jobs = append(jobs, j.job)
+		}
+	}
+
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].ID < jobs[k].ID })
+
+	return jobs
+}
+
+func (s *JobService) Cancel(ctx context.Context, chat dto.WhatsappJID, jobID uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[jobID]
+	if !ok || !isJobOf(ctx, j.job, chat) {
+		return errs.ErrJobNotFound
+	}
+
+	j.cancel()
+
+	return nil
+}
+
+// isJobOf reports whether a job was started from a chat of the context's device.
+func isJobOf(ctx context.Context, job *dto.Job, chat dto.WhatsappJID) bool {
+	return job.Device == dto.WhatsappDeviceFromContext(ctx) && job.Chat.User == chat.User && job.Chat.Server == chat.Server
+}
+
+func (s *JobService) Shutdown(ctx context.Context) error {
+	device := dto.WhatsappDeviceFromContext(ctx)
+
+	s.mu.Lock()
+	stopping := make([]*runningJob, 0)
+	for _, j := range s.jobs {
+		if j.job.Device == device {
+			j.cancel()
+			stopping = append(stopping, j)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, j := range stopping {
+		select {
+		case <-j.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
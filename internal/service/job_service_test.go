@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reports collects the progress reported by jobs.
+type reports struct {
+	mu    sync.Mutex
+	texts []string
+}
+
+func (r *reports) report(text string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.texts = append(r.texts, text)
+}
+
+func (r *reports) last() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.texts) == 0 {
+		return ""
+	}
+	return r.texts[len(r.texts)-1]
+}
+
+func TestJobService_Run(t *testing.T) {
+	svc := NewJobService(&svcTmpl{})
+	chat := dto.WhatsappJID{User: "123", Server: "g.us"}
+	r := new(reports)
+	done := make(chan error, 1)
+	ctx := WithJobOrigin(context.Background(), &JobOrigin{Chat: chat, Report: r.report, Done: func(err error) { done <- err }})
+
+	release := make(chan struct{})
+	job := svc.Run(ctx, "test", func(ctx context.Context, job *dto.Job, report JobReporter) (string, error) {
+		report("working")
+		<-release
+		return "done", nil
+	})
+
+	assert.Equal(t, chat, job.Chat)
+	assert.Len(t, svc.List(ctx, chat), 1)
+	assert.Empty(t, svc.List(ctx, dto.WhatsappJID{User: "other", Server: "g.us"}))
+
+	close(release)
+	require.NoError(t, svc.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"working", "done"}, r.texts)
+	assert.Empty(t, svc.List(ctx, chat))
+	assert.NoError(t, <-done)
+}
+
+func TestJobService_Cancel(t *testing.T) {
+	svc := NewJobService(&svcTmpl{})
+	chat := dto.WhatsappJID{User: "123", Server: "g.us"}
+	r := new(reports)
+	done := make(chan error, 1)
+	ctx := WithJobOrigin(context.Background(), &JobOrigin{Chat: chat, Report: r.report, Done: func(err error) { done <- err }})
+
+	job := svc.Run(ctx, "test", func(ctx context.Context, job *dto.Job, report JobReporter) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+
+	err := svc.Cancel(ctx, dto.WhatsappJID{User: "other", Server: "g.us"}, job.ID)
+	assert.True(t, errors.Is(err, errs.ErrJobNotFound), "jobs can only be cancelled from their chat")
+
+	require.NoError(t, svc.Cancel(ctx, chat, job.ID))
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, svc.Shutdown(shutdownCtx))
+
+	assert.Contains(t, r.last(), "cancelled")
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestJobService_Failed(t *testing.T) {
+	chat := dto.WhatsappJID{User: "123", Server: "g.us"}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "known error", err: fmt.Errorf("start: %w", errs.ErrServerNotFound), want: "Server not found"},
+		{name: "timeout", err: context.DeadlineExceeded, want: "That took too long"},
+		{name: "unknown error, the reference of the logs", err: errors.New("dial tcp: connection refused"), want: "(ref: 3fa9c1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewJobService(&svcTmpl{})
+			r := new(reports)
+			done := make(chan error, 1)
+			ctx := config.WithRequestID(i18n.WithLang(context.Background(), i18n.EN), "3fa9c1")
+			ctx = WithJobOrigin(ctx, &JobOrigin{Chat: chat, Report: r.report, Done: func(err error) { done <- err }})
+
+			svc.Run(ctx, "test", func(ctx context.Context, job *dto.Job, report JobReporter) (string, error) {
+				return "", tt.err
+			})
+			assert.ErrorIs(t, <-done, tt.err)
+
+			assert.Contains(t, r.last(), "failed")
+			assert.Contains(t, r.last(), tt.want)
+			assert.NotContains(t, r.last(), tt.err.Error(), "the raw error is only logged")
+		})
+	}
+}
+
+func TestJobService_Device(t *testing.T) {
+	svc := NewJobService(&svcTmpl{})
+	chat := dto.WhatsappJID{User: "123", Server: "g.us"}
+	ctx := dto.WithWhatsappDevice(WithJobOrigin(context.Background(), &JobOrigin{Chat: chat}), 2)
+
+	job := svc.Run(ctx, "test", func(ctx context.Context, job *dto.Job, report JobReporter) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	assert.Equal(t, uint(2), job.Device)
+
+	// the same chat of another device
+	other := dto.WithWhatsappDevice(context.Background(), 3)
+	assert.Empty(t, svc.List(other, chat))
+	assert.ErrorIs(t, svc.Cancel(other, chat, job.ID), errs.ErrJobNotFound)
+	require.NoError(t, svc.Shutdown(other), "no job of the device")
+	assert.Len(t, svc.List(ctx, chat), 1)
+
+	shutdownCtx, cancel := context.WithTimeout(dto.WithWhatsappDevice(context.Background(), 2), time.Second)
+	defer cancel()
+	require.NoError(t, svc.Shutdown(shutdownCtx))
+	assert.Empty(t, svc.List(ctx, chat))
+}
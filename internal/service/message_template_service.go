@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
+	"exaroton-wa-bot/internal/render"
+	"exaroton-wa-bot/internal/repository"
+	"log/slog"
+	"sync"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type IMessageTemplateService interface {
+	// Render renders a message template (see render.TemplateDefs) with its
+	// override from the web UI if any, in the language of ctx (see i18n.WithLang).
+	Render(ctx context.Context, name string, data any) (string, error)
+
+	GetAll(ctx context.Context) ([]*dto.MessageTemplate, error)
+	Update(ctx context.Context, req *dto.UpdateMessageTemplateReq) error
+	Reset(ctx context.Context, req *dto.ResetMessageTemplateReq) error
+}
+
+type MessageTemplateService struct {
+	*svcTmpl
+	tmplRepo  repository.IMessageTemplateRepo
+	templates *render.Templates
+
+	loadMu sync.Mutex
+	loaded bool // the overrides are loaded from the db
+}
+
+func NewMessageTemplateService(svcTmpl *svcTmpl, tmplRepo repository.IMessageTemplateRepo) IMessageTemplateService {
+	return &MessageTemplateService{
+		svcTmpl:   svcTmpl,
+		tmplRepo:  tmplRepo,
+		templates: render.NewTemplates(),
+	}
+}
+
+func (s *MessageTemplateService) Render(ctx context.Context, name string, data any) (string, error) {
+	// the defaults still work without the overrides
+	if err := s.load(ctx); err != nil {
+		slog.WarnContext(ctx, "failed to load message template overrides", "error", err.Error())
+	}
+
+	return s.templates.Render(i18n.FromContext(ctx), name, data)
+}
+
+func (s *MessageTemplateService) GetAll(ctx context.Context) ([]*dto.MessageTemplate, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	rows, err := s.tmplRepo.GetAll(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]string, len(rows))
+	for _, row := range rows {
+		overrides[row.Name] = row.Body
+	}
+
+	defs := render.TemplateDefs()
+	res := make([]*dto.MessageTemplate, 0, len(defs))
+	for _, def := range defs {
+		res = append(res, &dto.MessageTemplate{
+			Name:     def.Name,
+			Help:     def.Help,
+			Default:  s.templates.Default(def.Name),
+			Override: overrides[def.Name],
+		})
+	}
+
+	return res, nil
+}
+
+// Update overrides a template, a template that doesn't parse or render its
+// sample data is a validation error of the body.
+func (s *MessageTemplateService) Update(ctx context.Context, req *dto.UpdateMessageTemplateReq) error {
+	if err := render.CheckTemplate(req.Name, req.Body); err != nil {
+		if errors.Is(err, errs.ErrTemplateNotFound) {
+			return validation.Errors{"name": err}
+		}
+		return validation.Errors{"body": err}
+	}
+
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	if err := s.tmplRepo.Upsert(ctx, tx, req.ToEntity()); err != nil {
+		return err
+	}
+
+	if err := s.tx.Commit(tx); err != nil {
+		return err
+	}
+
+	return s.templates.Override(req.Name, req.Body)
+}
+
+func (s *MessageTemplateService) Reset(ctx context.Context, req *dto.ResetMessageTemplateReq) error {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	if err := s.tmplRepo.Delete(ctx, tx, req.Name); err != nil {
+		return err
+	}
+
+	if err := s.tx.Commit(tx); err != nil {
+		return err
+	}
+
+	return s.templates.Override(req.Name, "")
+}
+
+// load applies the overrides from the db once, it's retried on the next call if it failed.
+func (s *MessageTemplateService) load(ctx context.Context) error {
+	s.loadMu.Lock()
+	defer s.loadMu.Unlock()
+
+	if s.loaded {
+		return nil
+	}
+
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	rows, err := s.tmplRepo.GetAll(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		// e.g. a template removed since, or no longer valid with its data
+		if err := s.templates.Override(row.Name, row.Body); err != nil {
+			slog.WarnContext(ctx, "ignoring invalid message template override", "template", row.Name, "error", err.Error())
+		}
+	}
+	s.loaded = true
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	mockRepo "exaroton-wa-bot/internal/mocks/repository"
+	"exaroton-wa-bot/internal/render"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type messageTemplateServiceMocks struct {
+	sqlTx    *mockRepo.MockSqlTx
+	tmplRepo *mockRepo.MockIMessageTemplateRepo
+}
+
+func setupTestMessageTemplateService(t *testing.T) (IMessageTemplateService, *messageTemplateServiceMocks) {
+	m := &messageTemplateServiceMocks{
+		sqlTx:    mockRepo.NewMockSqlTx(t),
+		tmplRepo: mockRepo.NewMockIMessageTemplateRepo(t),
+	}
+
+	m.sqlTx.EXPECT().Begin(mock.Anything).Return(new(gorm.DB)).Maybe()
+	m.sqlTx.EXPECT().Rollback(mock.Anything).Return(nil).Maybe()
+
+	svcTmpl := &svcTmpl{
+		cfg: &config.Cfg{Koanf: koanf.New(".")},
+		tx:  m.sqlTx,
+	}
+
+	return NewMessageTemplateService(svcTmpl, m.tmplRepo), m
+}
+
+func TestMessageTemplateService_Render(t *testing.T) {
+	data := dto.ServerIdxTmplData{Idx: 1}
+
+	t.Run("overrides are loaded once, invalid ones are skipped", func(t *testing.T) {
+		svc, m := setupTestMessageTemplateService(t)
+
+		m.tmplRepo.EXPECT().GetAll(mock.Anything, mock.Anything).Return([]*entity.MessageTemplate{
+			{Name: render.TmplServerStopping, Body: "Stopping {{.Idx}}"},
+			{Name: render.TmplServerRestarting, Body: "{{.Nope}}"},
+		}, nil).Once()
+
+		text, err := svc.Render(context.Background(), render.TmplServerStopping, data)
+		require.NoError(t, err)
+		assert.Equal(t, "Stopping 1", text)
+
+		text, err = svc.Render(context.Background(), render.TmplServerRestarting, data)
+		require.NoError(t, err)
+		assert.Equal(t, "Server 1 is restarting...", text)
+	})
+
+	t.Run("defaults are used when loading fails", func(t *testing.T) {
+		svc, m := setupTestMessageTemplateService(t)
+
+		m.tmplRepo.EXPECT().GetAll(mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+		text, err := svc.Render(context.Background(), render.TmplServerStopping, data)
+		require.NoError(t, err)
+		assert.Equal(t, "Server 1 is stopping :)", text)
+	})
+}
+
+func TestMessageTemplateService_Update(t *testing.T) {
+	t.Run("saves and applies the override", func(t *testing.T) {
+		svc, m := setupTestMessageTemplateService(t)
+
+		req := &dto.UpdateMessageTemplateReq{Name: render.TmplServerStopping, Body: "Bye {{.Idx}}"}
+		m.tmplRepo.EXPECT().Upsert(mock.Anything, mock.Anything, req.ToEntity()).Return(nil)
+		m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+		m.tmplRepo.EXPECT().GetAll(mock.Anything, mock.Anything).Return(nil, nil)
+
+		require.NoError(t, svc.Update(context.Background(), req))
+
+		text, err := svc.Render(context.Background(), render.TmplServerStopping, dto.ServerIdxTmplData{Idx: 3})
+		require.NoError(t, err)
+		assert.Equal(t, "Bye 3", text)
+	})
+
+	t.Run("invalid templates aren't saved", func(t *testing.T) {
+		svc, _ := setupTestMessageTemplateService(t)
+
+		var verr validation.Errors
+
+		err := svc.Update(context.Background(), &dto.UpdateMessageTemplateReq{Name: render.TmplServerStopping, Body: "{{.Name}}"})
+		require.ErrorAs(t, err, &verr)
+		assert.Contains(t, verr, "body")
+
+		err = svc.Update(context.Background(), &dto.UpdateMessageTemplateReq{Name: "nope", Body: "hi"})
+		require.ErrorAs(t, err, &verr)
+		assert.Contains(t, verr, "name")
+	})
+}
+
+func TestMessageTemplateService_Reset(t *testing.T) {
+	svc, m := setupTestMessageTemplateService(t)
+
+	m.tmplRepo.EXPECT().GetAll(mock.Anything, mock.Anything).Return([]*entity.MessageTemplate{
+		{Name: render.TmplServerStopping, Body: "Bye"},
+	}, nil)
+	m.tmplRepo.EXPECT().Delete(mock.Anything, mock.Anything, render.TmplServerStopping).Return(nil)
+	m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+
+	text, err := svc.Render(context.Background(), render.TmplServerStopping, dto.ServerIdxTmplData{Idx: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Bye", text)
+
+	require.NoError(t, svc.Reset(context.Background(), &dto.ResetMessageTemplateReq{Name: render.TmplServerStopping}))
+
+	text, err = svc.Render(context.Background(), render.TmplServerStopping, dto.ServerIdxTmplData{Idx: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Server 1 is stopping :)", text)
+}
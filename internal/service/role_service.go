@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/repository"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// Caller is the user who sent a command, passed to commands thru the context.
+type Caller struct {
+	Chat    dto.WhatsappJID // where the command was sent
+	User    dto.WhatsappJID
+	UserAlt dto.WhatsappJID // the other identity of the user (PN or LID), empty if unknown
+	Role    dto.WhatsappRole
+}
+
+type callerKey struct{}
+
+// WithCaller returns a copy of ctx carrying the caller of a command.
+func WithCaller(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the caller carried by ctx, never nil.
+func CallerFromContext(ctx context.Context) *Caller {
+	if caller, ok := ctx.Value(callerKey{}).(*Caller); ok && caller != nil {
+		return caller
+	}
+
+	return &Caller{}
+}
+
+type IRoleService interface {
+	// GetRole resolves the role of a user in a chat. The user can be given by
+	// both its phone number and LID, the highest assigned role wins. A role
+	// assigned in the group takes precedence over one assigned for every chat,
+	// unassigned users get the configured default role. The group admins are
+	// only looked up if the stored role is lower than atLeast, pass dto.RoleAdmin
+	// to always resolve the user's highest role.
+	GetRole(ctx context.Context, chat dto.WhatsappJID, atLeast dto.WhatsappRole, users ...dto.WhatsappJID) (dto.WhatsappRole, error)
+
+	// ForgetGroupAdmins drops the cached admins of a group, e.g. when they changed.
+	ForgetGroupAdmins(ctx context.Context, group dto.WhatsappJID)
+
+	GetAll(ctx context.Context) ([]*dto.WhatsappUserRole, error)
+	Assign(ctx context.Context, req *dto.AssignWhatsappRoleReq) error
+	Unassign(ctx context.Context, req *dto.UnassignWhatsappRoleReq) error
+}
+
+// groupAdminsTTL is how long the admins of a group are cached, the group
+// events also clear them, see ForgetGroupAdmins.
+const groupAdminsTTL = time.Minute
+
+type cachedGroupAdmins struct {
+	admins    []types.GroupParticipant
+	expiresAt time.Time
+}
+
+type RoleService struct {
+	*svcTmpl
+	roleRepo          repository.IWhatsappUserRoleRepo
+	waRepo            repository.IWhatsappRepo
+	groupSettingsRepo repository.IWhatsappGroupSettingsRepo
+
+	adminsMu sync.Mutex
+	admins   map[string]*cachedGroupAdmins // key: device + group
+}
+
+func NewRoleService(
+	svcTmpl *svcTmpl,
+	roleRepo repository.IWhatsappUserRoleRepo,
+	waRepo repository.IWhatsappRepo,
+	groupSettingsRepo repository.IWhatsappGroupSettingsRepo,
+) IRoleService {
+	return &RoleService{
+		svcTmpl:           svcTmpl,
+		roleRepo:          roleRepo,
+		waRepo:            waRepo,
+		groupSettingsRepo: groupSettingsRepo,
+		admins:            make(map[string]*cachedGroupAdmins),
+	}
+}
+
+func (s *RoleService) GetRole(ctx context.Context, chat dto.WhatsappJID, atLeast dto.WhatsappRole, users ...dto.WhatsappJID) (dto.WhatsappRole, error) {
+	users = slices.DeleteFunc(slices.Clone(users), func(u dto.WhatsappJID) bool { return u.User == "" })
+
+	group := dto.WhatsappJID{User: chat.User, Server: chat.Server}
+	if chat.Server != types.GroupServer {
+		group = dto.WhatsappJID{} // only the roles for every chat apply to DMs
+	}
+
+	role, checkAdmin, err := s.storedRole(ctx, group, users)
+	if err != nil || !checkAdmin || role >= atLeast {
+		return role, err
+	}
+
+	// the group info is fetched from WhatsApp, outside of the transaction
+	isAdmin, err := s.isGroupAdmin(ctx, group, users)
+	if err != nil {
+		return dto.RoleGuest, err
+	}
+
+	if isAdmin {
+		return dto.RoleOperator, nil
+	}
+
+	return role, nil
+}
+
+// storedRole resolves the role of the users from the assigned roles and the
+// default one, checkAdmin is true if the group admins are operators and the
+// role is lower.
+func (s *RoleService) storedRole(ctx context.Context, group dto.WhatsappJID, users []dto.WhatsappJID) (role dto.WhatsappRole, checkAdmin bool, err error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	rows, err := s.roleRepo.GetByUsers(ctx, tx, group, users)
+	if err != nil {
+		return dto.RoleGuest, false, err
+	}
+
+	if role, ok := assignedRole(rows); ok {
+		return role, false, nil
+	}
+
+	role = s.defaultRole()
+	if group.User == "" || role >= dto.RoleOperator {
+		return role, false, nil
+	}
+
+	settingRows, err := s.groupSettingsRepo.GetAll(ctx, tx, group)
+	if err != nil {
+		return dto.RoleGuest, false, err
+	}
+
+	return role, dto.NewWhatsappGroupSettings(settingRows).AdminsAreOperators, nil
+}
+
+// assignedRole picks the highest role of the group, or of every chat if
+// none is assigned in the group.
+func assignedRole(rows []*entity.WhatsappUserRole) (dto.WhatsappRole, bool) {
+	groupRole, globalRole := dto.WhatsappRole(-1), dto.WhatsappRole(-1) // -1: none assigned
+
+	for _, row := range rows {
+		role, ok := dto.ParseWhatsappRole(row.Role)
+		if !ok {
+			continue
+		}
+
+		if row.GroupJID == "" {
+			globalRole = max(globalRole, role)
+		} else {
+			groupRole = max(groupRole, role)
+		}
+	}
+
+	switch {
+	case groupRole >= 0:
+		return groupRole, true
+	case globalRole >= 0:
+		return globalRole, true
+	}
+
+	return dto.RoleGuest, false
+}
+
+func (s *RoleService) isGroupAdmin(ctx context.Context, group dto.WhatsappJID, users []dto.WhatsappJID) (bool, error) {
+	admins, err := s.groupAdmins(ctx, group)
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range admins {
+		for _, u := range users {
+			jid := types.NewJID(u.User, u.Server)
+			if jid == p.JID.ToNonAD() || jid == p.PhoneNumber.ToNonAD() || jid == p.LID.ToNonAD() {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// groupAdmins returns the admins of a group, they're cached for groupAdminsTTL.
+func (s *RoleService) groupAdmins(ctx context.Context, group dto.WhatsappJID) ([]types.GroupParticipant, error) {
+	key := groupAdminsKey(ctx, group)
+
+	s.adminsMu.Lock()
+	cached, ok := s.admins[key]
+	s.adminsMu.Unlock()
+
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.admins, nil
+	}
+
+	info, err := s.waRepo.GetGroupInfo(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	admins := slices.DeleteFunc(slices.Clone(info.Participants), func(p types.GroupParticipant) bool {
+		return !p.IsAdmin && !p.IsSuperAdmin
+	})
+
+	s.adminsMu.Lock()
+	s.admins[key] = &cachedGroupAdmins{admins: admins, expiresAt: time.Now().Add(groupAdminsTTL)}
+	s.adminsMu.Unlock()
+
+	return admins, nil
+}
+
+func (s *RoleService) ForgetGroupAdmins(ctx context.Context, group dto.WhatsappJID) {
+	s.adminsMu.Lock()
+	defer s.adminsMu.Unlock()
+
+	delete(s.admins, groupAdminsKey(ctx, group))
+}
+
+// groupAdminsKey scopes the cached admins to the context's device, the
+// devices can see different groups.
+func groupAdminsKey(ctx context.Context, group dto.WhatsappJID) string {
+	return fmt.Sprintf("%d|%s", dto.WhatsappDeviceFromContext(ctx), group.String())
+}
+
+// defaultRole is the role of unassigned users, see config.KeyBotDefaultRole.
+func (s *RoleService) defaultRole() dto.WhatsappRole {
+	if s.cfg != nil && s.cfg.Koanf != nil {
+		if role, ok := dto.ParseWhatsappRole(s.cfg.String(config.KeyBotDefaultRole)); ok {
+			return role
+		}
+	}
+
+	return dto.RolePlayer
+}
+
+func (s *RoleService) GetAll(ctx context.Context) ([]*dto.WhatsappUserRole, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	rows, err := s.roleRepo.GetAll(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*dto.WhatsappUserRole, len(rows))
+	for i, row := range rows {
+		res[i] = dto.NewWhatsappUserRole(row)
+	}
+
+	return res, nil
+}
+
+func (s *RoleService) Assign(ctx context.Context, req *dto.AssignWhatsappRoleReq) error {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	if err := s.roleRepo.Upsert(ctx, tx, req.ToEntity()); err != nil {
+		return err
+	}
+
+	return s.tx.Commit(tx)
+}
+
+func (s *RoleService) Unassign(ctx context.Context, req *dto.UnassignWhatsappRoleReq) error {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	if err := s.roleRepo.Delete(ctx, tx, req.ToEntity()); err != nil {
+		return err
+	}
+
+	return s.tx.Commit(tx)
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	mockRepo "exaroton-wa-bot/internal/mocks/repository"
+	"testing"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mau.fi/whatsmeow/types"
+	"gorm.io/gorm"
+)
+
+type roleServiceMocks struct {
+	sqlTx         *mockRepo.MockSqlTx
+	roleRepo      *mockRepo.MockIWhatsappUserRoleRepo
+	waRepo        *mockRepo.MockIWhatsappRepo
+	groupSettings *mockRepo.MockIWhatsappGroupSettingsRepo
+}
+
+func setupTestRoleService(t *testing.T) (IRoleService, *roleServiceMocks) {
+	m := &roleServiceMocks{
+		sqlTx:         mockRepo.NewMockSqlTx(t),
+		roleRepo:      mockRepo.NewMockIWhatsappUserRoleRepo(t),
+		waRepo:        mockRepo.NewMockIWhatsappRepo(t),
+		groupSettings: mockRepo.NewMockIWhatsappGroupSettingsRepo(t),
+	}
+
+	m.sqlTx.EXPECT().Begin(mock.Anything).Return(new(gorm.DB))
+	m.sqlTx.EXPECT().Rollback(mock.Anything).Return(nil)
+
+	svcTmpl := &svcTmpl{
+		cfg: &config.Cfg{Koanf: koanf.New(".")},
+		tx:  m.sqlTx,
+	}
+
+	return NewRoleService(svcTmpl, m.roleRepo, m.waRepo, m.groupSettings), m
+}
+
+func TestRoleService_GetRole(t *testing.T) {
+	group := dto.WhatsappJID{User: "1203630", Server: "g.us"}
+	pn := dto.WhatsappJID{User: "6285", Server: "s.whatsapp.net"}
+	lid := dto.WhatsappJID{User: "77", Server: "lid"}
+
+	t.Run("group role takes precedence over every chat", func(t *testing.T) {
+		svc, m := setupTestRoleService(t)
+
+		m.roleRepo.EXPECT().GetByUsers(mock.Anything, mock.Anything, group, []dto.WhatsappJID{lid, pn}).Return([]*entity.WhatsappUserRole{
+			{JID: "6285", ServerJID: "s.whatsapp.net", Role: "admin"},
+			{GroupJID: "1203630", GroupServerJID: "g.us", JID: "77", ServerJID: "lid", Role: "guest"},
+			{GroupJID: "1203630", GroupServerJID: "g.us", JID: "6285", ServerJID: "s.whatsapp.net", Role: "operator"},
+		}, nil)
+
+		role, err := svc.GetRole(context.Background(), group, dto.RoleAdmin, lid, pn)
+		require.NoError(t, err)
+		assert.Equal(t, dto.RoleOperator, role)
+	})
+
+	t.Run("role for every chat", func(t *testing.T) {
+		svc, m := setupTestRoleService(t)
+
+		m.roleRepo.EXPECT().GetByUsers(mock.Anything, mock.Anything, group, []dto.WhatsappJID{pn}).Return([]*entity.WhatsappUserRole{
+			{JID: "6285", ServerJID: "s.whatsapp.net", Role: "admin"},
+		}, nil)
+
+		// the empty alt jid is skipped
+		role, err := svc.GetRole(context.Background(), group, dto.RoleAdmin, pn, dto.WhatsappJID{})
+		require.NoError(t, err)
+		assert.Equal(t, dto.RoleAdmin, role)
+	})
+
+	t.Run("direct messages only use roles for every chat", func(t *testing.T) {
+		svc, m := setupTestRoleService(t)
+
+		m.roleRepo.EXPECT().GetByUsers(mock.Anything, mock.Anything, dto.WhatsappJID{}, []dto.WhatsappJID{pn}).Return(nil, nil)
+
+		role, err := svc.GetRole(context.Background(), pn, dto.RoleAdmin, pn)
+		require.NoError(t, err)
+		assert.Equal(t, dto.RolePlayer, role)
+	})
+
+	t.Run("default role", func(t *testing.T) {
+		svc, m := setupTestRoleService(t)
+
+		m.roleRepo.EXPECT().GetByUsers(mock.Anything, mock.Anything, group, []dto.WhatsappJID{pn}).Return(nil, nil)
+		m.groupSettings.EXPECT().GetAll(mock.Anything, mock.Anything, group).Return(nil, nil)
+
+		role, err := svc.GetRole(context.Background(), group, dto.RoleAdmin, pn)
+		require.NoError(t, err)
+		assert.Equal(t, dto.RolePlayer, role)
+	})
+
+	t.Run("configured default role", func(t *testing.T) {
+		svc, m := setupTestRoleService(t)
+		require.NoError(t, svc.(*RoleService).cfg.Set(config.KeyBotDefaultRole, "operator"))
+
+		m.roleRepo.EXPECT().GetByUsers(mock.Anything, mock.Anything, group, []dto.WhatsappJID{pn}).Return(nil, nil)
+
+		role, err := svc.GetRole(context.Background(), group, dto.RoleAdmin, pn)
+		require.NoError(t, err)
+		assert.Equal(t, dto.RoleOperator, role)
+	})
+
+	t.Run("group admins are operators", func(t *testing.T) {
+		svc, m := setupTestRoleService(t)
+
+		m.roleRepo.EXPECT().GetByUsers(mock.Anything, mock.Anything, group, []dto.WhatsappJID{lid}).Return(nil, nil).Times(3)
+		m.groupSettings.EXPECT().GetAll(mock.Anything, mock.Anything, group).Return([]*entity.WhatsappGroupSettings{
+			{Key: constants.GroupAdminsAreOperators, Value: "true"},
+		}, nil).Times(3)
+		m.waRepo.EXPECT().GetGroupInfo(mock.Anything, group).Return(&types.GroupInfo{
+			Participants: []types.GroupParticipant{
+				{JID: types.NewJID("6285", "s.whatsapp.net"), LID: types.NewJID("77", "lid"), IsAdmin: true},
+			},
+		}, nil).Once()
+		m.waRepo.EXPECT().GetGroupInfo(mock.Anything, group).Return(&types.GroupInfo{
+			Participants: []types.GroupParticipant{
+				{JID: types.NewJID("6285", "s.whatsapp.net"), LID: types.NewJID("77", "lid")},
+			},
+		}, nil).Once()
+
+		role, err := svc.GetRole(context.Background(), group, dto.RoleAdmin, lid)
+		require.NoError(t, err)
+		assert.Equal(t, dto.RoleOperator, role)
+
+		// the admins are cached
+		role, err = svc.GetRole(context.Background(), group, dto.RoleAdmin, lid)
+		require.NoError(t, err)
+		assert.Equal(t, dto.RoleOperator, role)
+
+		// not an admin anymore, the group info event clears the cache
+		svc.ForgetGroupAdmins(context.Background(), group)
+		role, err = svc.GetRole(context.Background(), group, dto.RoleAdmin, lid)
+		require.NoError(t, err)
+		assert.Equal(t, dto.RolePlayer, role)
+	})
+
+	t.Run("group admins aren't looked up if the stored role is enough", func(t *testing.T) {
+		svc, m := setupTestRoleService(t)
+
+		m.roleRepo.EXPECT().GetByUsers(mock.Anything, mock.Anything, group, []dto.WhatsappJID{lid}).Return(nil, nil)
+		m.groupSettings.EXPECT().GetAll(mock.Anything, mock.Anything, group).Return([]*entity.WhatsappGroupSettings{
+			{Key: constants.GroupAdminsAreOperators, Value: "true"},
+		}, nil)
+
+		role, err := svc.GetRole(context.Background(), group, dto.RolePlayer, lid)
+		require.NoError(t, err)
+		assert.Equal(t, dto.RolePlayer, role)
+	})
+
+	t.Run("group info is fetched outside of the transaction", func(t *testing.T) {
+		m := &roleServiceMocks{
+			sqlTx:         mockRepo.NewMockSqlTx(t),
+			roleRepo:      mockRepo.NewMockIWhatsappUserRoleRepo(t),
+			waRepo:        mockRepo.NewMockIWhatsappRepo(t),
+			groupSettings: mockRepo.NewMockIWhatsappGroupSettingsRepo(t),
+		}
+		svc := NewRoleService(&svcTmpl{cfg: &config.Cfg{Koanf: koanf.New(".")}, tx: m.sqlTx}, m.roleRepo, m.waRepo, m.groupSettings)
+
+		inTx := false
+		m.sqlTx.EXPECT().Begin(mock.Anything).RunAndReturn(func(context.Context) *gorm.DB {
+			inTx = true
+			return new(gorm.DB)
+		})
+		m.sqlTx.EXPECT().Rollback(mock.Anything).RunAndReturn(func(*gorm.DB) error {
+			inTx = false
+			return nil
+		})
+
+		m.roleRepo.EXPECT().GetByUsers(mock.Anything, mock.Anything, group, []dto.WhatsappJID{lid}).Return(nil, nil)
+		m.groupSettings.EXPECT().GetAll(mock.Anything, mock.Anything, group).Return([]*entity.WhatsappGroupSettings{
+			{Key: constants.GroupAdminsAreOperators, Value: "true"},
+		}, nil)
+		m.waRepo.EXPECT().GetGroupInfo(mock.Anything, group).RunAndReturn(func(context.Context, dto.WhatsappJID) (*types.GroupInfo, error) {
+			assert.False(t, inTx)
+			return &types.GroupInfo{}, nil
+		})
+
+		role, err := svc.GetRole(context.Background(), group, dto.RoleAdmin, lid)
+		require.NoError(t, err)
+		assert.Equal(t, dto.RolePlayer, role)
+	})
+}
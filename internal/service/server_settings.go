@@ -10,6 +10,8 @@ import (
 	"exaroton-wa-bot/internal/repository"
 	"log/slog"
 	"time"
+
+	"golang.org/x/sync/errgroup"
 )
 
 type IServerSettingsService interface {
@@ -20,14 +22,18 @@ type IServerSettingsService interface {
 	ListExarotonServer(ctx context.Context) ([]*dto.ExarotonServerInfo, error)
 	StartExarotonServer(ctx context.Context, serverIdx uint, opts ...StartExarotonServerOption) *dto.StartExarotonServerRes
 	StopExarotonServer(ctx context.Context, serverIdx uint) error
+	RestartExarotonServer(ctx context.Context, serverIdx uint) error
 	GetExarotonServerInfo(ctx context.Context, serverIdx uint) (*dto.ExarotonServerInfo, error)
 	GetExarotonServerPlayerList(ctx context.Context, serverIdx uint) (*dto.ExarotonServerPlayers, error)
+	GetExarotonServersStatus(ctx context.Context, opts ...ServersStatusOption) ([]*dto.ExarotonServerStatus, error)
 }
 
 type ServerSettingsService struct {
 	*svcTmpl
 	serverSettingsRepo repository.IServerSettingsRepo
 	exarotonRepo       repository.IExarotonRepo
+
+	uptime *serverUptime
 }
 
 func NewServerSettingsService(svcTmpl *svcTmpl, serverSettingsRepo repository.IServerSettingsRepo, exarotonRepo repository.IExarotonRepo) IServerSettingsService {
@@ -35,6 +41,7 @@ func NewServerSettingsService(svcTmpl *svcTmpl, serverSettingsRepo repository.IS
 		svcTmpl:            svcTmpl,
 		serverSettingsRepo: serverSettingsRepo,
 		exarotonRepo:       exarotonRepo,
+		uptime:             newServerUptime(),
 	}
 }
 
@@ -193,7 +200,7 @@ func (s *ServerSettingsService) StartExarotonServer(ctx context.Context, serverI
 			ticker := time.NewTicker(cfg.interval)
 			defer ticker.Stop()
 
-			pollCtx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+			pollCtx, cancel := context.WithTimeout(ctx, cfg.timeout)
 			defer cancel()
 
 			for {
@@ -209,6 +216,8 @@ func (s *ServerSettingsService) StartExarotonServer(ctx context.Context, serverI
 						return
 					}
 
+					s.uptime.observe(srv.ID, srv.Status)
+					status = srv.Status
 					statusCh <- srv.Status
 
 					if srv.Status == dto.ServerStatusOnline ||
@@ -257,6 +266,37 @@ func (s *ServerSettingsService) StopExarotonServer(ctx context.Context, serverId
 	return s.exarotonRepo.StopServer(ctx, apiKey, servers[serverIdx].ID)
 }
 
+func (s *ServerSettingsService) RestartExarotonServer(ctx context.Context, serverIdx uint) error {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	settings, err := s.serverSettingsRepo.Get(ctx, tx, constants.ExarotonAPIKey)
+	if err != nil {
+		return err
+	}
+
+	if settings == nil {
+		return errs.ErrGSEmptyAPIKey
+	}
+
+	apiKey := settings.Value
+
+	servers, err := s.exarotonRepo.ListServers(ctx, apiKey)
+	if err != nil {
+		return err
+	}
+
+	if serverIdx >= uint(len(servers)) {
+		return errs.ErrServerNotFound
+	}
+
+	return s.exarotonRepo.RestartServer(ctx, apiKey, servers[serverIdx].ID)
+}
+
 func (s *ServerSettingsService) GetExarotonServerInfo(ctx context.Context, serverIdx uint) (*dto.ExarotonServerInfo, error) {
 	tx := s.tx.Begin(ctx)
 	defer func() {
@@ -285,7 +325,14 @@ func (s *ServerSettingsService) GetExarotonServerInfo(ctx context.Context, serve
 		return nil, errs.ErrServerNotFound
 	}
 
-	return s.exarotonRepo.GetServerInfo(ctx, apiKey, servers[serverIdx].ID)
+	server, err := s.exarotonRepo.GetServerInfo(ctx, apiKey, servers[serverIdx].ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.uptime.observe(server.ID, server.Status)
+
+	return server, nil
 }
 
 func (s *ServerSettingsService) GetExarotonServerPlayerList(ctx context.Context, serverIdx uint) (*dto.ExarotonServerPlayers, error) {
@@ -320,3 +367,99 @@ func (s *ServerSettingsService) GetExarotonServerPlayerList(ctx context.Context,
 
 	return s.exarotonRepo.GetServerPlayerList(ctx, apiKey, server.ID)
 }
+
+type (
+	serversStatusConfig struct {
+		workers     int
+		callTimeout time.Duration
+		allows      func(serverID string) bool
+	}
+
+	ServersStatusOption func(*serversStatusConfig)
+)
+
+// WithWorkers sets how many servers are fetched at the same time.
+func WithWorkers(n int) ServersStatusOption {
+	return func(c *serversStatusConfig) {
+		c.workers = helper.If(n <= 0, 1, n)
+	}
+}
+
+// WithCallTimeout sets the timeout of each server info call.
+func WithCallTimeout(timeout time.Duration) ServersStatusOption {
+	return func(c *serversStatusConfig) {
+		c.callTimeout = helper.If(timeout <= 0, 5*time.Second, timeout)
+	}
+}
+
+// WithServerFilter only fetches the servers allows returns true for, they
+// keep their index in the server list.
+func WithServerFilter(allows func(serverID string) bool) ServersStatusOption {
+	return func(c *serversStatusConfig) {
+		c.allows = allows
+	}
+}
+
+// GetExarotonServersStatus fetches the details of every server concurrently, a
+// server that fails or times out keeps its entry from the server list with Err set.
+func (s *ServerSettingsService) GetExarotonServersStatus(ctx context.Context, opts ...ServersStatusOption) ([]*dto.ExarotonServerStatus, error) {
+	// default values
+	cfg := &serversStatusConfig{
+		workers:     4,
+		callTimeout: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// the transaction is done before the calls to exaroton
+	apiKey, err := s.GetExarotonAPIKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey == "" {
+		return nil, errs.ErrGSEmptyAPIKey
+	}
+
+	servers, err := s.exarotonRepo.ListServers(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*dto.ExarotonServerStatus, 0, len(servers))
+
+	g := new(errgroup.Group)
+	g.SetLimit(cfg.workers)
+
+	for i, srv := range servers {
+		if cfg.allows != nil && !cfg.allows(srv.ID) {
+			continue
+		}
+
+		st := &dto.ExarotonServerStatus{Idx: uint(i), Server: srv}
+		res = append(res, st)
+
+		g.Go(func() error {
+			callCtx, cancel := context.WithTimeout(ctx, cfg.callTimeout)
+			defer cancel()
+
+			info, err := s.exarotonRepo.GetServerInfo(callCtx, apiKey, srv.ID)
+			if err != nil {
+				st.Err = err
+				return nil
+			}
+
+			st.Server = info
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	for _, r := range res {
+		r.OnlineSince = s.uptime.observe(r.Server.ID, r.Server.Status)
+	}
+
+	return res, nil
+}
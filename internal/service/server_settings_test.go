@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	mockRepo "exaroton-wa-bot/internal/mocks/repository"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupTestServerSettingsService(t *testing.T) (
+	IServerSettingsService,
+	*mockRepo.MockSqlTx,
+	*mockRepo.MockIServerSettingsRepo,
+	*mockRepo.MockIExarotonRepo,
+) {
+	mockSqlTx := mockRepo.NewMockSqlTx(t)
+	mockServerSettingsRepo := mockRepo.NewMockIServerSettingsRepo(t)
+	mockExarotonRepo := mockRepo.NewMockIExarotonRepo(t)
+
+	svcTmpl := &svcTmpl{
+		cfg: &config.Cfg{Koanf: koanf.New(".")},
+		tx:  mockSqlTx,
+	}
+
+	svc := NewServerSettingsService(svcTmpl, mockServerSettingsRepo, mockExarotonRepo)
+
+	return svc, mockSqlTx, mockServerSettingsRepo, mockExarotonRepo
+}
+
+func TestServerSettingsService_GetExarotonServersStatus(t *testing.T) {
+	svc, mockSqlTx, mockServerSettingsRepo, mockExarotonRepo := setupTestServerSettingsService(t)
+
+	// transaction, it's done before the calls to exaroton
+	inTx := false
+	mockSqlTx.EXPECT().Begin(mock.Anything).RunAndReturn(func(context.Context) *gorm.DB {
+		inTx = true
+		return new(gorm.DB)
+	})
+	mockSqlTx.EXPECT().Rollback(mock.Anything).RunAndReturn(func(*gorm.DB) error {
+		inTx = false
+		return nil
+	})
+
+	mockServerSettingsRepo.EXPECT().
+		Get(mock.Anything, mock.Anything, constants.ExarotonAPIKey).
+		Return(&entity.ServerSettings{Key: constants.ExarotonAPIKey, Value: "key"}, nil)
+
+	mockExarotonRepo.EXPECT().
+		ListServers(mock.Anything, "key").
+		RunAndReturn(func(context.Context, string) ([]*dto.ExarotonServerInfo, error) {
+			assert.False(t, inTx)
+			return []*dto.ExarotonServerInfo{
+				{ID: "fast", Name: "Fast", Status: dto.ServerStatusOffline},
+				{ID: "slow", Name: "Slow", Status: dto.ServerStatusOffline},
+			}, nil
+		})
+
+	mockExarotonRepo.EXPECT().
+		GetServerInfo(mock.Anything, "key", "fast").
+		Return(&dto.ExarotonServerInfo{ID: "fast", Name: "Fast", Status: dto.ServerStatusOnline}, nil)
+
+	// never answers, should be cut by the per-call timeout
+	mockExarotonRepo.EXPECT().
+		GetServerInfo(mock.Anything, "key", "slow").
+		RunAndReturn(func(ctx context.Context, _ string, _ string) (*dto.ExarotonServerInfo, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+
+	start := time.Now()
+	res, err := svc.GetExarotonServersStatus(context.Background(),
+		WithWorkers(2),
+		WithCallTimeout(50*time.Millisecond),
+	)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+
+	require.Len(t, res, 2)
+
+	assert.Equal(t, uint(0), res[0].Idx)
+	assert.NoError(t, res[0].Err)
+	assert.Equal(t, dto.ServerStatusOnline, res[0].Server.Status)
+
+	// falls back to the list entry
+	assert.Equal(t, uint(1), res[1].Idx)
+	assert.ErrorIs(t, res[1].Err, context.DeadlineExceeded)
+	assert.Equal(t, "Slow", res[1].Server.Name)
+}
+
+func TestServerSettingsService_GetExarotonServersStatus_Filter(t *testing.T) {
+	svc, mockSqlTx, mockServerSettingsRepo, mockExarotonRepo := setupTestServerSettingsService(t)
+
+	mockSqlTx.EXPECT().Begin(mock.Anything).Return(new(gorm.DB))
+	mockSqlTx.EXPECT().Rollback(mock.Anything).Return(nil)
+
+	mockServerSettingsRepo.EXPECT().
+		Get(mock.Anything, mock.Anything, constants.ExarotonAPIKey).
+		Return(&entity.ServerSettings{Key: constants.ExarotonAPIKey, Value: "key"}, nil)
+
+	mockExarotonRepo.EXPECT().
+		ListServers(mock.Anything, "key").
+		Return([]*dto.ExarotonServerInfo{
+			{ID: "a", Name: "A", Status: dto.ServerStatusOffline},
+			{ID: "b", Name: "B", Status: dto.ServerStatusOffline},
+		}, nil)
+
+	// only the allowed server is fetched
+	mockExarotonRepo.EXPECT().
+		GetServerInfo(mock.Anything, "key", "b").
+		Return(&dto.ExarotonServerInfo{ID: "b", Name: "B", Status: dto.ServerStatusOnline}, nil)
+
+	res, err := svc.GetExarotonServersStatus(context.Background(), WithServerFilter(func(serverID string) bool {
+		return serverID == "b"
+	}))
+	require.NoError(t, err)
+
+	require.Len(t, res, 1)
+	assert.Equal(t, uint(1), res[0].Idx)
+	assert.Equal(t, dto.ServerStatusOnline, res[0].Server.Status)
+}
+
+func TestServerUptime_Observe(t *testing.T) {
+	u := newServerUptime()
+
+	// already online the first time it is seen, uptime is unknown
+	assert.Nil(t, u.observe("a", dto.ServerStatusOnline))
+	assert.Nil(t, u.observe("a", dto.ServerStatusOnline))
+
+	// offline -> online transition is tracked
+	assert.Nil(t, u.observe("b", dto.ServerStatusStarting))
+	since := u.observe("b", dto.ServerStatusOnline)
+	require.NotNil(t, since)
+	assert.Equal(t, since, u.observe("b", dto.ServerStatusOnline))
+
+	// going offline resets it
+	assert.Nil(t, u.observe("b", dto.ServerStatusStopping))
+	assert.Nil(t, u.observe("a", dto.ServerStatusOffline))
+}
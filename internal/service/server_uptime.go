@@ -0,0 +1,53 @@
+package service
+
+import (
+	"exaroton-wa-bot/internal/dto"
+	"sync"
+	"time"
+)
+
+// serverUptime keeps track of when servers went online.
+//
+// exaroton doesn't expose the uptime of a server, so it is derived from the
+// statuses the bot has seen. A server that was already online the first time
+// it is seen has an unknown uptime.
+type serverUptime struct {
+	mu      sync.Mutex
+	servers map[string]*serverUptimeEntry // key: server id
+}
+
+type serverUptimeEntry struct {
+	status      dto.ServerStatus
+	onlineSince *time.Time
+}
+
+func newServerUptime() *serverUptime {
+	return &serverUptime{
+		servers: make(map[string]*serverUptimeEntry),
+	}
+}
+
+// observe records the current status of a server and returns since when
+// the server has been online, nil if it is not online or if it is unknown.
+func (u *serverUptime) observe(serverID string, status dto.ServerStatus) *time.Time {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	entry, seen := u.servers[serverID]
+	if !seen {
+		u.servers[serverID] = &serverUptimeEntry{status: status}
+		return nil
+	}
+
+	if status != dto.ServerStatusOnline {
+		entry.status, entry.onlineSince = status, nil
+		return nil
+	}
+
+	if entry.status != dto.ServerStatusOnline {
+		now := time.Now()
+		entry.status, entry.onlineSince = status, &now
+	}
+
+	return entry.onlineSince
+}
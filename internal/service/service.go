@@ -8,9 +8,17 @@ import (
 )
 
 type Service struct {
-	AuthService           IAuthService
-	ServerSettingsService IServerSettingsService
-	WhatsappService       IWhatsappService
+	AuthService            IAuthService
+	ServerSettingsService  IServerSettingsService
+	WhatsappService        IWhatsappService
+	WhatsappDeviceService  IWhatsappDeviceService
+	StartVoteService       IStartVoteService
+	JobService             IJobService
+	RoleService            IRoleService
+	MessageTemplateService IMessageTemplateService
+
+	WhatsappConnectionService   IWhatsappConnectionService
+	WhatsappRegistrationService IWhatsappRegistrationService
 }
 
 func New(cfg *config.Cfg, db *gorm.DB, repo *repository.Repo) *Service {
@@ -18,9 +26,19 @@ func New(cfg *config.Cfg, db *gorm.DB, repo *repository.Repo) *Service {
 
 	// register services here...
 	return &Service{
-		AuthService:           NewAuthService(svcTmpl, repo.WhatsappRepo, repo.UserRepo),
-		ServerSettingsService: NewServerSettingsService(svcTmpl, repo.ServerSettingsRepo, repo.ExarotonRepo),
-		WhatsappService:       NewWhatsappService(svcTmpl, repo.WhatsappRepo),
+		AuthService:            NewAuthService(svcTmpl, repo.WhatsappRepo, repo.UserRepo),
+		ServerSettingsService:  NewServerSettingsService(svcTmpl, repo.ServerSettingsRepo, repo.ExarotonRepo),
+		WhatsappService:        NewWhatsappService(svcTmpl, repo.WhatsappRepo, repo.WhatsappGroupSettingsRepo),
+		WhatsappDeviceService:  NewWhatsappDeviceService(svcTmpl, repo.WhatsappRepo, repo.WhatsappDeviceRepo),
+		StartVoteService:       NewStartVoteService(svcTmpl, repo.WhatsappStartVoteRepo),
+		JobService:             NewJobService(svcTmpl),
+		RoleService:            NewRoleService(svcTmpl, repo.WhatsappUserRoleRepo, repo.WhatsappRepo, repo.WhatsappGroupSettingsRepo),
+		MessageTemplateService: NewMessageTemplateService(svcTmpl, repo.MessageTemplateRepo),
+
+		WhatsappConnectionService: NewWhatsappConnectionService(svcTmpl, repo.WhatsappRepo, repo.WhatsappDeviceRepo,
+			repo.WhatsappConnectionLogRepo, repo.AlertRepo),
+		WhatsappRegistrationService: NewWhatsappRegistrationService(svcTmpl, repo.WhatsappRegistrationRepo, repo.WhatsappRepo,
+			repo.WhatsappGroupSettingsRepo),
 	}
 }
 
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/repository"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type IStartVoteService interface {
+	// Vote opens a vote to start a server in a chat, or joins the open one.
+	Vote(ctx context.Context, req *dto.StartVoteReq) (*dto.StartVoteRes, error)
+
+	// VoteByMessageID joins the open vote announced by the bot message with the given id,
+	// returns nil if the message doesn't belong to an open vote.
+	VoteByMessageID(ctx context.Context, chat dto.WhatsappJID, messageID string, voter string) (*dto.StartVoteRes, error)
+
+	// IsOpenVoteMessage returns true if the bot message with the given id announces an open vote.
+	IsOpenVoteMessage(ctx context.Context, chat dto.WhatsappJID, messageID string) (bool, error)
+
+	SetVoteMessageID(ctx context.Context, voteID uint, messageID string) error
+
+	// CloseExpiredVotes marks open votes past their deadline as expired and returns them.
+	CloseExpiredVotes(ctx context.Context) ([]*dto.WhatsappStartVote, error)
+}
+
+type StartVoteService struct {
+	*svcTmpl
+	startVoteRepo repository.IWhatsappStartVoteRepo
+}
+
+func NewStartVoteService(svcTmpl *svcTmpl, startVoteRepo repository.IWhatsappStartVoteRepo) IStartVoteService {
+	return &StartVoteService{
+		svcTmpl:       svcTmpl,
+		startVoteRepo: startVoteRepo,
+	}
+}
+
+func (s *StartVoteService) Vote(ctx context.Context, req *dto.StartVoteReq) (*dto.StartVoteRes, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	vote, err := s.startVoteRepo.GetOpen(ctx, tx, req.Chat, req.ServerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the vote watcher might not have closed it yet
+	if vote != nil && !vote.Deadline.After(now) {
+		if err := s.startVoteRepo.UpdateStatus(ctx, tx, vote.ID, entity.StartVoteStatusExpired); err != nil {
+			return nil, err
+		}
+		vote = nil
+	}
+
+	opened := false
+	if vote == nil {
+		vote = &entity.WhatsappStartVote{
+			JID:        req.Chat.User,
+			ServerJID:  req.Chat.Server,
+			ServerID:   req.ServerID,
+			ServerName: req.ServerName,
+			Threshold:  req.Threshold,
+			Status:     entity.StartVoteStatusOpen,
+			Deadline:   now.Add(req.Deadline),
+			CreatedAt:  now,
+		}
+
+		if err := s.startVoteRepo.Create(ctx, tx, vote); err != nil {
+			return nil, err
+		}
+		opened = true
+	}
+
+	res, err := s.addVoter(ctx, tx, vote, req.Voter)
+	if err != nil {
+		return nil, err
+	}
+	res.Opened = opened
+
+	if err := s.tx.Commit(tx); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *StartVoteService) VoteByMessageID(ctx context.Context, chat dto.WhatsappJID, messageID string, voter string) (*dto.StartVoteRes, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	vote, err := s.startVoteRepo.GetOpenByMessageID(ctx, tx, chat, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if vote == nil || !vote.Deadline.After(time.Now().UTC()) {
+		return nil, nil
+	}
+
+	res, err := s.addVoter(ctx, tx, vote, voter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tx.Commit(tx); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *StartVoteService) IsOpenVoteMessage(ctx context.Context, chat dto.WhatsappJID, messageID string) (bool, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	vote, err := s.startVoteRepo.GetOpenByMessageID(ctx, tx, chat, messageID)
+	if err != nil {
+		return false, err
+	}
+
+	return vote != nil && vote.Deadline.After(time.Now().UTC()), nil
+}
+
+// addVoter adds a voter to an open vote and marks the vote as passed once
+// the threshold is reached.
+func (s *StartVoteService) addVoter(ctx context.Context, tx *gorm.DB, vote *entity.WhatsappStartVote, voter string) (*dto.StartVoteRes, error) {
+	joined, err := s.startVoteRepo.AddVoter(ctx, tx, vote.ID, voter)
+	if err != nil {
+		return nil, err
+	}
+
+	if joined {
+		vote.Voters = append(vote.Voters, entity.WhatsappStartVoteVoter{VoteID: vote.ID, Voter: voter})
+	}
+
+	passed := joined && len(vote.Voters) >= vote.Threshold
+	if passed {
+		if err := s.startVoteRepo.UpdateStatus(ctx, tx, vote.ID, entity.StartVoteStatusPassed); err != nil {
+			return nil, err
+		}
+		vote.Status = entity.StartVoteStatusPassed
+	}
+
+	return &dto.StartVoteRes{
+		Vote:   dto.NewWhatsappStartVote(vote),
+		Joined: joined,
+		Passed: passed,
+	}, nil
+}
+
+func (s *StartVoteService) SetVoteMessageID(ctx context.Context, voteID uint, messageID string) error {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	if err := s.startVoteRepo.UpdateMessageID(ctx, tx, voteID, messageID); err != nil {
+		return err
+	}
+
+	return s.tx.Commit(tx)
+}
+
+func (s *StartVoteService) CloseExpiredVotes(ctx context.Context) ([]*dto.WhatsappStartVote, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	votes, err := s.startVoteRepo.ListOpenBefore(ctx, tx, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*dto.WhatsappStartVote, len(votes))
+	for i, vote := range votes {
+		if err := s.startVoteRepo.UpdateStatus(ctx, tx, vote.ID, entity.StartVoteStatusExpired); err != nil {
+			return nil, err
+		}
+
+		vote.Status = entity.StartVoteStatusExpired
+		res[i] = dto.NewWhatsappStartVote(vote)
+	}
+
+	if err := s.tx.Commit(tx); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	mockRepo "exaroton-wa-bot/internal/mocks/repository"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func setupTestStartVoteService(t *testing.T) (
+	IStartVoteService,
+	*mockRepo.MockSqlTx,
+	*mockRepo.MockIWhatsappStartVoteRepo,
+) {
+	mockSqlTx := mockRepo.NewMockSqlTx(t)
+	mockStartVoteRepo := mockRepo.NewMockIWhatsappStartVoteRepo(t)
+
+	svcTmpl := &svcTmpl{
+		cfg: &config.Cfg{Koanf: koanf.New(".")},
+		tx:  mockSqlTx,
+	}
+
+	return NewStartVoteService(svcTmpl, mockStartVoteRepo), mockSqlTx, mockStartVoteRepo
+}
+
+func TestStartVoteService_Vote(t *testing.T) {
+	chat := dto.WhatsappJID{User: "123", Server: "g.us"}
+
+	t.Run("opens a new vote", func(t *testing.T) {
+		svc, mockSqlTx, mockStartVoteRepo := setupTestStartVoteService(t)
+
+		mockSqlTx.EXPECT().Begin(mock.Anything).Return(new(gorm.DB))
+		mockSqlTx.EXPECT().Rollback(mock.Anything).Return(nil)
+		mockSqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+
+		mockStartVoteRepo.EXPECT().GetOpen(mock.Anything, mock.Anything, chat, "srv1").Return(nil, nil)
+		mockStartVoteRepo.EXPECT().
+			Create(mock.Anything, mock.Anything, mock.AnythingOfType("*entity.WhatsappStartVote")).
+			Run(func(_ context.Context, _ *gorm.DB, vote *entity.WhatsappStartVote) { vote.ID = 7 }).
+			Return(nil)
+		mockStartVoteRepo.EXPECT().AddVoter(mock.Anything, mock.Anything, uint(7), "a@s.whatsapp.net").Return(true, nil)
+
+		res, err := svc.Vote(context.Background(), &dto.StartVoteReq{
+			Chat:       chat,
+			ServerID:   "srv1",
+			Voter:      "a@s.whatsapp.net",
+			ServerName: "Survival",
+			Threshold:  3,
+			Deadline:   time.Minute,
+		})
+		require.NoError(t, err)
+
+		assert.True(t, res.Opened)
+		assert.True(t, res.Joined)
+		assert.False(t, res.Passed)
+		assert.Equal(t, 1, res.Vote.Votes)
+		assert.Equal(t, 3, res.Vote.Threshold)
+		assert.Equal(t, "srv1", res.Vote.ServerID)
+		assert.Equal(t, "Survival", res.Vote.ServerName, "the replies show the server's name")
+	})
+
+	t.Run("passes once the threshold is reached", func(t *testing.T) {
+		svc, mockSqlTx, mockStartVoteRepo := setupTestStartVoteService(t)
+
+		mockSqlTx.EXPECT().Begin(mock.Anything).Return(new(gorm.DB))
+		mockSqlTx.EXPECT().Rollback(mock.Anything).Return(nil)
+		mockSqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+
+		mockStartVoteRepo.EXPECT().GetOpen(mock.Anything, mock.Anything, chat, "srv1").Return(&entity.WhatsappStartVote{
+			ID:        7,
+			ServerID:  "srv1",
+			Threshold: 2,
+			Status:    entity.StartVoteStatusOpen,
+			Deadline:  time.Now().Add(time.Minute),
+			Voters:    []entity.WhatsappStartVoteVoter{{VoteID: 7, Voter: "a@s.whatsapp.net"}},
+		}, nil)
+		mockStartVoteRepo.EXPECT().AddVoter(mock.Anything, mock.Anything, uint(7), "b@s.whatsapp.net").Return(true, nil)
+		mockStartVoteRepo.EXPECT().UpdateStatus(mock.Anything, mock.Anything, uint(7), entity.StartVoteStatusPassed).Return(nil)
+
+		res, err := svc.Vote(context.Background(), &dto.StartVoteReq{
+			Chat:     chat,
+			ServerID: "srv1",
+			Voter:    "b@s.whatsapp.net",
+		})
+		require.NoError(t, err)
+
+		assert.False(t, res.Opened)
+		assert.True(t, res.Passed)
+		assert.Equal(t, entity.StartVoteStatusPassed, res.Vote.Status)
+	})
+
+	t.Run("same voter is counted once", func(t *testing.T) {
+		svc, mockSqlTx, mockStartVoteRepo := setupTestStartVoteService(t)
+
+		mockSqlTx.EXPECT().Begin(mock.Anything).Return(new(gorm.DB))
+		mockSqlTx.EXPECT().Rollback(mock.Anything).Return(nil)
+		mockSqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+
+		mockStartVoteRepo.EXPECT().GetOpen(mock.Anything, mock.Anything, chat, "srv1").Return(&entity.WhatsappStartVote{
+			ID:        7,
+			Threshold: 2,
+			Status:    entity.StartVoteStatusOpen,
+			Deadline:  time.Now().Add(time.Minute),
+			Voters:    []entity.WhatsappStartVoteVoter{{VoteID: 7, Voter: "a@s.whatsapp.net"}},
+		}, nil)
+		mockStartVoteRepo.EXPECT().AddVoter(mock.Anything, mock.Anything, uint(7), "a@s.whatsapp.net").Return(false, nil)
+
+		res, err := svc.Vote(context.Background(), &dto.StartVoteReq{
+			Chat:     chat,
+			ServerID: "srv1",
+			Voter:    "a@s.whatsapp.net",
+		})
+		require.NoError(t, err)
+
+		assert.False(t, res.Joined)
+		assert.False(t, res.Passed)
+		assert.Equal(t, 1, res.Vote.Votes)
+	})
+}
+
+func TestStartVoteService_IsOpenVoteMessage(t *testing.T) {
+	chat := dto.WhatsappJID{User: "123", Server: "g.us"}
+
+	svc, mockSqlTx, mockStartVoteRepo := setupTestStartVoteService(t)
+
+	mockSqlTx.EXPECT().Begin(mock.Anything).Return(new(gorm.DB))
+	mockSqlTx.EXPECT().Rollback(mock.Anything).Return(nil)
+
+	mockStartVoteRepo.EXPECT().GetOpenByMessageID(mock.Anything, mock.Anything, chat, "open").
+		Return(&entity.WhatsappStartVote{ID: 7, Deadline: time.Now().Add(time.Minute)}, nil)
+	mockStartVoteRepo.EXPECT().GetOpenByMessageID(mock.Anything, mock.Anything, chat, "expired").
+		Return(&entity.WhatsappStartVote{ID: 8, Deadline: time.Now().Add(-time.Minute)}, nil)
+	mockStartVoteRepo.EXPECT().GetOpenByMessageID(mock.Anything, mock.Anything, chat, "other").Return(nil, nil)
+
+	for messageID, want := range map[string]bool{"open": true, "expired": false, "other": false} {
+		ok, err := svc.IsOpenVoteMessage(context.Background(), chat, messageID)
+		require.NoError(t, err)
+		assert.Equal(t, want, ok, messageID)
+	}
+}
@@ -0,0 +1,425 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/helper"
+	"exaroton-wa-bot/internal/repository"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultWAReconnectMinDelay = 2 * time.Second
+	DefaultWAReconnectMaxDelay = 5 * time.Minute
+	DefaultWAHistoryRetention  = 30 * 24 * time.Hour
+
+	// alerts are sent in the background, whatsmeow waits for its event handlers
+	alertTimeout = 30 * time.Second
+
+	defaultSMTPPort = 587
+)
+
+// IWhatsappConnectionService supervises the connection of every device: it
+// keeps their state, reconnects them with a backoff when their connection
+// drops, records the states they go thru and alerts the admins (email or
+// webhook) when a session is lost.
+type IWhatsappConnectionService interface {
+	// Watch starts supervising the devices, it must be called before they
+	// are opened. ctx is the parent of the reconnects.
+	Watch(ctx context.Context)
+	// Stop cancels the scheduled reconnects, e.g. before shutting down.
+	Stop()
+
+	// Status returns the connection state of the device of ctx.
+	Status(ctx context.Context) dto.WhatsappConnStatus
+	// History returns the latest connection states of the device of ctx,
+	// the newest first.
+	History(ctx context.Context, limit int) ([]*dto.WhatsappConnLog, error)
+}
+
+type WhatsappConnectionService struct {
+	*svcTmpl
+	waRepo     repository.IWhatsappRepo
+	deviceRepo repository.IWhatsappDeviceRepo
+	logRepo    repository.IWhatsappConnectionLogRepo
+	alertRepo  repository.IAlertRepo
+
+	ctx     context.Context // of Watch
+	mu      sync.Mutex
+	devices map[uint]*deviceConn // key: device id
+	stopped bool
+}
+
+// deviceConn is the connection state of a device.
+type deviceConn struct {
+	state    dto.WhatsappConnState
+	reason   string
+	since    time.Time
+	attempts int // failed reconnects since the last connection
+
+	// every transition bumps gen, a reconnect scheduled before is stale
+	gen       uint
+	retry     *time.Timer
+	nextRetry *time.Time
+}
+
+func NewWhatsappConnectionService(svcTmpl *svcTmpl, waRepo repository.IWhatsappRepo, deviceRepo repository.IWhatsappDeviceRepo,
+	logRepo repository.IWhatsappConnectionLogRepo, alertRepo repository.IAlertRepo) IWhatsappConnectionService {
+	return &WhatsappConnectionService{
+		svcTmpl:    svcTmpl,
+		waRepo:     waRepo,
+		deviceRepo: deviceRepo,
+		logRepo:    logRepo,
+		alertRepo:  alertRepo,
+		ctx:        context.Background(),
+		devices:    make(map[uint]*deviceConn),
+	}
+}
+
+func (s *WhatsappConnectionService) Watch(ctx context.Context) {
+	s.mu.Lock()
+	s.ctx = ctx
+	s.mu.Unlock()
+
+	s.waRepo.OnConnectionEvent(s.handle)
+}
+
+func (s *WhatsappConnectionService) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopped = true
+	for _, conn := range s.devices {
+		conn.cancelRetry()
+	}
+}
+
+func (s *WhatsappConnectionService) Status(ctx context.Context) dto.WhatsappConnStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conn, ok := s.devices[dto.WhatsappDeviceFromContext(ctx)]
+	if !ok {
+		return dto.WhatsappConnStatus{
+			State: dto.WAConnStateDisconnected,
+			Label: dto.WAConnStateDisconnected.String(),
+		}
+	}
+
+	return dto.WhatsappConnStatus{
+		State:     conn.state,
+		Label:     conn.state.String(),
+		Reason:    conn.reason,
+		Since:     conn.since,
+		Attempts:  conn.attempts,
+		NextRetry: conn.nextRetry,
+	}
+}
+
+func (s *WhatsappConnectionService) History(ctx context.Context, limit int) ([]*dto.WhatsappConnLog, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	logs, err := s.logRepo.GetLatest(ctx, tx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*dto.WhatsappConnLog, len(logs))
+	for i, log := range logs {
+		res[i] = dto.NewWhatsappConnLog(log)
+	}
+
+	return res, nil
+}
+
+// nextConnState returns the state a device in state goes to on an event, and
+// whether it reconnects right away.
+func nextConnState(state dto.WhatsappConnState, evt dto.WhatsappConnEventType) (dto.WhatsappConnState, bool) {
+	switch evt {
+	case dto.WAConnEventConnected:
+		return dto.WAConnStateConnected, false
+	case dto.WAConnEventDisconnected:
+		if state.IsSessionLost() {
+			return state, false
+		}
+		return dto.WAConnStateReconnecting, true
+	case dto.WAConnEventLoggedOut:
+		return dto.WAConnStateLoggedOut, false
+	case dto.WAConnEventLogout:
+		return dto.WAConnStateDisconnected, false
+	case dto.WAConnEventStreamReplaced:
+		return dto.WAConnStateReplaced, false
+	case dto.WAConnEventTemporaryBan:
+		return dto.WAConnStateBanned, false
+	case dto.WAConnEventClientOutdated:
+		return dto.WAConnStateOutdated, false
+	case dto.WAConnEventConnectFailure:
+		return dto.WAConnStateFailed, false
+	case dto.WAConnEventKeepAliveTimeout:
+		if state == dto.WAConnStateConnected {
+			return dto.WAConnStateUnstable, false
+		}
+	case dto.WAConnEventKeepAliveRestored:
+		if state == dto.WAConnStateUnstable {
+			return dto.WAConnStateConnected, false
+		}
+	}
+
+	return state, false
+}
+
+// handle is the connection event hook, called from whatsmeow's event loop.
+func (s *WhatsappConnectionService) handle(deviceID uint, evt dto.WhatsappConnEvent) {
+	s.mu.Lock()
+	conn := s.device(deviceID)
+
+	next, reconnect := nextConnState(conn.state, evt.Type)
+	if next == conn.state {
+		// the connection of a reconnect that went thru dropped again
+		retry := next == dto.WAConnStateReconnecting && evt.Type == dto.WAConnEventDisconnected && conn.retry == nil
+		if !retry {
+			s.mu.Unlock()
+			return
+		}
+
+		reason := s.failedReconnect(deviceID, conn, helper.If(evt.Reason == "", "disconnected", evt.Reason))
+		ctx := dto.WithWhatsappDevice(context.WithoutCancel(s.ctx), deviceID)
+		s.mu.Unlock()
+
+		slog.WarnContext(ctx, "whatsapp disconnected while reconnecting", "device", deviceID, "reason", evt.Reason)
+		s.record(ctx, dto.WAConnStateReconnecting, reason)
+		return
+	}
+
+	prev := conn.state
+	conn.transition(next, evt.Reason)
+	if next == dto.WAConnStateConnected {
+		conn.attempts = 0
+	}
+
+	switch {
+	case reconnect:
+		s.scheduleReconnect(deviceID, conn, s.reconnectDelay(conn.attempts))
+	case next == dto.WAConnStateBanned && evt.Expire > 0:
+		s.scheduleReconnect(deviceID, conn, evt.Expire)
+	}
+	ctx, at := s.ctx, conn.since
+	s.mu.Unlock()
+
+	ctx = dto.WithWhatsappDevice(context.WithoutCancel(ctx), deviceID)
+	slog.InfoContext(ctx, "whatsapp connection state changed", "device", deviceID, "from", prev, "to", next, "reason", evt.Reason)
+	s.record(ctx, next, evt.Reason)
+
+	if next.IsSessionLost() && !prev.IsSessionLost() {
+		go s.alert(ctx, &dto.WhatsappConnAlert{
+			DeviceID: deviceID,
+			State:    next,
+			Reason:   evt.Reason,
+			At:       at,
+		})
+	}
+}
+
+// device returns the state of a device, a device the service hasn't seen yet
+// is disconnected. The caller holds mu.
+func (s *WhatsappConnectionService) device(deviceID uint) *deviceConn {
+	conn, ok := s.devices[deviceID]
+	if !ok {
+		conn = &deviceConn{state: dto.WAConnStateDisconnected}
+		s.devices[deviceID] = conn
+	}
+
+	return conn
+}
+
+func (c *deviceConn) transition(state dto.WhatsappConnState, reason string) {
+	c.cancelRetry()
+	c.gen++
+	c.state, c.reason, c.since = state, reason, time.Now()
+}
+
+func (c *deviceConn) cancelRetry() {
+	if c.retry != nil {
+		c.retry.Stop()
+	}
+	c.retry, c.nextRetry = nil, nil
+}
+
+// reconnectDelay is the delay before the next reconnect, doubled after each
+// failed attempt up to the max delay.
+func (s *WhatsappConnectionService) reconnectDelay(attempts int) time.Duration {
+	minDelay := s.cfgDuration(config.KeyWAReconnectMinDelay, DefaultWAReconnectMinDelay)
+	maxDelay := s.cfgDuration(config.KeyWAReconnectMaxDelay, DefaultWAReconnectMaxDelay)
+
+	delay := minDelay
+	for i := 0; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxDelay)
+}
+
+func (s *WhatsappConnectionService) cfgDuration(key string, def time.Duration) time.Duration {
+	if d := s.cfg.Duration(key); d > 0 {
+		return d
+	}
+
+	return def
+}
+
+// scheduleReconnect reconnects a device after delay, unless it changes state
+// in the meantime. The caller holds mu.
+func (s *WhatsappConnectionService) scheduleReconnect(deviceID uint, conn *deviceConn, delay time.Duration) {
+	if s.stopped {
+		return
+	}
+
+	gen := conn.gen
+	next := time.Now().Add(delay)
+	conn.nextRetry = &next
+	conn.retry = time.AfterFunc(delay, func() {
+		s.reconnect(deviceID, gen)
+	})
+}
+
+func (s *WhatsappConnectionService) reconnect(deviceID uint, gen uint) {
+	s.mu.Lock()
+	conn, ok := s.devices[deviceID]
+	if !ok || conn.gen != gen || s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	conn.retry, conn.nextRetry = nil, nil
+	ctx := dto.WithWhatsappDevice(context.WithoutCancel(s.ctx), deviceID)
+	s.mu.Unlock()
+
+	// the connected event changes the state once logged in
+	err := s.waRepo.Reconnect(ctx)
+	if err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if conn.gen != gen {
+		s.mu.Unlock()
+		return
+	}
+
+	if errors.Is(err, errs.ErrWADeviceNotFound) || errors.Is(err, errs.ErrWANotLoggedIn) {
+		// removed or unpaired in the meantime, there is nothing to reconnect
+		conn.transition(dto.WAConnStateDisconnected, "")
+		s.mu.Unlock()
+		return
+	}
+
+	reason := s.failedReconnect(deviceID, conn, err.Error())
+	s.mu.Unlock()
+
+	slog.WarnContext(ctx, "failed to reconnect whatsapp", "device", deviceID, "error", err.Error())
+	s.record(ctx, dto.WAConnStateReconnecting, reason)
+}
+
+// failedReconnect counts a failed reconnect of a device and schedules the
+// next one, it returns the reason of the failure. The caller holds mu.
+func (s *WhatsappConnectionService) failedReconnect(deviceID uint, conn *deviceConn, cause string) string {
+	conn.cancelRetry()
+	conn.attempts++
+	conn.reason = fmt.Sprintf("reconnect attempt %d failed: %s", conn.attempts, cause)
+	s.scheduleReconnect(deviceID, conn, s.reconnectDelay(conn.attempts))
+
+	return conn.reason
+}
+
+// record adds a state to the device's history and drops the expired history.
+func (s *WhatsappConnectionService) record(ctx context.Context, state dto.WhatsappConnState, reason string) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	err := s.logRepo.Create(ctx, tx, &entity.WhatsappConnectionLog{
+		State:  string(state),
+		Reason: reason,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to record the whatsapp connection state", "error", err.Error())
+		return
+	}
+
+	retention := s.cfgDuration(config.KeyWAHistoryRetention, DefaultWAHistoryRetention)
+	if err := s.logRepo.DeleteBefore(ctx, tx, time.Now().Add(-retention)); err != nil {
+		slog.ErrorContext(ctx, "failed to delete the expired whatsapp connection history", "error", err.Error())
+		return
+	}
+
+	if err := s.tx.Commit(tx); err != nil {
+		slog.ErrorContext(ctx, "failed to record the whatsapp connection state", "error", err.Error())
+	}
+}
+
+// alert sends an alert thru every configured channel.
+func (s *WhatsappConnectionService) alert(ctx context.Context, alert *dto.WhatsappConnAlert) {
+	ctx, cancel := context.WithTimeout(ctx, alertTimeout)
+	defer cancel()
+
+	alert.DeviceName = s.deviceName(ctx, alert.DeviceID)
+	slog.ErrorContext(ctx, "whatsapp session lost", "device", alert.DeviceID, "state", alert.State, "reason", alert.Reason)
+
+	if url := s.cfg.String(config.KeyAlertWebhookURL); url != "" {
+		if err := s.alertRepo.PostWebhook(ctx, url, alert); err != nil {
+			slog.ErrorContext(ctx, "failed to send the webhook alert", "error", err.Error())
+		}
+	}
+
+	if smtpCfg := s.smtpConfig(); smtpCfg.Host != "" && len(smtpCfg.To) > 0 {
+		if err := s.alertRepo.SendEmail(ctx, smtpCfg, alert.Subject(), alert.Body()); err != nil {
+			slog.ErrorContext(ctx, "failed to send the email alert", "error", err.Error())
+		}
+	}
+}
+
+func (s *WhatsappConnectionService) deviceName(ctx context.Context, deviceID uint) string {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	device, err := s.deviceRepo.Get(ctx, tx, deviceID)
+	if err != nil || device == nil {
+		return fmt.Sprintf("#%d", deviceID)
+	}
+
+	return device.Name
+}
+
+func (s *WhatsappConnectionService) smtpConfig() dto.SMTPConfig {
+	port := s.cfg.Int(config.KeyAlertSMTPPort)
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+
+	return dto.SMTPConfig{
+		Host:     s.cfg.String(config.KeyAlertSMTPHost),
+		Port:     port,
+		Username: s.cfg.String(config.KeyAlertSMTPUsername),
+		Password: s.cfg.String(config.KeyAlertSMTPPassword),
+		From:     s.cfg.String(config.KeyAlertSMTPFrom),
+		To:       s.cfg.Strings(config.KeyAlertSMTPTo),
+	}
+}
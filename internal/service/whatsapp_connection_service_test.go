@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	mockRepo "exaroton-wa-bot/internal/mocks/repository"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type connServiceMocks struct {
+	sqlTx      *mockRepo.MockSqlTx
+	waRepo     *mockRepo.MockIWhatsappRepo
+	deviceRepo *mockRepo.MockIWhatsappDeviceRepo
+	logRepo    *mockRepo.MockIWhatsappConnectionLogRepo
+	alertRepo  *mockRepo.MockIAlertRepo
+}
+
+func setupTestWhatsappConnectionService(t *testing.T, cfgValues map[string]any) (*WhatsappConnectionService, func(uint, dto.WhatsappConnEvent), *connServiceMocks) {
+	m := &connServiceMocks{
+		sqlTx:      mockRepo.NewMockSqlTx(t),
+		waRepo:     mockRepo.NewMockIWhatsappRepo(t),
+		deviceRepo: mockRepo.NewMockIWhatsappDeviceRepo(t),
+		logRepo:    mockRepo.NewMockIWhatsappConnectionLogRepo(t),
+		alertRepo:  mockRepo.NewMockIAlertRepo(t),
+	}
+
+	m.sqlTx.EXPECT().Begin(mock.Anything).Return(new(gorm.DB)).Maybe()
+	m.sqlTx.EXPECT().Rollback(mock.Anything).Return(nil).Maybe()
+	m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil).Maybe()
+	m.logRepo.EXPECT().DeleteBefore(mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	k := koanf.New(".")
+	for key, value := range cfgValues {
+		require.NoError(t, k.Set(key, value))
+	}
+
+	svcTmpl := &svcTmpl{
+		cfg: &config.Cfg{Koanf: k},
+		tx:  m.sqlTx,
+	}
+
+	svc := NewWhatsappConnectionService(svcTmpl, m.waRepo, m.deviceRepo, m.logRepo, m.alertRepo).(*WhatsappConnectionService)
+	t.Cleanup(svc.Stop)
+
+	var hook func(uint, dto.WhatsappConnEvent)
+	m.waRepo.EXPECT().OnConnectionEvent(mock.Anything).Run(func(f func(uint, dto.WhatsappConnEvent)) { hook = f }).Return()
+	svc.Watch(context.Background())
+
+	return svc, hook, m
+}
+
+// expectRecord expects a state in the history of device.
+func (m *connServiceMocks) expectRecord(device uint, state dto.WhatsappConnState) {
+	isDevice := mock.MatchedBy(func(ctx context.Context) bool {
+		return dto.WhatsappDeviceFromContext(ctx) == device
+	})
+	isState := mock.MatchedBy(func(log *entity.WhatsappConnectionLog) bool {
+		return log.State == string(state)
+	})
+
+	m.logRepo.EXPECT().Create(isDevice, mock.Anything, isState).Return(nil).Once()
+}
+
+func TestNextConnState(t *testing.T) {
+	tests := []struct {
+		state     dto.WhatsappConnState
+		evt       dto.WhatsappConnEventType
+		want      dto.WhatsappConnState
+		reconnect bool
+	}{
+		{dto.WAConnStateDisconnected, dto.WAConnEventConnected, dto.WAConnStateConnected, false},
+		{dto.WAConnStateConnected, dto.WAConnEventDisconnected, dto.WAConnStateReconnecting, true},
+		{dto.WAConnStateReplaced, dto.WAConnEventDisconnected, dto.WAConnStateReplaced, false},
+		{dto.WAConnStateConnected, dto.WAConnEventLoggedOut, dto.WAConnStateLoggedOut, false},
+		{dto.WAConnStateConnected, dto.WAConnEventLogout, dto.WAConnStateDisconnected, false},
+		{dto.WAConnStateConnected, dto.WAConnEventStreamReplaced, dto.WAConnStateReplaced, false},
+		{dto.WAConnStateReconnecting, dto.WAConnEventTemporaryBan, dto.WAConnStateBanned, false},
+		{dto.WAConnStateReconnecting, dto.WAConnEventClientOutdated, dto.WAConnStateOutdated, false},
+		{dto.WAConnStateReconnecting, dto.WAConnEventConnectFailure, dto.WAConnStateFailed, false},
+		{dto.WAConnStateConnected, dto.WAConnEventKeepAliveTimeout, dto.WAConnStateUnstable, false},
+		{dto.WAConnStateReconnecting, dto.WAConnEventKeepAliveTimeout, dto.WAConnStateReconnecting, false},
+		{dto.WAConnStateUnstable, dto.WAConnEventKeepAliveRestored, dto.WAConnStateConnected, false},
+		{dto.WAConnStateLoggedOut, dto.WAConnEventKeepAliveRestored, dto.WAConnStateLoggedOut, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.state)+" on "+string(tt.evt), func(t *testing.T) {
+			got, reconnect := nextConnState(tt.state, tt.evt)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.reconnect, reconnect)
+		})
+	}
+}
+
+func TestWhatsappConnectionService_reconnectDelay(t *testing.T) {
+	svc, _, _ := setupTestWhatsappConnectionService(t, map[string]any{
+		config.KeyWAReconnectMinDelay: "1s",
+		config.KeyWAReconnectMaxDelay: "5s",
+	})
+
+	assert.Equal(t, time.Second, svc.reconnectDelay(0))
+	assert.Equal(t, 2*time.Second, svc.reconnectDelay(1))
+	assert.Equal(t, 4*time.Second, svc.reconnectDelay(2))
+	assert.Equal(t, 5*time.Second, svc.reconnectDelay(3))
+	assert.Equal(t, 5*time.Second, svc.reconnectDelay(100))
+}
+
+func TestWhatsappConnectionService_Reconnect(t *testing.T) {
+	t.Run("reconnects a dropped connection with a backoff", func(t *testing.T) {
+		svc, hook, m := setupTestWhatsappConnectionService(t, map[string]any{
+			config.KeyWAReconnectMinDelay: "1ms",
+		})
+		ctx := dto.WithWhatsappDevice(context.Background(), 2)
+
+		m.expectRecord(2, dto.WAConnStateConnected)
+		hook(2, dto.WhatsappConnEvent{Type: dto.WAConnEventConnected})
+		assert.Equal(t, dto.WAConnStateConnected, svc.Status(ctx).State)
+
+		reconnected := make(chan struct{})
+		m.expectRecord(2, dto.WAConnStateReconnecting)
+		m.expectRecord(2, dto.WAConnStateReconnecting) // the failed attempt
+		m.waRepo.EXPECT().Reconnect(mock.Anything).Return(errors.New("network is down")).Once()
+		m.waRepo.EXPECT().Reconnect(mock.Anything).Run(func(context.Context) { close(reconnected) }).Return(nil).Once()
+		hook(2, dto.WhatsappConnEvent{Type: dto.WAConnEventDisconnected})
+
+		select {
+		case <-reconnected:
+		case <-time.After(time.Second):
+			t.Fatal("the device wasn't reconnected")
+		}
+
+		status := svc.Status(ctx)
+		assert.Equal(t, dto.WAConnStateReconnecting, status.State)
+		assert.Equal(t, 1, status.Attempts)
+		assert.Contains(t, status.Reason, "network is down")
+
+		m.expectRecord(2, dto.WAConnStateConnected)
+		hook(2, dto.WhatsappConnEvent{Type: dto.WAConnEventConnected})
+
+		status = svc.Status(ctx)
+		assert.Equal(t, dto.WAConnStateConnected, status.State)
+		assert.Zero(t, status.Attempts)
+		assert.Nil(t, status.NextRetry)
+	})
+
+	t.Run("retries when the reconnected connection drops again", func(t *testing.T) {
+		svc, hook, m := setupTestWhatsappConnectionService(t, map[string]any{
+			config.KeyWAReconnectMinDelay: "1ms",
+		})
+		ctx := dto.WithWhatsappDevice(context.Background(), 2)
+
+		m.expectRecord(2, dto.WAConnStateConnected)
+		hook(2, dto.WhatsappConnEvent{Type: dto.WAConnEventConnected})
+
+		reconnected := make(chan struct{})
+		m.expectRecord(2, dto.WAConnStateReconnecting)
+		m.waRepo.EXPECT().Reconnect(mock.Anything).Run(func(context.Context) { close(reconnected) }).Return(nil).Once()
+		hook(2, dto.WhatsappConnEvent{Type: dto.WAConnEventDisconnected})
+
+		select {
+		case <-reconnected:
+		case <-time.After(time.Second):
+			t.Fatal("the device wasn't reconnected")
+		}
+		assert.Eventually(t, func() bool { return svc.Status(ctx).NextRetry == nil }, time.Second, time.Millisecond)
+
+		// the reconnect went thru but the connection drops before logging in
+		reconnectedAgain := make(chan struct{})
+		m.expectRecord(2, dto.WAConnStateReconnecting) // the failed attempt
+		m.waRepo.EXPECT().Reconnect(mock.Anything).Run(func(context.Context) { close(reconnectedAgain) }).Return(nil).Once()
+		hook(2, dto.WhatsappConnEvent{Type: dto.WAConnEventDisconnected, Reason: "stream closed"})
+
+		status := svc.Status(ctx)
+		assert.Equal(t, dto.WAConnStateReconnecting, status.State)
+		assert.Equal(t, 1, status.Attempts)
+		assert.Contains(t, status.Reason, "stream closed")
+
+		select {
+		case <-reconnectedAgain:
+		case <-time.After(time.Second):
+			t.Fatal("the device wasn't reconnected again")
+		}
+	})
+
+	t.Run("a repeated disconnect waits for the scheduled reconnect", func(t *testing.T) {
+		svc, hook, m := setupTestWhatsappConnectionService(t, nil)
+		ctx := dto.WithWhatsappDevice(context.Background(), 2)
+
+		m.expectRecord(2, dto.WAConnStateReconnecting)
+		hook(2, dto.WhatsappConnEvent{Type: dto.WAConnEventDisconnected})
+		hook(2, dto.WhatsappConnEvent{Type: dto.WAConnEventDisconnected})
+
+		status := svc.Status(ctx)
+		assert.Zero(t, status.Attempts)
+		assert.NotNil(t, status.NextRetry)
+	})
+
+	t.Run("stops once the device is removed", func(t *testing.T) {
+		svc, hook, m := setupTestWhatsappConnectionService(t, map[string]any{
+			config.KeyWAReconnectMinDelay: "1ms",
+		})
+
+		reconnected := make(chan struct{})
+		m.expectRecord(1, dto.WAConnStateReconnecting)
+		m.waRepo.EXPECT().Reconnect(mock.Anything).Run(func(context.Context) { close(reconnected) }).Return(errs.ErrWADeviceNotFound).Once()
+		hook(1, dto.WhatsappConnEvent{Type: dto.WAConnEventDisconnected})
+
+		<-reconnected
+		assert.Eventually(t, func() bool {
+			return svc.Status(context.Background()).State == dto.WAConnStateDisconnected
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("no reconnect after stop", func(t *testing.T) {
+		svc, hook, m := setupTestWhatsappConnectionService(t, nil)
+
+		svc.Stop()
+		m.expectRecord(1, dto.WAConnStateReconnecting)
+		hook(1, dto.WhatsappConnEvent{Type: dto.WAConnEventDisconnected})
+
+		assert.Nil(t, svc.Status(context.Background()).NextRetry)
+	})
+}
+
+func TestWhatsappConnectionService_Alert(t *testing.T) {
+	t.Run("alerts every channel when the session is lost", func(t *testing.T) {
+		svc, hook, m := setupTestWhatsappConnectionService(t, map[string]any{
+			config.KeyAlertWebhookURL: "https://example.com/hook",
+			config.KeyAlertSMTPHost:   "smtp.example.com",
+			config.KeyAlertSMTPFrom:   "bot@example.com",
+			config.KeyAlertSMTPTo:     []string{"admin@example.com"},
+		})
+
+		emailed := make(chan struct{})
+		m.expectRecord(2, dto.WAConnStateLoggedOut)
+		m.deviceRepo.EXPECT().Get(mock.Anything, mock.Anything, uint(2)).Return(&entity.WhatsappDevice{ID: 2, Name: "Community"}, nil)
+		m.alertRepo.EXPECT().PostWebhook(mock.Anything, "https://example.com/hook", mock.MatchedBy(func(alert *dto.WhatsappConnAlert) bool {
+			return alert.DeviceName == "Community" && alert.State == dto.WAConnStateLoggedOut && alert.Reason == "logged out from another device"
+		})).Return(nil)
+		m.alertRepo.EXPECT().SendEmail(mock.Anything, mock.MatchedBy(func(cfg dto.SMTPConfig) bool {
+			return cfg.Host == "smtp.example.com" && cfg.Port == defaultSMTPPort
+		}), "WhatsApp bot: Community is Logged out", mock.Anything).
+			Run(func(context.Context, dto.SMTPConfig, string, string) { close(emailed) }).
+			Return(nil)
+
+		hook(2, dto.WhatsappConnEvent{Type: dto.WAConnEventLoggedOut, Reason: "logged out from another device"})
+
+		select {
+		case <-emailed:
+		case <-time.After(time.Second):
+			t.Fatal("no alert was sent")
+		}
+
+		status := svc.Status(dto.WithWhatsappDevice(context.Background(), 2))
+		assert.Equal(t, dto.WAConnStateLoggedOut, status.State)
+		assert.Nil(t, status.NextRetry)
+	})
+
+	t.Run("a temporary ban reconnects once it expires", func(t *testing.T) {
+		svc, hook, m := setupTestWhatsappConnectionService(t, nil)
+
+		m.expectRecord(1, dto.WAConnStateBanned)
+		m.deviceRepo.EXPECT().Get(mock.Anything, mock.Anything, uint(1)).Return(nil, nil).Maybe()
+		hook(1, dto.WhatsappConnEvent{Type: dto.WAConnEventTemporaryBan, Expire: time.Hour})
+
+		status := svc.Status(context.Background())
+		assert.Equal(t, dto.WAConnStateBanned, status.State)
+		require.NotNil(t, status.NextRetry)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), *status.NextRetry, time.Minute)
+	})
+}
+
+func TestWhatsappConnectionService_History(t *testing.T) {
+	svc, _, m := setupTestWhatsappConnectionService(t, nil)
+
+	at := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	m.logRepo.EXPECT().GetLatest(mock.Anything, mock.Anything, 20).Return([]*entity.WhatsappConnectionLog{
+		{State: string(dto.WAConnStateReplaced), CreatedAt: at},
+	}, nil)
+
+	history, err := svc.History(context.Background(), 20)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "Replaced by another client", history[0].Label)
+	assert.Equal(t, at, history[0].At)
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/repository"
+	"log/slog"
+)
+
+// IWhatsappDeviceService manages the bot accounts, every whatsapp repository
+// call is scoped to a device thru dto.WithWhatsappDevice.
+type IWhatsappDeviceService interface {
+	// Load opens every device and keeps their paired store device up to date.
+	// The default device adopts the store device paired before there were
+	// several devices.
+	Load(ctx context.Context) error
+
+	// LoginWithExistingSessions connects every paired device, it returns the
+	// ids of the ones that logged in.
+	LoginWithExistingSessions(ctx context.Context) ([]uint, error)
+
+	// List returns every device with its connection status, Current is the
+	// device of ctx.
+	List(ctx context.Context) ([]*dto.WhatsappDevice, error)
+	Exists(ctx context.Context, deviceID uint) (bool, error)
+	Create(ctx context.Context, req *dto.CreateWhatsappDeviceReq) (*dto.WhatsappDevice, error)
+
+	// Delete logs a device out and deletes it with its whitelists, settings
+	// and roles, the default device can't be deleted.
+	Delete(ctx context.Context, req *dto.WhatsappDeviceReq) error
+}
+
+type WhatsappDeviceService struct {
+	*svcTmpl
+	waRepo     repository.IWhatsappRepo
+	deviceRepo repository.IWhatsappDeviceRepo
+}
+
+func NewWhatsappDeviceService(svcTmpl *svcTmpl, waRepo repository.IWhatsappRepo, deviceRepo repository.IWhatsappDeviceRepo) IWhatsappDeviceService {
+	return &WhatsappDeviceService{
+		svcTmpl:    svcTmpl,
+		waRepo:     waRepo,
+		deviceRepo: deviceRepo,
+	}
+}
+
+func (s *WhatsappDeviceService) Load(ctx context.Context) error {
+	s.waRepo.OnDeviceJID(func(deviceID uint, jid string) {
+		// called from whatsmeow's event loop, the device outlives ctx
+		ctx := dto.WithWhatsappDevice(context.WithoutCancel(ctx), deviceID)
+		if err := s.updateJID(ctx, deviceID, jid); err != nil {
+			slog.ErrorContext(ctx, "failed to save the whatsapp device's jid", "device", deviceID, "error", err.Error())
+		}
+	})
+
+	devices, err := s.adoptStoredDevice(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, device := range devices {
+		if err := s.waRepo.OpenDevice(ctx, device.ID, device.JID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// adoptStoredDevice gives the default device the first paired store device no
+// device claims, if it isn't paired, and returns every device.
+func (s *WhatsappDeviceService) adoptStoredDevice(ctx context.Context) ([]*entity.WhatsappDevice, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	devices, err := s.deviceRepo.GetAll(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultDevice *entity.WhatsappDevice
+	claimed := make(map[string]bool)
+	for _, device := range devices {
+		if device.ID == dto.DefaultWhatsappDevice {
+			defaultDevice = device
+		}
+		claimed[device.JID] = true
+	}
+
+	if defaultDevice == nil || defaultDevice.JID != "" {
+		return devices, nil
+	}
+
+	storedJIDs, err := s.waRepo.GetStoredDeviceJIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, jid := range storedJIDs {
+		if claimed[jid] {
+			continue
+		}
+
+		if err := s.deviceRepo.UpdateJID(ctx, tx, defaultDevice.ID, jid); err != nil {
+			return nil, err
+		}
+		defaultDevice.JID = jid
+		break
+	}
+
+	return devices, s.tx.Commit(tx)
+}
+
+func (s *WhatsappDeviceService) updateJID(ctx context.Context, deviceID uint, jid string) error {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	if err := s.deviceRepo.UpdateJID(ctx, tx, deviceID, jid); err != nil {
+		return err
+	}
+
+	return s.tx.Commit(tx)
+}
+
+func (s *WhatsappDeviceService) LoginWithExistingSessions(ctx context.Context) ([]uint, error) {
+	devices, err := s.getAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loggedIn := make([]uint, 0)
+	for _, device := range devices {
+		ok, err := s.waRepo.LoginWithExistingSession(dto.WithWhatsappDevice(ctx, device.ID))
+		if err != nil {
+			return loggedIn, err
+		}
+
+		if ok {
+			loggedIn = append(loggedIn, device.ID)
+		}
+	}
+
+	return loggedIn, nil
+}
+
+func (s *WhatsappDeviceService) List(ctx context.Context) ([]*dto.WhatsappDevice, error) {
+	devices, err := s.getAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := dto.WhatsappDeviceFromContext(ctx)
+
+	res := make([]*dto.WhatsappDevice, len(devices))
+	for i, device := range devices {
+		res[i] = dto.NewWhatsappDevice(device, s.waRepo.GetDeviceStatus(ctx, device.ID))
+		res[i].Current = device.ID == current
+	}
+
+	return res, nil
+}
+
+func (s *WhatsappDeviceService) getAll(ctx context.Context) ([]*entity.WhatsappDevice, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	return s.deviceRepo.GetAll(ctx, tx)
+}
+
+func (s *WhatsappDeviceService) Exists(ctx context.Context, deviceID uint) (bool, error) {
+	device, err := s.get(ctx, deviceID)
+	if err != nil {
+		return false, err
+	}
+
+	return device != nil, nil
+}
+
+func (s *WhatsappDeviceService) get(ctx context.Context, deviceID uint) (*entity.WhatsappDevice, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	return s.deviceRepo.Get(ctx, tx, deviceID)
+}
+
+func (s *WhatsappDeviceService) Create(ctx context.Context, req *dto.CreateWhatsappDeviceReq) (*dto.WhatsappDevice, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	device := &entity.WhatsappDevice{Name: req.Name}
+	if err := s.deviceRepo.Create(ctx, tx, device); err != nil {
+		return nil, err
+	}
+
+	if err := s.tx.Commit(tx); err != nil {
+		return nil, err
+	}
+
+	// not paired yet, it's paired from the whatsapp login page
+	if err := s.waRepo.OpenDevice(ctx, device.ID, ""); err != nil {
+		return nil, err
+	}
+
+	return dto.NewWhatsappDevice(device, s.waRepo.GetDeviceStatus(ctx, device.ID)), nil
+}
+
+func (s *WhatsappDeviceService) Delete(ctx context.Context, req *dto.WhatsappDeviceReq) error {
+	if req.ID == dto.DefaultWhatsappDevice {
+		return errs.ErrWADeviceNotRemovable
+	}
+
+	device, err := s.get(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	if device == nil {
+		return errs.ErrWADeviceNotFound
+	}
+
+	// before the transaction, the logout saves the device's jid. Either way
+	// its store device goes, an unpaired device would adopt it otherwise.
+	deviceCtx := dto.WithWhatsappDevice(ctx, device.ID)
+	if s.waRepo.IsLoggedIn(deviceCtx) {
+		if err := s.waRepo.Logout(deviceCtx); err != nil {
+			return err
+		}
+	} else if device.JID != "" {
+		slog.WarnContext(ctx, "deleting a whatsapp device that isn't connected, unlink it from the phone", "device", device.ID, "jid", device.JID)
+
+		s.waRepo.CloseDevice(device.ID)
+		if err := s.waRepo.DeleteStoredDevice(ctx, device.JID); err != nil {
+			return err
+		}
+	}
+
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	if err := s.deviceRepo.Delete(ctx, tx, device.ID); err != nil {
+		return err
+	}
+
+	if err := s.tx.Commit(tx); err != nil {
+		return err
+	}
+
+	s.waRepo.CloseDevice(device.ID)
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	mockRepo "exaroton-wa-bot/internal/mocks/repository"
+	"testing"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type deviceServiceMocks struct {
+	sqlTx      *mockRepo.MockSqlTx
+	waRepo     *mockRepo.MockIWhatsappRepo
+	deviceRepo *mockRepo.MockIWhatsappDeviceRepo
+}
+
+func setupTestWhatsappDeviceService(t *testing.T) (IWhatsappDeviceService, *deviceServiceMocks) {
+	m := &deviceServiceMocks{
+		sqlTx:      mockRepo.NewMockSqlTx(t),
+		waRepo:     mockRepo.NewMockIWhatsappRepo(t),
+		deviceRepo: mockRepo.NewMockIWhatsappDeviceRepo(t),
+	}
+
+	m.sqlTx.EXPECT().Begin(mock.Anything).Return(new(gorm.DB)).Maybe()
+	m.sqlTx.EXPECT().Rollback(mock.Anything).Return(nil).Maybe()
+
+	svcTmpl := &svcTmpl{
+		cfg: &config.Cfg{Koanf: koanf.New(".")},
+		tx:  m.sqlTx,
+	}
+
+	return NewWhatsappDeviceService(svcTmpl, m.waRepo, m.deviceRepo), m
+}
+
+func TestWhatsappDeviceService_Load(t *testing.T) {
+	t.Run("the default device adopts the unclaimed store device", func(t *testing.T) {
+		svc, m := setupTestWhatsappDeviceService(t)
+
+		m.waRepo.EXPECT().OnDeviceJID(mock.Anything).Return()
+		m.deviceRepo.EXPECT().GetAll(mock.Anything, mock.Anything).Return([]*entity.WhatsappDevice{
+			{ID: 1, Name: "Default"},
+			{ID: 2, Name: "Second", JID: "6285:2@s.whatsapp.net"},
+		}, nil)
+		m.waRepo.EXPECT().GetStoredDeviceJIDs(mock.Anything).Return([]string{"6285:2@s.whatsapp.net", "6281:7@s.whatsapp.net"}, nil)
+		m.deviceRepo.EXPECT().UpdateJID(mock.Anything, mock.Anything, uint(1), "6281:7@s.whatsapp.net").Return(nil)
+		m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+		m.waRepo.EXPECT().OpenDevice(mock.Anything, uint(1), "6281:7@s.whatsapp.net").Return(nil)
+		m.waRepo.EXPECT().OpenDevice(mock.Anything, uint(2), "6285:2@s.whatsapp.net").Return(nil)
+
+		require.NoError(t, svc.Load(context.Background()))
+	})
+
+	t.Run("a paired default device keeps its store device", func(t *testing.T) {
+		svc, m := setupTestWhatsappDeviceService(t)
+
+		m.waRepo.EXPECT().OnDeviceJID(mock.Anything).Return()
+		m.deviceRepo.EXPECT().GetAll(mock.Anything, mock.Anything).Return([]*entity.WhatsappDevice{
+			{ID: 1, Name: "Default", JID: "6281:7@s.whatsapp.net"},
+			{ID: 3, Name: "New"},
+		}, nil)
+		m.waRepo.EXPECT().OpenDevice(mock.Anything, uint(1), "6281:7@s.whatsapp.net").Return(nil)
+		m.waRepo.EXPECT().OpenDevice(mock.Anything, uint(3), "").Return(nil)
+
+		require.NoError(t, svc.Load(context.Background()))
+	})
+
+	t.Run("the jid hook saves the device's jid", func(t *testing.T) {
+		svc, m := setupTestWhatsappDeviceService(t)
+
+		var hook func(deviceID uint, jid string)
+		m.waRepo.EXPECT().OnDeviceJID(mock.Anything).Run(func(f func(uint, string)) { hook = f }).Return()
+		m.deviceRepo.EXPECT().GetAll(mock.Anything, mock.Anything).Return([]*entity.WhatsappDevice{}, nil)
+		require.NoError(t, svc.Load(context.Background()))
+
+		m.deviceRepo.EXPECT().UpdateJID(mock.Anything, mock.Anything, uint(2), "").Return(nil)
+		m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+		hook(2, "")
+	})
+}
+
+func TestWhatsappDeviceService_List(t *testing.T) {
+	svc, m := setupTestWhatsappDeviceService(t)
+
+	m.deviceRepo.EXPECT().GetAll(mock.Anything, mock.Anything).Return([]*entity.WhatsappDevice{
+		{ID: 1, Name: "Default"},
+		{ID: 2, Name: "Second"},
+	}, nil)
+	m.waRepo.EXPECT().GetDeviceStatus(mock.Anything, uint(1)).Return(dto.WhatsappDeviceStatus{})
+	m.waRepo.EXPECT().GetDeviceStatus(mock.Anything, uint(2)).Return(dto.WhatsappDeviceStatus{LoggedIn: true, Connected: true, Phone: "6285"})
+
+	devices, err := svc.List(dto.WithWhatsappDevice(context.Background(), 2))
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+
+	assert.True(t, devices[0].Default)
+	assert.False(t, devices[0].Current)
+	assert.True(t, devices[1].Current)
+	assert.Equal(t, "6285", devices[1].Phone)
+}
+
+func TestWhatsappDeviceService_Create(t *testing.T) {
+	svc, m := setupTestWhatsappDeviceService(t)
+
+	m.deviceRepo.EXPECT().Create(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, _ *gorm.DB, device *entity.WhatsappDevice) { device.ID = 4 }).
+		Return(nil)
+	m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+	m.waRepo.EXPECT().OpenDevice(mock.Anything, uint(4), "").Return(nil)
+	m.waRepo.EXPECT().GetDeviceStatus(mock.Anything, uint(4)).Return(dto.WhatsappDeviceStatus{})
+
+	device, err := svc.Create(context.Background(), &dto.CreateWhatsappDeviceReq{Name: "Community"})
+	require.NoError(t, err)
+	assert.Equal(t, uint(4), device.ID)
+	assert.Equal(t, "Community", device.Name)
+}
+
+func TestWhatsappDeviceService_Delete(t *testing.T) {
+	t.Run("the default device can't be removed", func(t *testing.T) {
+		svc, _ := setupTestWhatsappDeviceService(t)
+
+		err := svc.Delete(context.Background(), &dto.WhatsappDeviceReq{ID: dto.DefaultWhatsappDevice})
+		assert.ErrorIs(t, err, errs.ErrWADeviceNotRemovable)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc, m := setupTestWhatsappDeviceService(t)
+
+		m.deviceRepo.EXPECT().Get(mock.Anything, mock.Anything, uint(9)).Return(nil, nil)
+
+		err := svc.Delete(context.Background(), &dto.WhatsappDeviceReq{ID: 9})
+		assert.ErrorIs(t, err, errs.ErrWADeviceNotFound)
+	})
+
+	t.Run("logs the device out first", func(t *testing.T) {
+		svc, m := setupTestWhatsappDeviceService(t)
+
+		isDevice := mock.MatchedBy(func(ctx context.Context) bool {
+			return dto.WhatsappDeviceFromContext(ctx) == 2
+		})
+
+		m.deviceRepo.EXPECT().Get(mock.Anything, mock.Anything, uint(2)).Return(&entity.WhatsappDevice{ID: 2, JID: "6285:2@s.whatsapp.net"}, nil)
+		m.waRepo.EXPECT().IsLoggedIn(isDevice).Return(true)
+		m.waRepo.EXPECT().Logout(isDevice).Return(nil)
+		m.deviceRepo.EXPECT().Delete(mock.Anything, mock.Anything, uint(2)).Return(nil)
+		m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+		m.waRepo.EXPECT().CloseDevice(uint(2)).Return()
+
+		require.NoError(t, svc.Delete(context.Background(), &dto.WhatsappDeviceReq{ID: 2}))
+	})
+
+	t.Run("deletes the store device of a device that isn't connected", func(t *testing.T) {
+		svc, m := setupTestWhatsappDeviceService(t)
+
+		m.deviceRepo.EXPECT().Get(mock.Anything, mock.Anything, uint(2)).Return(&entity.WhatsappDevice{ID: 2, JID: "6285:2@s.whatsapp.net"}, nil)
+		m.waRepo.EXPECT().IsLoggedIn(mock.Anything).Return(false)
+		m.waRepo.EXPECT().CloseDevice(uint(2)).Return()
+		m.waRepo.EXPECT().DeleteStoredDevice(mock.Anything, "6285:2@s.whatsapp.net").Return(nil)
+		m.deviceRepo.EXPECT().Delete(mock.Anything, mock.Anything, uint(2)).Return(nil)
+		m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+
+		require.NoError(t, svc.Delete(context.Background(), &dto.WhatsappDeviceReq{ID: 2}))
+	})
+
+	t.Run("keeps the device if its store device can't be deleted", func(t *testing.T) {
+		svc, m := setupTestWhatsappDeviceService(t)
+
+		m.deviceRepo.EXPECT().Get(mock.Anything, mock.Anything, uint(2)).Return(&entity.WhatsappDevice{ID: 2, JID: "6285:2@s.whatsapp.net"}, nil)
+		m.waRepo.EXPECT().IsLoggedIn(mock.Anything).Return(false)
+		m.waRepo.EXPECT().CloseDevice(uint(2)).Return()
+		m.waRepo.EXPECT().DeleteStoredDevice(mock.Anything, "6285:2@s.whatsapp.net").Return(errors.New("database is locked"))
+
+		assert.Error(t, svc.Delete(context.Background(), &dto.WhatsappDeviceReq{ID: 2}))
+	})
+}
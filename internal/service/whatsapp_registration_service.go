@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/repository"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// registrationCodeAlphabet leaves out the look-alike characters (0/O, 1/I),
+// its 32 characters split a random byte evenly.
+const registrationCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+const registrationCodeLen = 8
+
+type IWhatsappRegistrationService interface {
+	// CreateCode creates a one-time registration code, a group redeems it
+	// with /register to be whitelisted with the settings it presets.
+	CreateCode(ctx context.Context, req *dto.CreateRegistrationCodeReq) (*dto.WhatsappRegistrationCode, error)
+	GetCodes(ctx context.Context) ([]*dto.WhatsappRegistrationCode, error)
+	DeleteCode(ctx context.Context, req *dto.DeleteRegistrationCodeReq) error
+
+	// Redeem whitelists the group of req with the settings of its code, and
+	// records the redemption in the audit trail. It returns
+	// errs.ErrRegistrationCodeInvalid if the code doesn't exist, expired or
+	// was already redeemed.
+	Redeem(ctx context.Context, req *dto.RedeemRegistrationCodeReq) (*dto.WhatsappRegistrationCode, error)
+	// GetRegistrations returns the latest redemptions, the newest first.
+	GetRegistrations(ctx context.Context, limit int) ([]*dto.WhatsappGroupRegistration, error)
+}
+
+type WhatsappRegistrationService struct {
+	*svcTmpl
+	registrationRepo  repository.IWhatsappRegistrationRepo
+	waRepo            repository.IWhatsappRepo
+	groupSettingsRepo repository.IWhatsappGroupSettingsRepo
+}
+
+func NewWhatsappRegistrationService(svcTmpl *svcTmpl, registrationRepo repository.IWhatsappRegistrationRepo,
+	waRepo repository.IWhatsappRepo, groupSettingsRepo repository.IWhatsappGroupSettingsRepo) IWhatsappRegistrationService {
+	return &WhatsappRegistrationService{
+		svcTmpl:           svcTmpl,
+		registrationRepo:  registrationRepo,
+		waRepo:            waRepo,
+		groupSettingsRepo: groupSettingsRepo,
+	}
+}
+
+func (s *WhatsappRegistrationService) CreateCode(ctx context.Context, req *dto.CreateRegistrationCodeReq) (*dto.WhatsappRegistrationCode, error) {
+	code, err := newRegistrationCode()
+	if err != nil {
+		return nil, err
+	}
+
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	now := time.Now()
+	e := req.ToEntity(code, now)
+	if err := s.registrationRepo.CreateCode(ctx, tx, e); err != nil {
+		return nil, err
+	}
+
+	if err := s.tx.Commit(tx); err != nil {
+		return nil, err
+	}
+
+	return dto.NewWhatsappRegistrationCode(e, now), nil
+}
+
+func (s *WhatsappRegistrationService) GetCodes(ctx context.Context) ([]*dto.WhatsappRegistrationCode, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	codes, err := s.registrationRepo.GetCodes(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	res := make([]*dto.WhatsappRegistrationCode, len(codes))
+	for i, code := range codes {
+		res[i] = dto.NewWhatsappRegistrationCode(code, now)
+	}
+
+	return res, nil
+}
+
+func (s *WhatsappRegistrationService) DeleteCode(ctx context.Context, req *dto.DeleteRegistrationCodeReq) error {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	if err := s.registrationRepo.DeleteCode(ctx, tx, req.ID); err != nil {
+		return err
+	}
+
+	return s.tx.Commit(tx)
+}
+
+func (s *WhatsappRegistrationService) Redeem(ctx context.Context, req *dto.RedeemRegistrationCodeReq) (*dto.WhatsappRegistrationCode, error) {
+	if req.Group.Server != types.GroupServer {
+		return nil, errs.ErrRegisterGroupOnly
+	}
+
+	// the info is stored so the group is still named once the bot leaves it
+	var name, topic string
+	info, err := s.waRepo.GetGroupInfo(ctx, req.Group)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to get group info", "error", err.Error())
+	} else {
+		name, topic = info.Name, info.Topic
+	}
+
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	now := time.Now()
+	code, err := s.registrationRepo.GetCode(ctx, tx, strings.ToUpper(req.Code))
+	if err != nil {
+		return nil, err
+	}
+	if code == nil || dto.RegistrationCodeStatus(code, now) != dto.RegistrationCodeActive {
+		return nil, errs.ErrRegistrationCodeInvalid
+	}
+
+	groups, err := s.waRepo.GetWhitelistedGroupJIDs(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// a code isn't spent on a group that can already use the bot
+	whitelisted := slices.ContainsFunc(groups, func(g *entity.WhatsappWhitelistedGroup) bool {
+		return g.JID == req.Group.User && g.ServerJID == req.Group.Server
+	})
+	if whitelisted {
+		return nil, errs.ErrWAGroupAlreadyWhitelisted
+	}
+
+	redeemed, err := s.registrationRepo.RedeemCode(ctx, tx, code.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !redeemed {
+		return nil, errs.ErrRegistrationCodeInvalid
+	}
+	code.RedeemedAt = &now
+
+	err = s.waRepo.WhitelistGroup(ctx, tx, &entity.WhatsappWhitelistedGroup{
+		JID:        req.Group.User,
+		ServerJID:  req.Group.Server,
+		Name:       name,
+		Topic:      topic,
+		LastSeenAt: &now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.groupSettingsRepo.Upsert(ctx, tx, dto.NewRegistrationGroupSettings(req.Group, code)...); err != nil {
+		return nil, err
+	}
+
+	err = s.registrationRepo.CreateRegistration(ctx, tx, &entity.WhatsappGroupRegistration{
+		Code:           code.Code,
+		GroupJID:       req.Group.User,
+		GroupServerJID: req.Group.Server,
+		GroupName:      name,
+		RedeemedBy:     req.RedeemedBy.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tx.Commit(tx); err != nil {
+		return nil, err
+	}
+
+	return dto.NewWhatsappRegistrationCode(code, now), nil
+}
+
+func (s *WhatsappRegistrationService) GetRegistrations(ctx context.Context, limit int) ([]*dto.WhatsappGroupRegistration, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	registrations, err := s.registrationRepo.GetRegistrations(ctx, tx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*dto.WhatsappGroupRegistration, len(registrations))
+	for i, registration := range registrations {
+		res[i] = dto.NewWhatsappGroupRegistration(registration)
+	}
+
+	return res, nil
+}
+
+// newRegistrationCode returns a random code, e.g. "K7QM2XHP".
+func newRegistrationCode() (string, error) {
+	b := make([]byte, registrationCodeLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	for i := range b {
+		b[i] = registrationCodeAlphabet[int(b[i])%len(registrationCodeAlphabet)]
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants/errs"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	mockRepo "exaroton-wa-bot/internal/mocks/repository"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mau.fi/whatsmeow/types"
+	"gorm.io/gorm"
+)
+
+type whatsappRegistrationServiceMocks struct {
+	sqlTx            *mockRepo.MockSqlTx
+	registrationRepo *mockRepo.MockIWhatsappRegistrationRepo
+	waRepo           *mockRepo.MockIWhatsappRepo
+	groupSettings    *mockRepo.MockIWhatsappGroupSettingsRepo
+}
+
+func setupTestWhatsappRegistrationService(t *testing.T) (IWhatsappRegistrationService, *whatsappRegistrationServiceMocks) {
+	m := &whatsappRegistrationServiceMocks{
+		sqlTx:            mockRepo.NewMockSqlTx(t),
+		registrationRepo: mockRepo.NewMockIWhatsappRegistrationRepo(t),
+		waRepo:           mockRepo.NewMockIWhatsappRepo(t),
+		groupSettings:    mockRepo.NewMockIWhatsappGroupSettingsRepo(t),
+	}
+
+	m.sqlTx.EXPECT().Begin(mock.Anything).Return(new(gorm.DB)).Maybe()
+	m.sqlTx.EXPECT().Rollback(mock.Anything).Return(nil).Maybe()
+
+	svcTmpl := &svcTmpl{
+		cfg: &config.Cfg{Koanf: koanf.New(".")},
+		tx:  m.sqlTx,
+	}
+
+	return NewWhatsappRegistrationService(svcTmpl, m.registrationRepo, m.waRepo, m.groupSettings), m
+}
+
+func TestWhatsappRegistrationService_CreateCode(t *testing.T) {
+	svc, m := setupTestWhatsappRegistrationService(t)
+
+	m.registrationRepo.EXPECT().CreateCode(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, tx *gorm.DB, code *entity.WhatsappRegistrationCode) error {
+			code.ID = 1
+			return nil
+		})
+	m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+
+	before := time.Now()
+	res, err := svc.CreateCode(context.Background(), &dto.CreateRegistrationCodeReq{ExpiresInMinutes: 60, Servers: []string{"a", "c"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(1), res.ID)
+	assert.Len(t, res.Code, registrationCodeLen)
+	for _, r := range res.Code {
+		assert.Contains(t, registrationCodeAlphabet, string(r))
+	}
+	assert.Equal(t, []string{"a", "c"}, res.Servers)
+	assert.Equal(t, dto.RegistrationCodeActive, res.Status)
+	assert.WithinDuration(t, before.Add(time.Hour), res.ExpiresAt, time.Minute)
+}
+
+func TestWhatsappRegistrationService_Redeem(t *testing.T) {
+	group := dto.WhatsappJID{User: "1", Server: types.GroupServer}
+	sender := dto.WhatsappJID{User: "6285", Server: types.DefaultUserServer}
+	req := &dto.RedeemRegistrationCodeReq{Code: "k7qm2xhp", Group: group, RedeemedBy: sender}
+
+	activeCode := func() *entity.WhatsappRegistrationCode {
+		return &entity.WhatsappRegistrationCode{
+			ID: 1, Code: "K7QM2XHP", Servers: "a,c", AdminsAreOperators: true,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	setup := func(t *testing.T, code *entity.WhatsappRegistrationCode) (IWhatsappRegistrationService, *whatsappRegistrationServiceMocks) {
+		svc, m := setupTestWhatsappRegistrationService(t)
+		m.waRepo.EXPECT().GetGroupInfo(mock.Anything, group).Return(groupInfo("1", "Group", "Rules"), nil)
+		m.registrationRepo.EXPECT().GetCode(mock.Anything, mock.Anything, "K7QM2XHP").Return(code, nil)
+
+		return svc, m
+	}
+
+	t.Run("whitelists the group", func(t *testing.T) {
+		code := activeCode()
+		svc, m := setup(t, code)
+
+		m.waRepo.EXPECT().GetWhitelistedGroupJIDs(mock.Anything, mock.Anything).Return([]*entity.WhatsappWhitelistedGroup{
+			{JID: "2", ServerJID: types.GroupServer},
+		}, nil)
+		m.registrationRepo.EXPECT().RedeemCode(mock.Anything, mock.Anything, uint(1), mock.Anything).Return(true, nil)
+		m.waRepo.EXPECT().WhitelistGroup(mock.Anything, mock.Anything, mock.MatchedBy(func(g *entity.WhatsappWhitelistedGroup) bool {
+			return g.JID == "1" && g.Name == "Group" && g.Topic == "Rules"
+		})).Return(nil)
+		m.groupSettings.EXPECT().Upsert(mock.Anything, mock.Anything, dto.NewRegistrationGroupSettings(group, code)).Return(nil)
+		m.registrationRepo.EXPECT().CreateRegistration(mock.Anything, mock.Anything, mock.MatchedBy(func(r *entity.WhatsappGroupRegistration) bool {
+			return r.Code == "K7QM2XHP" && r.GroupJID == "1" && r.GroupName == "Group" && r.RedeemedBy == "6285@s.whatsapp.net"
+		})).Return(nil)
+		m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+
+		res, err := svc.Redeem(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "c"}, res.Servers)
+		assert.Equal(t, dto.RegistrationCodeRedeemed, res.Status)
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		svc, _ := setup(t, nil)
+
+		_, err := svc.Redeem(context.Background(), req)
+		assert.ErrorIs(t, err, errs.ErrRegistrationCodeInvalid)
+	})
+
+	t.Run("expired code", func(t *testing.T) {
+		code := activeCode()
+		code.ExpiresAt = time.Now().Add(-time.Minute)
+		svc, _ := setup(t, code)
+
+		_, err := svc.Redeem(context.Background(), req)
+		assert.ErrorIs(t, err, errs.ErrRegistrationCodeInvalid)
+	})
+
+	t.Run("redeemed meanwhile", func(t *testing.T) {
+		svc, m := setup(t, activeCode())
+
+		m.waRepo.EXPECT().GetWhitelistedGroupJIDs(mock.Anything, mock.Anything).Return(nil, nil)
+		m.registrationRepo.EXPECT().RedeemCode(mock.Anything, mock.Anything, uint(1), mock.Anything).Return(false, nil)
+
+		_, err := svc.Redeem(context.Background(), req)
+		assert.ErrorIs(t, err, errs.ErrRegistrationCodeInvalid)
+	})
+
+	t.Run("already whitelisted group keeps the code", func(t *testing.T) {
+		svc, m := setup(t, activeCode())
+
+		m.waRepo.EXPECT().GetWhitelistedGroupJIDs(mock.Anything, mock.Anything).Return([]*entity.WhatsappWhitelistedGroup{
+			{JID: "1", ServerJID: types.GroupServer},
+		}, nil)
+
+		_, err := svc.Redeem(context.Background(), req)
+		assert.ErrorIs(t, err, errs.ErrWAGroupAlreadyWhitelisted)
+	})
+
+	t.Run("group info is optional", func(t *testing.T) {
+		svc, m := setupTestWhatsappRegistrationService(t)
+		m.waRepo.EXPECT().GetGroupInfo(mock.Anything, group).Return(nil, errors.New("offline"))
+		m.registrationRepo.EXPECT().GetCode(mock.Anything, mock.Anything, "K7QM2XHP").Return(nil, nil)
+
+		_, err := svc.Redeem(context.Background(), req)
+		assert.ErrorIs(t, err, errs.ErrRegistrationCodeInvalid)
+	})
+
+	t.Run("direct chat", func(t *testing.T) {
+		svc, _ := setupTestWhatsappRegistrationService(t)
+
+		_, err := svc.Redeem(context.Background(), &dto.RedeemRegistrationCodeReq{Code: "K7QM2XHP", Group: sender, RedeemedBy: sender})
+		assert.ErrorIs(t, err, errs.ErrRegisterGroupOnly)
+	})
+}
@@ -2,9 +2,15 @@ package service
 
 import (
 	"context"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/constants"
+	"exaroton-wa-bot/internal/database/entity"
 	"exaroton-wa-bot/internal/dto"
+	"exaroton-wa-bot/internal/i18n"
 	"exaroton-wa-bot/internal/repository"
 	"log/slog"
+	"slices"
+	"time"
 
 	"go.mau.fi/whatsmeow/types"
 )
@@ -12,22 +18,68 @@ import (
 type IWhatsappService interface {
 	WhitelistGroup(ctx context.Context, req *dto.WhitelistWhatsappGroupReq) error
 	UnwhitelistGroup(ctx context.Context, req *dto.UnwhitelistWhatsappGroupReq) error
+	// GetGroups returns the groups the bot is in, with req.Whitelist the
+	// whitelisted groups also include the ones the bot isn't in anymore,
+	// flagged as left.
 	GetGroups(ctx context.Context, req *dto.GetWhatsappGroupReq) ([]*dto.WhatsappGroupInfo, error)
+
+	// PreviewGroupInvite returns the group of an invite link, without joining it.
+	PreviewGroupInvite(ctx context.Context, req *dto.WhatsappGroupInviteReq) (*dto.WhatsappGroupInfo, error)
+	// JoinGroup joins the group of an invite link, with req.Whitelist it's
+	// whitelisted with the servers it can use.
+	JoinGroup(ctx context.Context, req *dto.JoinWhatsappGroupReq) (*dto.WhatsappGroupInfo, error)
+
+	// the info of the whitelisted groups is kept current from the group events
+
+	// SyncGroup stores the info of a group the bot is in, if it's whitelisted.
+	SyncGroup(ctx context.Context, info *types.GroupInfo) error
+	UpdateGroupInfo(ctx context.Context, req *dto.WhatsappGroupInfoUpdate) error
+	// MarkGroupLeft flags a whitelisted group the bot left or was removed from,
+	// it's unwhitelisted instead if config.KeyWARemoveLeftGroups is set.
+	MarkGroupLeft(ctx context.Context, group dto.WhatsappJID) error
+	// ReconcileGroups compares the whitelist with the groups the bot is in,
+	// the others are handled like MarkGroupLeft.
+	ReconcileGroups(ctx context.Context) error
+	// CleanupGroups unwhitelists the groups the bot isn't in anymore.
+	CleanupGroups(ctx context.Context) (*dto.WhatsappGroupsCleanupRes, error)
+
+	// direct messages allowlist
+	WhitelistUser(ctx context.Context, req *dto.WhitelistWhatsappUserReq) error
+	UnwhitelistUser(ctx context.Context, req *dto.UnwhitelistWhatsappUserReq) error
+
+	GetGroupSettings(ctx context.Context, group dto.WhatsappJID) (*dto.WhatsappGroupSettings, error)
+	UpdateGroupSettings(ctx context.Context, req *dto.UpdateWhatsappGroupSettingsReq) error
+
+	// SetGroupLanguage sets the language of a chat's replies, a group or a direct chat.
+	SetGroupLanguage(ctx context.Context, group dto.WhatsappJID, lang i18n.Lang) error
 }
 
 type WhatsappService struct {
 	*svcTmpl
-	waRepo repository.IWhatsappRepo
+	waRepo            repository.IWhatsappRepo
+	groupSettingsRepo repository.IWhatsappGroupSettingsRepo
 }
 
-func NewWhatsappService(svcTmpl *svcTmpl, waRepo repository.IWhatsappRepo) IWhatsappService {
+func NewWhatsappService(svcTmpl *svcTmpl, waRepo repository.IWhatsappRepo, groupSettingsRepo repository.IWhatsappGroupSettingsRepo) IWhatsappService {
 	return &WhatsappService{
-		svcTmpl: svcTmpl,
-		waRepo:  waRepo,
+		svcTmpl:           svcTmpl,
+		waRepo:            waRepo,
+		groupSettingsRepo: groupSettingsRepo,
 	}
 }
 
 func (s *WhatsappService) WhitelistGroup(ctx context.Context, req *dto.WhitelistWhatsappGroupReq) error {
+	group := &entity.WhatsappWhitelistedGroup{JID: req.User, ServerJID: req.Server}
+
+	// the info is stored so the group is still named once the bot leaves it
+	info, err := s.waRepo.GetGroupInfo(ctx, dto.WhatsappJID{User: req.User, Server: req.Server})
+	if err != nil {
+		slog.WarnContext(ctx, "failed to get group info", "error", err.Error())
+	} else {
+		now := time.Now()
+		group.Name, group.Topic, group.LastSeenAt = info.Name, info.Topic, &now
+	}
+
 	tx := s.tx.Begin(ctx)
 	defer func() {
 		if rbErr := s.tx.Rollback(tx); rbErr != nil {
@@ -35,7 +87,7 @@ func (s *WhatsappService) WhitelistGroup(ctx context.Context, req *dto.Whitelist
 		}
 	}()
 
-	if err := s.waRepo.WhitelistGroup(ctx, tx, req); err != nil {
+	if err := s.waRepo.WhitelistGroup(ctx, tx, group); err != nil {
 		return err
 	}
 
@@ -57,6 +109,36 @@ func (s *WhatsappService) UnwhitelistGroup(ctx context.Context, req *dto.Unwhite
 	return s.tx.Commit(tx)
 }
 
+func (s *WhatsappService) WhitelistUser(ctx context.Context, req *dto.WhitelistWhatsappUserReq) error {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	if err := s.waRepo.WhitelistUser(ctx, tx, req); err != nil {
+		return err
+	}
+
+	return s.tx.Commit(tx)
+}
+
+func (s *WhatsappService) UnwhitelistUser(ctx context.Context, req *dto.UnwhitelistWhatsappUserReq) error {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	if err := s.waRepo.UnwhitelistUser(ctx, tx, req); err != nil {
+		return err
+	}
+
+	return s.tx.Commit(tx)
+}
+
 func (s *WhatsappService) GetGroups(ctx context.Context, req *dto.GetWhatsappGroupReq) ([]*dto.WhatsappGroupInfo, error) {
 	allGroups, err := s.waRepo.GetGroups(ctx)
 	if err != nil {
@@ -92,15 +174,268 @@ func (s *WhatsappService) GetGroups(ctx context.Context, req *dto.GetWhatsappGro
 
 	isWhitelist := *req.Whitelist
 	filteredGroups := make([]*dto.WhatsappGroupInfo, 0)
+	joinedMap := make(map[types.JID]bool, len(allGroups))
 	for _, g := range allGroups {
+		joinedMap[g.JID] = true
+
 		_, inWhitelist := whitelistMap[g.JID]
 		if (isWhitelist && inWhitelist) || (!isWhitelist && !inWhitelist) {
 			filteredGroups = append(filteredGroups, dto.NewWhatsappGroupInfo(g))
 		}
 	}
 
-	// might need to make the logic above cleaner later
-	// but rn it'll do
+	if !isWhitelist {
+		return filteredGroups, nil
+	}
+
+	// the whitelisted groups the bot isn't in, until they're cleaned up
+	for _, j := range jids {
+		if !joinedMap[types.NewJID(j.JID, j.ServerJID)] {
+			filteredGroups = append(filteredGroups, dto.NewWhatsappLeftGroupInfo(j))
+		}
+	}
 
 	return filteredGroups, nil
 }
+
+func (s *WhatsappService) PreviewGroupInvite(ctx context.Context, req *dto.WhatsappGroupInviteReq) (*dto.WhatsappGroupInfo, error) {
+	info, err := s.waRepo.GetGroupInfoFromLink(ctx, req.Link)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewWhatsappGroupInfo(info), nil
+}
+
+func (s *WhatsappService) JoinGroup(ctx context.Context, req *dto.JoinWhatsappGroupReq) (*dto.WhatsappGroupInfo, error) {
+	// the info is only available from the link before joining
+	info, err := s.waRepo.GetGroupInfoFromLink(ctx, req.Link)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.waRepo.JoinGroupWithLink(ctx, req.Link)
+	if err != nil {
+		return nil, err
+	}
+	info.JID = group.To()
+
+	if !req.Whitelist {
+		return dto.NewWhatsappGroupInfo(info), nil
+	}
+
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	now := time.Now()
+	err = s.waRepo.WhitelistGroup(ctx, tx, &entity.WhatsappWhitelistedGroup{
+		JID:        group.User,
+		ServerJID:  group.Server,
+		Name:       info.Name,
+		Topic:      info.Topic,
+		LastSeenAt: &now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.groupSettingsRepo.Upsert(ctx, tx, dto.NewWhatsappGroupServersSetting(group, req.Servers)); err != nil {
+		return nil, err
+	}
+
+	if err := s.tx.Commit(tx); err != nil {
+		return nil, err
+	}
+
+	return dto.NewWhatsappGroupInfo(info), nil
+}
+
+func (s *WhatsappService) SyncGroup(ctx context.Context, info *types.GroupInfo) error {
+	return s.updateWhitelistedGroup(ctx, dto.NewWhatsappJID(info.JID), func(g *entity.WhatsappWhitelistedGroup) {
+		now := time.Now()
+		g.Name, g.Topic, g.LastSeenAt, g.LeftAt = info.Name, info.Topic, &now, nil
+	})
+}
+
+func (s *WhatsappService) UpdateGroupInfo(ctx context.Context, req *dto.WhatsappGroupInfoUpdate) error {
+	return s.updateWhitelistedGroup(ctx, req.Group, func(g *entity.WhatsappWhitelistedGroup) {
+		if req.Name != nil {
+			g.Name = *req.Name
+		}
+		if req.Topic != nil {
+			g.Topic = *req.Topic
+		}
+
+		// only the members get the group's events
+		now := time.Now()
+		g.LastSeenAt, g.LeftAt = &now, nil
+	})
+}
+
+func (s *WhatsappService) MarkGroupLeft(ctx context.Context, group dto.WhatsappJID) error {
+	if s.cfg.Bool(config.KeyWARemoveLeftGroups) {
+		return s.UnwhitelistGroup(ctx, &dto.UnwhitelistWhatsappGroupReq{User: group.User, Server: group.Server})
+	}
+
+	return s.updateWhitelistedGroup(ctx, group, func(g *entity.WhatsappWhitelistedGroup) {
+		if g.LeftAt == nil {
+			now := time.Now()
+			g.LeftAt = &now
+		}
+	})
+}
+
+func (s *WhatsappService) ReconcileGroups(ctx context.Context) error {
+	_, err := s.reconcileGroups(ctx, s.cfg.Bool(config.KeyWARemoveLeftGroups))
+	return err
+}
+
+func (s *WhatsappService) CleanupGroups(ctx context.Context) (*dto.WhatsappGroupsCleanupRes, error) {
+	removed, err := s.reconcileGroups(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.WhatsappGroupsCleanupRes{Removed: removed}, nil
+}
+
+// reconcileGroups refreshes the info of the whitelisted groups the bot is in,
+// the others are flagged as left, or unwhitelisted if remove is set. It
+// returns how many groups were unwhitelisted.
+func (s *WhatsappService) reconcileGroups(ctx context.Context, remove bool) (int64, error) {
+	joined, err := s.waRepo.GetGroups(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	joinedMap := make(map[types.JID]*types.GroupInfo, len(joined))
+	for _, g := range joined {
+		joinedMap[g.JID] = g
+	}
+
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	groups, err := s.waRepo.GetWhitelistedGroupJIDs(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var removed int64
+	for _, g := range groups {
+		info, ok := joinedMap[types.NewJID(g.JID, g.ServerJID)]
+		switch {
+		case ok:
+			g.Name, g.Topic, g.LastSeenAt, g.LeftAt = info.Name, info.Topic, &now, nil
+		case remove:
+			err := s.waRepo.UnwhitelistGroup(ctx, tx, &dto.UnwhitelistWhatsappGroupReq{User: g.JID, Server: g.ServerJID})
+			if err != nil {
+				return 0, err
+			}
+
+			removed++
+			continue
+		case g.LeftAt == nil:
+			g.LeftAt = &now
+		default:
+			continue
+		}
+
+		if err := s.waRepo.UpdateWhitelistedGroup(ctx, tx, g); err != nil {
+			return 0, err
+		}
+	}
+
+	return removed, s.tx.Commit(tx)
+}
+
+// updateWhitelistedGroup applies update to a whitelisted group, it's a no-op
+// if the group isn't whitelisted.
+func (s *WhatsappService) updateWhitelistedGroup(ctx context.Context, group dto.WhatsappJID, update func(g *entity.WhatsappWhitelistedGroup)) error {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	groups, err := s.waRepo.GetWhitelistedGroupJIDs(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	idx := slices.IndexFunc(groups, func(g *entity.WhatsappWhitelistedGroup) bool {
+		return g.JID == group.User && g.ServerJID == group.Server
+	})
+	if idx < 0 {
+		return nil
+	}
+
+	update(groups[idx])
+	if err := s.waRepo.UpdateWhitelistedGroup(ctx, tx, groups[idx]); err != nil {
+		return err
+	}
+
+	return s.tx.Commit(tx)
+}
+
+func (s *WhatsappService) GetGroupSettings(ctx context.Context, group dto.WhatsappJID) (*dto.WhatsappGroupSettings, error) {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	rows, err := s.groupSettingsRepo.GetAll(ctx, tx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewWhatsappGroupSettings(rows), nil
+}
+
+func (s *WhatsappService) UpdateGroupSettings(ctx context.Context, req *dto.UpdateWhatsappGroupSettingsReq) error {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	if err := s.groupSettingsRepo.Upsert(ctx, tx, req.ToEntities()...); err != nil {
+		return err
+	}
+
+	return s.tx.Commit(tx)
+}
+
+func (s *WhatsappService) SetGroupLanguage(ctx context.Context, group dto.WhatsappJID, lang i18n.Lang) error {
+	tx := s.tx.Begin(ctx)
+	defer func() {
+		if rbErr := s.tx.Rollback(tx); rbErr != nil {
+			slog.ErrorContext(ctx, rbErr.Error())
+		}
+	}()
+
+	err := s.groupSettingsRepo.Upsert(ctx, tx, &entity.WhatsappGroupSettings{
+		JID:       group.User,
+		ServerJID: group.Server,
+		Key:       constants.GroupLanguage,
+		Value:     lang.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.tx.Commit(tx)
+}
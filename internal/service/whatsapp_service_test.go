@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"exaroton-wa-bot/internal/config"
+	"exaroton-wa-bot/internal/database/entity"
+	"exaroton-wa-bot/internal/dto"
+	mockRepo "exaroton-wa-bot/internal/mocks/repository"
+	"testing"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mau.fi/whatsmeow/types"
+	"gorm.io/gorm"
+)
+
+type whatsappServiceMocks struct {
+	sqlTx         *mockRepo.MockSqlTx
+	waRepo        *mockRepo.MockIWhatsappRepo
+	groupSettings *mockRepo.MockIWhatsappGroupSettingsRepo
+	cfg           *koanf.Koanf
+}
+
+func setupTestWhatsappService(t *testing.T) (IWhatsappService, *whatsappServiceMocks) {
+	m := &whatsappServiceMocks{
+		sqlTx:         mockRepo.NewMockSqlTx(t),
+		waRepo:        mockRepo.NewMockIWhatsappRepo(t),
+		groupSettings: mockRepo.NewMockIWhatsappGroupSettingsRepo(t),
+		cfg:           koanf.New("."),
+	}
+
+	m.sqlTx.EXPECT().Begin(mock.Anything).Return(new(gorm.DB)).Maybe()
+	m.sqlTx.EXPECT().Rollback(mock.Anything).Return(nil).Maybe()
+
+	svcTmpl := &svcTmpl{
+		cfg: &config.Cfg{Koanf: m.cfg},
+		tx:  m.sqlTx,
+	}
+
+	return NewWhatsappService(svcTmpl, m.waRepo, m.groupSettings), m
+}
+
+func groupInfo(user, name, topic string) *types.GroupInfo {
+	info := &types.GroupInfo{JID: types.NewJID(user, types.GroupServer)}
+	info.Name, info.Topic = name, topic
+
+	return info
+}
+
+func TestWhatsappService_GetGroups(t *testing.T) {
+	seen := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	whitelist, notWhitelist := true, false
+
+	setup := func(t *testing.T) IWhatsappService {
+		svc, m := setupTestWhatsappService(t)
+		m.waRepo.EXPECT().GetGroups(mock.Anything).Return([]*types.GroupInfo{
+			groupInfo("1", "Joined", ""),
+			groupInfo("2", "Other", ""),
+		}, nil)
+		m.waRepo.EXPECT().GetWhitelistedGroupJIDs(mock.Anything, mock.Anything).Return([]*entity.WhatsappWhitelistedGroup{
+			{JID: "1", ServerJID: types.GroupServer, Name: "Joined"},
+			{JID: "3", ServerJID: types.GroupServer, Name: "Gone", Topic: "old", LastSeenAt: &seen},
+		}, nil)
+
+		return svc
+	}
+
+	t.Run("whitelisted groups include the left ones", func(t *testing.T) {
+		groups, err := setup(t).GetGroups(context.Background(), &dto.GetWhatsappGroupReq{Whitelist: &whitelist})
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+
+		assert.Equal(t, "Joined", groups[0].Name)
+		assert.False(t, groups[0].Left)
+
+		assert.Equal(t, "3", groups[1].JIDUser)
+		assert.Equal(t, "Gone", groups[1].Name)
+		assert.Equal(t, "old", groups[1].Topic)
+		assert.True(t, groups[1].Left)
+		assert.Equal(t, &seen, groups[1].LastSeenAt)
+	})
+
+	t.Run("non-whitelisted groups", func(t *testing.T) {
+		groups, err := setup(t).GetGroups(context.Background(), &dto.GetWhatsappGroupReq{Whitelist: &notWhitelist})
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, "Other", groups[0].Name)
+	})
+}
+
+func TestWhatsappService_WhitelistGroup(t *testing.T) {
+	svc, m := setupTestWhatsappService(t)
+
+	m.waRepo.EXPECT().GetGroupInfo(mock.Anything, dto.WhatsappJID{User: "1", Server: types.GroupServer}).
+		Return(groupInfo("1", "Group", "Rules"), nil)
+	m.waRepo.EXPECT().WhitelistGroup(mock.Anything, mock.Anything, mock.MatchedBy(func(g *entity.WhatsappWhitelistedGroup) bool {
+		return g.JID == "1" && g.Name == "Group" && g.Topic == "Rules" && g.LastSeenAt != nil && g.LeftAt == nil
+	})).Return(nil)
+	m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+
+	require.NoError(t, svc.WhitelistGroup(context.Background(), &dto.WhitelistWhatsappGroupReq{User: "1", Server: types.GroupServer}))
+}
+
+func TestWhatsappService_UpdateGroupInfo(t *testing.T) {
+	left := time.Now().Add(-time.Hour)
+	group := dto.WhatsappJID{User: "1", Server: types.GroupServer}
+	name := "Renamed"
+
+	t.Run("whitelisted group", func(t *testing.T) {
+		svc, m := setupTestWhatsappService(t)
+
+		m.waRepo.EXPECT().GetWhitelistedGroupJIDs(mock.Anything, mock.Anything).Return([]*entity.WhatsappWhitelistedGroup{
+			{JID: "1", ServerJID: types.GroupServer, Name: "Group", Topic: "Rules", LeftAt: &left},
+		}, nil)
+		m.waRepo.EXPECT().UpdateWhitelistedGroup(mock.Anything, mock.Anything, mock.MatchedBy(func(g *entity.WhatsappWhitelistedGroup) bool {
+			// the topic is unchanged, and the group is seen again
+			return g.Name == "Renamed" && g.Topic == "Rules" && g.LastSeenAt != nil && g.LeftAt == nil
+		})).Return(nil)
+		m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+
+		require.NoError(t, svc.UpdateGroupInfo(context.Background(), &dto.WhatsappGroupInfoUpdate{Group: group, Name: &name}))
+	})
+
+	t.Run("not whitelisted group is ignored", func(t *testing.T) {
+		svc, m := setupTestWhatsappService(t)
+
+		m.waRepo.EXPECT().GetWhitelistedGroupJIDs(mock.Anything, mock.Anything).Return([]*entity.WhatsappWhitelistedGroup{
+			{JID: "2", ServerJID: types.GroupServer},
+		}, nil)
+
+		require.NoError(t, svc.UpdateGroupInfo(context.Background(), &dto.WhatsappGroupInfoUpdate{Group: group, Name: &name}))
+	})
+}
+
+func TestWhatsappService_MarkGroupLeft(t *testing.T) {
+	group := dto.WhatsappJID{User: "1", Server: types.GroupServer}
+
+	t.Run("flagged", func(t *testing.T) {
+		svc, m := setupTestWhatsappService(t)
+
+		m.waRepo.EXPECT().GetWhitelistedGroupJIDs(mock.Anything, mock.Anything).Return([]*entity.WhatsappWhitelistedGroup{
+			{JID: "1", ServerJID: types.GroupServer, Name: "Group"},
+		}, nil)
+		m.waRepo.EXPECT().UpdateWhitelistedGroup(mock.Anything, mock.Anything, mock.MatchedBy(func(g *entity.WhatsappWhitelistedGroup) bool {
+			return g.Name == "Group" && g.LeftAt != nil
+		})).Return(nil)
+		m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+
+		require.NoError(t, svc.MarkGroupLeft(context.Background(), group))
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		svc, m := setupTestWhatsappService(t)
+		require.NoError(t, m.cfg.Set(config.KeyWARemoveLeftGroups, true))
+
+		m.waRepo.EXPECT().UnwhitelistGroup(mock.Anything, mock.Anything, &dto.UnwhitelistWhatsappGroupReq{User: "1", Server: types.GroupServer}).Return(nil)
+		m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+
+		require.NoError(t, svc.MarkGroupLeft(context.Background(), group))
+	})
+}
+
+func TestWhatsappService_ReconcileGroups(t *testing.T) {
+	left := time.Now().Add(-time.Hour)
+
+	setup := func(t *testing.T) (IWhatsappService, *whatsappServiceMocks) {
+		svc, m := setupTestWhatsappService(t)
+
+		m.waRepo.EXPECT().GetGroups(mock.Anything).Return([]*types.GroupInfo{groupInfo("1", "Renamed", "New rules")}, nil)
+		m.waRepo.EXPECT().GetWhitelistedGroupJIDs(mock.Anything, mock.Anything).Return([]*entity.WhatsappWhitelistedGroup{
+			{JID: "1", ServerJID: types.GroupServer, Name: "Group", LeftAt: &left},
+			{JID: "2", ServerJID: types.GroupServer, Name: "Gone"},
+			{JID: "3", ServerJID: types.GroupServer, Name: "Gone before", LeftAt: &left},
+		}, nil)
+		m.waRepo.EXPECT().UpdateWhitelistedGroup(mock.Anything, mock.Anything, mock.MatchedBy(func(g *entity.WhatsappWhitelistedGroup) bool {
+			return g.JID == "1" && g.Name == "Renamed" && g.Topic == "New rules" && g.LastSeenAt != nil && g.LeftAt == nil
+		})).Return(nil)
+		m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+
+		return svc, m
+	}
+
+	t.Run("the groups the bot isn't in are flagged", func(t *testing.T) {
+		svc, m := setup(t)
+
+		// the group already flagged keeps its left time
+		m.waRepo.EXPECT().UpdateWhitelistedGroup(mock.Anything, mock.Anything, mock.MatchedBy(func(g *entity.WhatsappWhitelistedGroup) bool {
+			return g.JID == "2" && g.LeftAt != nil
+		})).Return(nil)
+
+		require.NoError(t, svc.ReconcileGroups(context.Background()))
+	})
+
+	t.Run("cleanup unwhitelists them", func(t *testing.T) {
+		svc, m := setup(t)
+
+		m.waRepo.EXPECT().UnwhitelistGroup(mock.Anything, mock.Anything, &dto.UnwhitelistWhatsappGroupReq{User: "2", Server: types.GroupServer}).Return(nil)
+		m.waRepo.EXPECT().UnwhitelistGroup(mock.Anything, mock.Anything, &dto.UnwhitelistWhatsappGroupReq{User: "3", Server: types.GroupServer}).Return(nil)
+
+		res, err := svc.CleanupGroups(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), res.Removed)
+	})
+}
+
+func TestWhatsappService_JoinGroup(t *testing.T) {
+	link := "https://chat.whatsapp.com/AbCdEfGhIjKlMnOpQrStUv"
+	group := dto.WhatsappJID{User: "1", Server: types.GroupServer}
+
+	setup := func(t *testing.T) (IWhatsappService, *whatsappServiceMocks) {
+		svc, m := setupTestWhatsappService(t)
+
+		// the invite info has no jid until joined
+		m.waRepo.EXPECT().GetGroupInfoFromLink(mock.Anything, link).Return(groupInfo("", "Group", "Rules"), nil)
+		m.waRepo.EXPECT().JoinGroupWithLink(mock.Anything, link).Return(group, nil)
+
+		return svc, m
+	}
+
+	t.Run("join only", func(t *testing.T) {
+		svc, _ := setup(t)
+
+		res, err := svc.JoinGroup(context.Background(), &dto.JoinWhatsappGroupReq{Link: link})
+		require.NoError(t, err)
+		assert.Equal(t, "1", res.JIDUser)
+		assert.Equal(t, "Group", res.Name)
+	})
+
+	t.Run("join and whitelist", func(t *testing.T) {
+		svc, m := setup(t)
+
+		m.waRepo.EXPECT().WhitelistGroup(mock.Anything, mock.Anything, mock.MatchedBy(func(g *entity.WhatsappWhitelistedGroup) bool {
+			return g.JID == "1" && g.Name == "Group" && g.Topic == "Rules"
+		})).Return(nil)
+		m.groupSettings.EXPECT().Upsert(mock.Anything, mock.Anything, []*entity.WhatsappGroupSettings{dto.NewWhatsappGroupServersSetting(group, []string{"a", "c"})}).Return(nil)
+		m.sqlTx.EXPECT().Commit(mock.Anything).Return(nil)
+
+		res, err := svc.JoinGroup(context.Background(), &dto.JoinWhatsappGroupReq{Link: link, Whitelist: true, Servers: []string{"a", "c"}})
+		require.NoError(t, err)
+		assert.Equal(t, "1", res.JIDUser)
+	})
+}
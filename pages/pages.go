@@ -12,9 +12,12 @@ const (
 	WhatsappLogin       = "whatsapp_login.jet"
 	WhatsappLoginQR     = "whatsapp_login_qr.jet"
 	WhatsappLoginNumber = "whatsapp_login_number.jet"
+	WhatsappDevices     = "whatsapp_devices.jet"
 
-	SettingsExaroton = "settings_exaroton.jet"
-	SettingsWhatsapp = "settings_whatsapp.jet"
+	SettingsExaroton      = "settings_exaroton.jet"
+	SettingsWhatsapp      = "settings_whatsapp.jet"
+	SettingsWhatsappRoles = "settings_whatsapp_roles.jet"
+	SettingsTemplates     = "settings_templates.jet"
 )
 
 // ==============================================================================